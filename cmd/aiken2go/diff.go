@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/pgrange/aiken_to_go/pkg/blueprint"
+)
+
+// runDiff implements `aiken2go diff old.json new.json`. It prints one line
+// per change and exits with status 1 when a change is breaking, or 2 when
+// the blueprints can't be loaded.
+func runDiff(args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s diff <old.json> <new.json>\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Compare two blueprints and report whether data encoded for the old one\n")
+		fmt.Fprintf(os.Stderr, "still decodes with the new one. Exits with status 1 on breaking changes.\n")
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, "Error: two blueprint files are required")
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	oldBP, err := blueprint.LoadBlueprint(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading blueprint: %v\n", err)
+		os.Exit(2)
+	}
+	newBP, err := blueprint.LoadBlueprint(fs.Arg(1))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading blueprint: %v\n", err)
+		os.Exit(2)
+	}
+
+	changes := blueprint.Diff(oldBP, newBP)
+	breaking := 0
+	for _, c := range changes {
+		fmt.Println(c)
+		if c.Compatibility == blueprint.Breaking {
+			breaking++
+		}
+	}
+
+	switch {
+	case len(changes) == 0:
+		fmt.Println("No changes")
+	case breaking > 0:
+		fmt.Printf("%d changes, %d breaking\n", len(changes), breaking)
+		os.Exit(1)
+	default:
+		fmt.Printf("%d changes, all wire-compatible\n", len(changes))
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pgrange/aiken_to_go/pkg/blueprint"
+)
+
+// genFlags are the code generation flags shared by the generate and watch
+// commands.
+type genFlags struct {
+	outfile         string
+	packageName     string
+	generics        bool
+	unknownVariants bool
+	constEnums      bool
+	migrations      bool
+	strict          bool
+	templates       string
+}
+
+func (f *genFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.outfile, "o", "", "Output file path, or directory to write types.go into (required)")
+	fs.StringVar(&f.outfile, "outfile", "", "Output file path, or directory to write types.go into (required)")
+	fs.StringVar(&f.packageName, "p", "contracts", "Go package name (default: from aiken.toml for a project directory)")
+	fs.StringVar(&f.packageName, "package", "contracts", "Go package name (default: from aiken.toml for a project directory)")
+	fs.BoolVar(&f.generics, "generics", false, "Emit Go type parameters for Option, List, Pairs and parametric types")
+	fs.BoolVar(&f.unknownVariants, "unknown-variants", false, "Decode undeclared enum constructors into an XxxUnknown variant instead of failing")
+	fs.BoolVar(&f.constEnums, "const-enums", false, "Emit enums whose constructors have no fields as uint8 constants with String and ParseXxx")
+	fs.BoolVar(&f.migrations, "migrations", false, "Emit From<Old> conversions between versions of a type (v0_1/types/X to v0_3/types/X)")
+	fs.BoolVar(&f.strict, "strict", false, "Fail if any definition couldn't be fully supported")
+	fs.StringVar(&f.templates, "templates", "", "Directory of *.tmpl files overriding the built-in code generation templates")
+}
+
+// defaultPackage takes the package name from the project when the only
+// input is an Aiken project directory and neither -p nor -package was given.
+func (f *genFlags) defaultPackage(fs *flag.FlagSet, infiles []string) {
+	explicit := false
+	fs.Visit(func(fl *flag.Flag) {
+		explicit = explicit || fl.Name == "p" || fl.Name == "package"
+	})
+	if explicit || len(infiles) != 1 {
+		return
+	}
+	if info, err := os.Stat(infiles[0]); err != nil || !info.IsDir() {
+		return
+	}
+	if project, err := blueprint.LoadProject(infiles[0]); err == nil && project.PackageName() != "" {
+		f.packageName = project.PackageName()
+	}
+}
+
+// generate loads and merges the blueprints and generates their Go code,
+// printing the generator's diagnostics. With -strict, any diagnostic is an
+// error.
+func (f *genFlags) generate(infiles []string) (string, error) {
+	bp, err := blueprint.LoadBlueprints(infiles...)
+	if err != nil {
+		return "", err
+	}
+	opts := blueprint.GeneratorOptions{
+		PackageName:     f.packageName,
+		Generics:        f.generics,
+		UnknownVariants: f.unknownVariants,
+		ConstEnums:      f.constEnums,
+		Migrations:      f.migrations,
+	}
+	if f.templates != "" {
+		info, err := os.Stat(f.templates)
+		if err != nil {
+			return "", fmt.Errorf("-templates: %w", err)
+		}
+		if !info.IsDir() {
+			return "", fmt.Errorf("-templates: %s is not a directory", f.templates)
+		}
+		opts.TemplateFS = os.DirFS(f.templates)
+	}
+	gen := blueprint.NewGenerator(bp, opts)
+	code, diagnostics, err := gen.Generate()
+	if err != nil {
+		return "", fmt.Errorf("generating code: %w", err)
+	}
+	for _, d := range diagnostics {
+		fmt.Fprintln(os.Stderr, d)
+	}
+	if f.strict && len(diagnostics) > 0 {
+		return "", fmt.Errorf("%d unsupported schemas (-strict)", len(diagnostics))
+	}
+	return code, nil
+}
+
+// outputPath returns the file to write, types.go inside outfile when it
+// names a directory.
+func outputPath(outfile string) string {
+	if info, err := os.Stat(outfile); (err == nil && info.IsDir()) || strings.HasSuffix(outfile, "/") {
+		return filepath.Join(outfile, "types.go")
+	}
+	return outfile
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames
+// it into place, so that readers never see a partial file.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// errorMessage formats an error for the terminal, listing merge conflicts
+// one per line.
+func errorMessage(err error) string {
+	var mergeErr *blueprint.MergeError
+	if errors.As(err, &mergeErr) {
+		lines := []string{"Error merging blueprints:"}
+		for _, c := range mergeErr.Conflicts {
+			lines = append(lines, "  "+c.String())
+		}
+		return strings.Join(lines, "\n")
+	}
+	return "Error: " + err.Error()
+}
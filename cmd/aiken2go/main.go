@@ -4,76 +4,105 @@
 //
 //	aiken2go plutus.json -o types.go
 //	aiken2go plutus.json -o types.go -p mypackage
+//	aiken2go plutus.json -o types.go -generics
+//	aiken2go plutus.json -o types.go -unknown-variants
+//	aiken2go plutus.json -o types.go -const-enums
+//	aiken2go plutus.json -o types.go -migrations
+//	aiken2go plutus.json -o types.go -strict
+//	aiken2go plutus.json -o types.go -templates ./templates
+//	aiken2go -o out/ core.json oracle.json treasury.json
+//	aiken2go -o types.go ./contracts
+//	aiken2go -check -o types.go plutus.json
+//	aiken2go watch -o types.go -build ./contracts
+//	aiken2go diff old.json new.json
 package main
 
 import (
 	"flag"
 	"fmt"
 	"os"
-
-	"github.com/pgrange/aiken_to_go/pkg/blueprint"
+	"strings"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "diff":
+			runDiff(os.Args[2:])
+			return
+		case "watch":
+			runWatch(os.Args[2:])
+			return
+		}
+	}
+
 	var (
-		outfile     string
-		packageName string
+		gen   genFlags
+		check bool
+		quiet bool
 	)
 
-	flag.StringVar(&outfile, "o", "", "Output file path (required)")
-	flag.StringVar(&outfile, "outfile", "", "Output file path (required)")
-	flag.StringVar(&packageName, "p", "contracts", "Go package name")
-	flag.StringVar(&packageName, "package", "contracts", "Go package name")
+	gen.register(flag.CommandLine)
+	flag.BoolVar(&check, "check", false, "Don't write the output; exit with status 1 if it is missing or out of date")
+	flag.BoolVar(&quiet, "quiet", false, "Don't print anything on success")
 
 	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: %s [options] <plutus.json>\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage: %s [options] <plutus.json | project dir>...\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s watch [options] <plutus.json | project dir>\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s diff <old.json> <new.json>\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Generate Go types from Aiken's CIP-0057 Plutus Blueprint.\n\n")
 		fmt.Fprintf(os.Stderr, "Options:\n")
 		flag.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\nExample:\n")
 		fmt.Fprintf(os.Stderr, "  %s -o types.go plutus.json\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -o types.go -p mypackage plutus.json\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -o out/ core.json oracle.json treasury.json\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -o types.go ./contracts\n", os.Args[0])
 	}
 
 	flag.Parse()
 
 	if flag.NArg() < 1 {
-		fmt.Fprintln(os.Stderr, "Error: plutus.json file is required")
+		fmt.Fprintln(os.Stderr, "Error: at least one plutus.json file or Aiken project directory is required")
 		flag.Usage()
 		os.Exit(1)
 	}
 
-	if outfile == "" {
+	if gen.outfile == "" {
 		fmt.Fprintln(os.Stderr, "Error: output file (-o) is required")
 		flag.Usage()
 		os.Exit(1)
 	}
 
-	infile := flag.Arg(0)
+	infiles := flag.Args()
+	gen.defaultPackage(flag.CommandLine, infiles)
 
-	// Load blueprint
-	bp, err := blueprint.LoadBlueprint(infile)
+	code, err := gen.generate(infiles)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading blueprint: %v\n", err)
+		fmt.Fprintln(os.Stderr, errorMessage(err))
 		os.Exit(1)
 	}
 
-	// Generate code
-	gen := blueprint.NewGenerator(bp, blueprint.GeneratorOptions{
-		PackageName: packageName,
-	})
+	outfile := outputPath(gen.outfile)
 
-	code, err := gen.Generate()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error generating code: %v\n", err)
-		os.Exit(1)
+	if check {
+		existing, err := os.ReadFile(outfile)
+		if err != nil || string(existing) != code {
+			fmt.Fprintf(os.Stderr, "%s is out of date with %s; run aiken2go to regenerate it\n", outfile, strings.Join(infiles, ", "))
+			os.Exit(1)
+		}
+		if !quiet {
+			fmt.Printf("%s is up to date\n", outfile)
+		}
+		return
 	}
 
-	// Write output
-	if err := os.WriteFile(outfile, []byte(code), 0644); err != nil {
+	if err := writeFileAtomic(outfile, []byte(code)); err != nil {
 		fmt.Fprintf(os.Stderr, "Error writing output file: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf("Generated %s from %s\n", outfile, infile)
+	if !quiet {
+		fmt.Printf("Generated %s from %s\n", outfile, strings.Join(infiles, ", "))
+	}
 }
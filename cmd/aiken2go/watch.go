@@ -0,0 +1,177 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pgrange/aiken_to_go/pkg/blueprint"
+)
+
+// runWatch implements `aiken2go watch`. It polls the blueprint and
+// regenerates the output whenever it changes. Given an Aiken project
+// directory, it watches the project's blueprint and, with -build, runs
+// `aiken build` whenever aiken.toml or an .ak source changes. Errors are
+// reported and watching goes on.
+func runWatch(args []string) {
+	var (
+		gen      genFlags
+		interval time.Duration
+		build    bool
+		quiet    bool
+	)
+
+	flags := flag.NewFlagSet("watch", flag.ExitOnError)
+	gen.register(flags)
+	flags.DurationVar(&interval, "interval", 500*time.Millisecond, "How often to poll for changes")
+	flags.BoolVar(&build, "build", false, "Run `aiken build` when the sources of the project directory change")
+	flags.BoolVar(&quiet, "quiet", false, "Only print errors")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s watch [options] <plutus.json | project dir>\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Regenerate Go types whenever the blueprint changes.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "Error: a plutus.json file or an Aiken project directory is required")
+		flags.Usage()
+		os.Exit(1)
+	}
+	if gen.outfile == "" {
+		fmt.Fprintln(os.Stderr, "Error: output file (-o) is required")
+		flags.Usage()
+		os.Exit(1)
+	}
+
+	gen.defaultPackage(flags, flags.Args())
+	blueprintPath, projectDir := flags.Arg(0), ""
+	if info, err := os.Stat(blueprintPath); err == nil && info.IsDir() {
+		project, err := blueprint.LoadProject(blueprintPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, errorMessage(err))
+			os.Exit(1)
+		}
+		projectDir = project.Dir
+		blueprintPath = project.BlueprintPath
+	}
+	if build && projectDir == "" {
+		fmt.Fprintln(os.Stderr, "Error: -build requires an Aiken project directory")
+		os.Exit(1)
+	}
+
+	w := &watcher{gen: gen, blueprintPath: blueprintPath, projectDir: projectDir, quiet: quiet}
+	w.watch(interval, build)
+}
+
+// watcher regenerates the output of one blueprint.
+type watcher struct {
+	gen           genFlags
+	blueprintPath string
+	projectDir    string
+	quiet         bool
+}
+
+// fileStamp is what polling compares to detect a change.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func (w *watcher) watch(interval time.Duration, build bool) {
+	var sources map[string]fileStamp
+	if build {
+		sources = w.sourceStamps()
+		w.build()
+	}
+	stamps := w.blueprintStamps()
+	w.regenerate()
+
+	for range time.Tick(interval) {
+		if build {
+			if current := w.sourceStamps(); !maps.Equal(current, sources) {
+				sources = current
+				w.build()
+			}
+		}
+		if current := w.blueprintStamps(); !maps.Equal(current, stamps) {
+			stamps = current
+			w.regenerate()
+		}
+	}
+}
+
+// regenerate rewrites the output if the blueprint generates different code.
+func (w *watcher) regenerate() {
+	input := w.blueprintPath
+	if w.projectDir != "" {
+		input = w.projectDir
+	}
+	code, err := w.gen.generate([]string{input})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, errorMessage(err))
+		return
+	}
+	outfile := outputPath(w.gen.outfile)
+	if existing, err := os.ReadFile(outfile); err == nil && string(existing) == code {
+		return
+	}
+	if err := writeFileAtomic(outfile, []byte(code)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing output file: %v\n", err)
+		return
+	}
+	if !w.quiet {
+		fmt.Printf("Generated %s from %s\n", outfile, w.blueprintPath)
+	}
+}
+
+// build runs `aiken build` in the project directory.
+func (w *watcher) build() {
+	cmd := exec.Command("aiken", "build")
+	cmd.Dir = w.projectDir
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error running aiken build: %v\n", err)
+	}
+}
+
+func (w *watcher) blueprintStamps() map[string]fileStamp {
+	stamps := make(map[string]fileStamp, 1)
+	if info, err := os.Stat(w.blueprintPath); err == nil {
+		stamps[w.blueprintPath] = fileStamp{info.ModTime(), info.Size()}
+	}
+	return stamps
+}
+
+// sourceStamps covers aiken.toml and the .ak files of the project, leaving
+// out the build directory and hidden directories.
+func (w *watcher) sourceStamps() map[string]fileStamp {
+	stamps := make(map[string]fileStamp)
+	filepath.WalkDir(w.projectDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != w.projectDir && (d.Name() == "build" || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != "aiken.toml" && filepath.Ext(path) != ".ak" {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			stamps[path] = fileStamp{info.ModTime(), info.Size()}
+		}
+		return nil
+	})
+	return stamps
+}
@@ -22,7 +22,7 @@ func TestAdvancedTypes(t *testing.T) {
 	}
 
 	gen := NewGenerator(bp, GeneratorOptions{PackageName: "advanced"})
-	code, err := gen.Generate()
+	code, _, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("failed to generate code: %v", err)
 	}
@@ -135,7 +135,7 @@ func TestAdvancedTypesCompiles(t *testing.T) {
 	}
 
 	gen := NewGenerator(bp, GeneratorOptions{PackageName: "advanced"})
-	code, err := gen.Generate()
+	code, _, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("failed to generate code: %v", err)
 	}
@@ -148,10 +148,6 @@ func TestAdvancedTypesCompiles(t *testing.T) {
 	goMod := `module testmod
 
 go 1.21
-
-require github.com/fxamacker/cbor/v2 v2.8.0
-
-require github.com/x448/float16 v0.8.4 // indirect
 `
 	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
 		t.Fatalf("failed to write go.mod: %v", err)
@@ -190,7 +186,7 @@ func TestAdvancedTypesRoundTrip(t *testing.T) {
 	}
 
 	gen := NewGenerator(bp, GeneratorOptions{PackageName: "types"})
-	code, err := gen.Generate()
+	code, _, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("failed to generate code: %v", err)
 	}
@@ -203,6 +199,7 @@ func TestAdvancedTypesRoundTrip(t *testing.T) {
 	testProgram := `package main
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 	"os"
@@ -211,7 +208,8 @@ import (
 )
 
 func testRoundTrip[T interface {
-	ToPlutusData() (types.PlutusData, error)
+	types.PlutusMarshaler
+	types.CBORAppender
 }](name string, original T, decode func(types.PlutusData) (T, error)) error {
 	pd, err := original.ToPlutusData()
 	if err != nil {
@@ -223,6 +221,15 @@ func testRoundTrip[T interface {
 		return fmt.Errorf("%s MarshalCBOR: %v", name, err)
 	}
 
+	// The direct encoder must write the same bytes
+	direct, err := original.AppendCBOR(nil)
+	if err != nil {
+		return fmt.Errorf("%s AppendCBOR: %v", name, err)
+	}
+	if !bytes.Equal(direct, cborBytes) {
+		return fmt.Errorf("%s AppendCBOR: got %x, want %x", name, direct, cborBytes)
+	}
+
 	var decodedPd types.PlutusData
 	if err := decodedPd.UnmarshalCBOR(cborBytes); err != nil {
 		return fmt.Errorf("%s UnmarshalCBOR: %v", name, err)
@@ -372,10 +379,6 @@ func main() {
 	goModContent := `module testpkg
 
 go 1.21
-
-require github.com/fxamacker/cbor/v2 v2.8.0
-
-require github.com/x448/float16 v0.8.4 // indirect
 `
 	goModFile := filepath.Join(tmpDir, "go.mod")
 	if err := os.WriteFile(goModFile, []byte(goModContent), 0644); err != nil {
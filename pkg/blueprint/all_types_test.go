@@ -31,7 +31,7 @@ func TestAllTypesRoundTrip(t *testing.T) {
 	}
 
 	gen := NewGenerator(bp, GeneratorOptions{PackageName: "types"})
-	code, err := gen.Generate()
+	code, _, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("failed to generate code: %v", err)
 	}
@@ -46,6 +46,7 @@ func TestAllTypesRoundTrip(t *testing.T) {
 	testProgram := `package main
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 	"os"
@@ -54,7 +55,8 @@ import (
 )
 
 func testRoundTrip[T interface {
-	ToPlutusData() (types.PlutusData, error)
+	types.PlutusMarshaler
+	types.CBORAppender
 }](name string, original T, decode func(types.PlutusData) (T, error)) error {
 	// Serialize to PlutusData
 	pd, err := original.ToPlutusData()
@@ -68,6 +70,15 @@ func testRoundTrip[T interface {
 		return fmt.Errorf("%s MarshalCBOR: %v", name, err)
 	}
 
+	// The direct encoder must write the same bytes
+	direct, err := original.AppendCBOR(nil)
+	if err != nil {
+		return fmt.Errorf("%s AppendCBOR: %v", name, err)
+	}
+	if !bytes.Equal(direct, cborBytes) {
+		return fmt.Errorf("%s AppendCBOR: got %x, want %x", name, direct, cborBytes)
+	}
+
 	// Deserialize from CBOR
 	var decodedPd types.PlutusData
 	if err := decodedPd.UnmarshalCBOR(cborBytes); err != nil {
@@ -255,6 +266,29 @@ func main() {
 		failed = true
 	}
 
+	// Test the generic helpers, decoding through the enum interface
+	encoded, err := types.EncodeCBOR[types.StringValidatorStatus](statusPending)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "EncodeCBOR:", err)
+		failed = true
+	} else if decodedStatus, err := types.DecodeCBOR[types.StringValidatorStatus](encoded); err != nil {
+		fmt.Fprintln(os.Stderr, "DecodeCBOR:", err)
+		failed = true
+	} else if _, ok := decodedStatus.(types.StringValidatorStatusPending); !ok {
+		fmt.Fprintf(os.Stderr, "DecodeCBOR: expected StringValidatorStatusPending, got %T\n", decodedStatus)
+		failed = true
+	}
+	if pd, err := types.Encode(nested); err != nil {
+		fmt.Fprintln(os.Stderr, "Encode:", err)
+		failed = true
+	} else if decodedNested, err := types.Decode[types.StringValidatorNested](pd); err != nil {
+		fmt.Fprintln(os.Stderr, "Decode:", err)
+		failed = true
+	} else if decodedNested.Count.Cmp(nested.Count) != 0 {
+		fmt.Fprintln(os.Stderr, "Decode: Count differs from original")
+		failed = true
+	}
+
 	if failed {
 		os.Exit(1)
 	}
@@ -272,10 +306,6 @@ func main() {
 	goModContent := `module testpkg
 
 go 1.21
-
-require github.com/fxamacker/cbor/v2 v2.8.0
-
-require github.com/x448/float16 v0.8.4 // indirect
 `
 	goModFile := filepath.Join(tmpDir, "go.mod")
 	if err := os.WriteFile(goModFile, []byte(goModContent), 0644); err != nil {
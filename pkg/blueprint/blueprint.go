@@ -4,9 +4,12 @@
 package blueprint
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 )
 
 // Blueprint represents a CIP-0057 Plutus Blueprint generated by Aiken.
@@ -14,6 +17,16 @@ type Blueprint struct {
 	Preamble    Preamble           `json:"preamble"`
 	Validators  []Validator        `json:"validators"`
 	Definitions map[string]*Schema `json:"definitions"`
+
+	// Sources are the files the blueprint was loaded from, recorded in
+	// the header of generated code.
+	Sources []Source `json:"-"`
+}
+
+// Source identifies a blueprint file by path and content.
+type Source struct {
+	Path   string
+	SHA256 string // hex-encoded SHA-256 of the file
 }
 
 // Preamble contains metadata about the Aiken project.
@@ -48,8 +61,20 @@ type Parameter struct {
 	Schema Schema `json:"schema"`
 }
 
-// LoadBlueprint reads and parses a plutus.json file.
+// LoadBlueprint reads and parses a plutus.json file. Given an Aiken project
+// directory, it loads the project's blueprint (see LoadProject).
 func LoadBlueprint(path string) (*Blueprint, error) {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		project, err := LoadProject(path)
+		if err != nil {
+			return nil, err
+		}
+		return project.LoadBlueprint()
+	}
+	return loadBlueprintFile(path)
+}
+
+func loadBlueprintFile(path string) (*Blueprint, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("reading blueprint file: %w", err)
@@ -60,6 +85,8 @@ func LoadBlueprint(path string) (*Blueprint, error) {
 		return nil, fmt.Errorf("parsing blueprint JSON: %w", err)
 	}
 
+	sum := sha256.Sum256(data)
+	bp.Sources = []Source{{Path: filepath.ToSlash(path), SHA256: hex.EncodeToString(sum[:])}}
 	return &bp, nil
 }
 
@@ -0,0 +1,165 @@
+package blueprint
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// boolBlueprint returns a blueprint with a flags/Flag type shaped like Bool
+// and a flags/Settings record holding a Bool and a Flag.
+func boolBlueprint() map[string]interface{} {
+	boolSchema := map[string]interface{}{
+		"title": "Bool",
+		"anyOf": []map[string]interface{}{
+			{"title": "False", "dataType": "constructor", "index": 0, "fields": []interface{}{}},
+			{"title": "True", "dataType": "constructor", "index": 1, "fields": []interface{}{}},
+		},
+	}
+	return map[string]interface{}{
+		"preamble":   map[string]interface{}{"title": "test/flags"},
+		"validators": []interface{}{},
+		"definitions": map[string]interface{}{
+			"Bool":       boolSchema,
+			"flags/Flag": boolSchema,
+			"flags/Settings": map[string]interface{}{
+				"title": "Settings",
+				"anyOf": []map[string]interface{}{{
+					"title": "Settings", "dataType": "constructor", "index": 0,
+					"fields": []map[string]interface{}{
+						{"title": "enabled", "$ref": "#/definitions/Bool"},
+						{"title": "flag", "$ref": "#/definitions/flags~1Flag"},
+					},
+				}},
+			},
+		},
+	}
+}
+
+// TestGeneratedCode_BoolDecoding tests that every generated Bool decoder
+// rejects constructor indexes other than 0 and 1, and fields.
+func TestGeneratedCode_BoolDecoding(t *testing.T) {
+	data, err := json.Marshal(boolBlueprint())
+	if err != nil {
+		t.Fatal(err)
+	}
+	blueprintPath := filepath.Join(t.TempDir(), "plutus.json")
+	if err := os.WriteFile(blueprintPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testProgram := `package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"testpkg/types"
+)
+
+func decode(h string) (types.PlutusData, []byte) {
+	data, _ := hex.DecodeString(h)
+	var pd types.PlutusData
+	if err := pd.UnmarshalCBOR(data); err != nil {
+		fmt.Fprintf(os.Stderr, "UnmarshalCBOR %s: %v\n", h, err)
+		os.Exit(1)
+	}
+	return pd, data
+}
+
+func expectError(what, h string, err error) {
+	if err == nil {
+		fmt.Fprintf(os.Stderr, "%s accepted %s\n", what, h)
+		os.Exit(1)
+	}
+}
+
+func main() {
+	for _, h := range []string{"d87b80", "d87a9f01ff"} {
+		pd, data := decode(h)
+		var flag types.FlagsFlag
+		expectError("FromPlutusData", h, flag.FromPlutusData(pd))
+		_, err := types.FlagsFlagFromPlutusData(pd)
+		expectError("FlagsFlagFromPlutusData", h, err)
+		expectError("UnmarshalCBOR", h, flag.UnmarshalCBOR(data))
+		_, err = types.DecodeCBOR[types.FlagsFlag](data)
+		expectError("DecodeCBOR", h, err)
+		_, err = types.DecodeCBORWithOptions[types.FlagsFlag](data, types.DecodeOptions{Strict: true})
+		expectError("DecodeCBORWithOptions", h, err)
+
+		for _, settings := range []string{"d8799f" + h + "d87980ff", "d8799fd87980" + h + "ff"} {
+			pd, data := decode(settings)
+			var v types.FlagsSettings
+			expectError("Settings.FromPlutusData", settings, v.FromPlutusData(pd))
+			expectError("Settings.UnmarshalCBOR", settings, v.UnmarshalCBOR(data))
+		}
+	}
+
+	pd, data := decode("d8799fd87a80d87980ff")
+	var v types.FlagsSettings
+	if err := v.FromPlutusData(pd); err != nil || !v.Enabled || bool(v.Flag) {
+		fmt.Fprintf(os.Stderr, "FromPlutusData: %+v %v\n", v, err)
+		os.Exit(1)
+	}
+	if err := v.UnmarshalCBOR(data); err != nil || !v.Enabled || bool(v.Flag) {
+		fmt.Fprintf(os.Stderr, "UnmarshalCBOR: %+v %v\n", v, err)
+		os.Exit(1)
+	}
+}
+`
+	tmpDir := setupTypesModule(t, blueprintPath, GeneratorOptions{PackageName: "types"}, map[string]string{"main.go": testProgram})
+
+	cmd := exec.Command("go", "run", "main.go")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("test program failed: %v\n%s", err, output)
+	}
+}
+
+// TestGeneratedCode_BoolDecodingGenerics tests the same for the Bool type
+// argument of generic types.
+func TestGeneratedCode_BoolDecodingGenerics(t *testing.T) {
+	testProgram := `package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"testpkg/types"
+)
+
+func main() {
+	for _, h := range []string{"d87b80", "d87a9f01ff"} {
+		data, _ := hex.DecodeString(h)
+		var pd types.PlutusData
+		if err := pd.UnmarshalCBOR(data); err != nil {
+			fmt.Fprintf(os.Stderr, "UnmarshalCBOR %s: %v\n", h, err)
+			os.Exit(1)
+		}
+		var b types.Bool
+		if err := b.FromPlutusData(pd); err == nil {
+			fmt.Fprintf(os.Stderr, "FromPlutusData accepted %s\n", h)
+			os.Exit(1)
+		}
+		if _, err := types.DecodeCBOR[types.List[types.Bool]](append([]byte{0x81}, data...)); err == nil {
+			fmt.Fprintf(os.Stderr, "DecodeCBOR accepted %s\n", h)
+			os.Exit(1)
+		}
+	}
+}
+`
+	opts := GeneratorOptions{PackageName: "types", Generics: true}
+	tmpDir := setupTypesModule(t, "../../testdata/generics/plutus.json", opts, map[string]string{"main.go": testProgram})
+
+	cmd := exec.Command("go", "run", "main.go")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("test program failed: %v\n%s", err, output)
+	}
+}
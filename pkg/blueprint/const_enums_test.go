@@ -0,0 +1,317 @@
+package blueprint
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const constEnumsBlueprint = `{
+  "preamble": {"title": "test/const_enums"},
+  "validators": [],
+  "definitions": {
+    "Int": {"dataType": "integer"},
+    "Color": {
+      "title": "Color",
+      "anyOf": [
+        {"title": "Red", "dataType": "constructor", "index": 0, "fields": []},
+        {"title": "Green", "dataType": "constructor", "index": 1, "fields": []},
+        {"title": "Blue", "dataType": "constructor", "index": 2, "fields": []}
+      ]
+    },
+    "Gap": {
+      "title": "Gap",
+      "anyOf": [
+        {"title": "Near", "dataType": "constructor", "index": 0, "fields": []},
+        {"title": "Far", "dataType": "constructor", "index": 5, "fields": []}
+      ]
+    },
+    "Shape": {
+      "title": "Shape",
+      "anyOf": [
+        {"title": "Dot", "dataType": "constructor", "index": 0, "fields": []},
+        {"title": "Line", "dataType": "constructor", "index": 1, "fields": [{"title": "color", "$ref": "#/definitions/Color"}]}
+      ]
+    },
+    "Option$Color": {
+      "title": "Option",
+      "anyOf": [
+        {"title": "Some", "dataType": "constructor", "index": 0, "fields": [{"$ref": "#/definitions/Color"}]},
+        {"title": "None", "dataType": "constructor", "index": 1, "fields": []}
+      ]
+    },
+    "List$Color": {"dataType": "list", "items": {"$ref": "#/definitions/Color"}},
+    "Pairs$Color_Int": {"title": "Pairs<Color, Int>", "dataType": "map", "keys": {"$ref": "#/definitions/Color"}, "values": {"$ref": "#/definitions/Int"}},
+    "Paint": {
+      "title": "Paint",
+      "anyOf": [{
+        "title": "Paint",
+        "dataType": "constructor",
+        "index": 0,
+        "fields": [
+          {"title": "color", "$ref": "#/definitions/Color"},
+          {"title": "accent", "$ref": "#/definitions/Option$Color"},
+          {"title": "palette", "$ref": "#/definitions/List$Color"},
+          {"title": "stock", "$ref": "#/definitions/Pairs$Color_Int"},
+          {"title": "gap", "$ref": "#/definitions/Gap"},
+          {"title": "shape", "$ref": "#/definitions/Shape"}
+        ]
+      }]
+    },
+    "v0_1/types/Level": {
+      "title": "Level",
+      "anyOf": [
+        {"title": "Low", "dataType": "constructor", "index": 0, "fields": []},
+        {"title": "Max", "dataType": "constructor", "index": 1, "fields": []}
+      ]
+    },
+    "v0_2/types/Level": {
+      "title": "Level",
+      "anyOf": [
+        {"title": "Low", "dataType": "constructor", "index": 0, "fields": []},
+        {"title": "High", "dataType": "constructor", "index": 1, "fields": []}
+      ]
+    }
+  }
+}`
+
+// TestConstEnums tests that fieldless enums generated as constants convert
+// to and from text, and encode like the variant structs they replace, on
+// their own and inside options, lists, maps and other types.
+func TestConstEnums(t *testing.T) {
+	blueprintPath := filepath.Join(t.TempDir(), "plutus.json")
+	if err := os.WriteFile(blueprintPath, []byte(constEnumsBlueprint), 0644); err != nil {
+		t.Fatalf("failed to write blueprint: %v", err)
+	}
+
+	testProgram := `package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"testpkg/types"
+)
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
+
+func main() {
+	if types.ColorBlue != 2 || types.GapFar != 5 || types.GapFar.Index() != 5 {
+		fail("unexpected constants %d %d", types.ColorBlue, types.GapFar)
+	}
+	if s := fmt.Sprint(types.ColorGreen, " ", types.Color(9)); s != "Green Color(9)" {
+		fail("unexpected String: %s", s)
+	}
+	if c, err := types.ParseColor("Blue"); err != nil || c != types.ColorBlue {
+		fail("ParseColor: %v %v", c, err)
+	}
+	if _, err := types.ParseColor("blue"); err == nil {
+		fail("ParseColor accepted blue")
+	}
+	if _, err := types.Color(9).MarshalText(); err == nil {
+		fail("MarshalText accepted Color(9)")
+	}
+
+	counts, err := json.Marshal(map[types.Color]int{types.ColorRed: 1, types.ColorBlue: 3})
+	if err != nil || string(counts) != ` + "`" + `{"Blue":3,"Red":1}` + "`" + ` {
+		fail("json.Marshal: %s %v", counts, err)
+	}
+	var parsed map[types.Color]int
+	if err := json.Unmarshal(counts, &parsed); err != nil || parsed[types.ColorBlue] != 3 {
+		fail("json.Unmarshal: %v %v", parsed, err)
+	}
+
+	name := types.MatchGap(types.GapFar, func() string { return "near" }, func() string { return "far" })
+	if name != "far" {
+		fail("MatchGap: %s", name)
+	}
+
+	paint := types.Paint{
+		Color:   types.ColorGreen,
+		Accent:  types.OptionColor{IsSet: true, Value: types.ColorBlue},
+		Palette: []types.Color{types.ColorRed, types.ColorBlue},
+		Stock:   map[types.Color]*big.Int{types.ColorRed: big.NewInt(4)},
+		Gap:     types.GapFar,
+		Shape:   types.ShapeLine{Color: types.ColorBlue},
+	}
+	pd, err := paint.ToPlutusData()
+	if err != nil {
+		fail("ToPlutusData: %v", err)
+	}
+	if got := pd.Constr.Fields[4]; got.Constr.Index != 5 || len(got.Constr.Fields) != 0 {
+		fail("unexpected Gap encoding: %+v", got)
+	}
+	data, err := paint.MarshalCBOR()
+	if err != nil {
+		fail("MarshalCBOR: %v", err)
+	}
+	viaPlutusData, err := pd.MarshalCBOR()
+	if err != nil || !bytes.Equal(data, viaPlutusData) {
+		fail("AppendCBOR and PlutusData encodings differ: %v", err)
+	}
+
+	var decoded types.Paint
+	if err := decoded.FromPlutusData(pd); err != nil || !decoded.Equals(paint) {
+		fail("FromPlutusData: %+v %v", decoded, err)
+	}
+	var streamed types.Paint
+	if err := streamed.UnmarshalCBOR(data); err != nil || !streamed.Equals(paint) {
+		fail("UnmarshalCBOR: %+v %v", streamed, err)
+	}
+
+	pd.Constr.Fields[0] = types.NewConstrPlutusData(3)
+	if err := decoded.FromPlutusData(pd); err == nil {
+		fail("FromPlutusData accepted Color index 3")
+	}
+	pd.Constr.Fields[0] = types.NewConstrPlutusData(256)
+	if err := decoded.FromPlutusData(pd); err == nil {
+		fail("FromPlutusData accepted Color index 256")
+	}
+	data, _ = pd.MarshalCBOR()
+	if err := streamed.UnmarshalCBOR(data); err == nil {
+		fail("UnmarshalCBOR accepted Color index 256")
+	}
+	if _, err := (types.Paint{Color: 7, Shape: types.ShapeDot{}}).ToPlutusData(); err == nil {
+		fail("ToPlutusData accepted Color(7)")
+	}
+
+	if level, err := types.V02TypesLevelFromV01TypesLevel(types.V01TypesLevelLow); err != nil || level != types.V02TypesLevelLow {
+		fail("migrating Low: %v %v", level, err)
+	}
+	if _, err := types.V02TypesLevelFromV01TypesLevel(types.V01TypesLevelMax); err == nil {
+		fail("migrated Max, which v0_2 doesn't have")
+	}
+}
+`
+	opts := GeneratorOptions{PackageName: "types", ConstEnums: true, Migrations: true}
+	tmpDir := setupTypesModule(t, blueprintPath, opts, map[string]string{"main.go": testProgram})
+
+	cmd := exec.Command("go", "run", "main.go")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("test program failed: %v\n%s", err, output)
+	}
+}
+
+// TestFieldlessConstructorsWithFields tests that a constructor declared
+// without fields is rejected when it has some, whether it is decoded as a
+// constant or a variant struct, from PlutusData or streamed, lenient or
+// strict.
+func TestFieldlessConstructorsWithFields(t *testing.T) {
+	blueprintPath := filepath.Join(t.TempDir(), "plutus.json")
+	if err := os.WriteFile(blueprintPath, []byte(constEnumsBlueprint), 0644); err != nil {
+		t.Fatalf("failed to write blueprint: %v", err)
+	}
+
+	testProgram := `package main
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+
+	"testpkg/types"
+)
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
+
+// paint returns a Paint whose field i is constructor index with a field,
+// or a valid Paint if i is negative.
+func paint(i int, index uint64) types.PlutusData {
+	fields := []types.PlutusData{
+		types.NewConstrPlutusData(1),
+		types.NewConstrPlutusData(1),
+		types.NewListPlutusData(),
+		types.NewMapPlutusData(),
+		types.NewConstrPlutusData(5),
+		types.NewConstrPlutusData(0),
+	}
+	if i >= 0 {
+		fields[i] = types.NewConstrPlutusData(index, types.NewIntPlutusData(big.NewInt(1)))
+	}
+	return types.NewConstrPlutusData(0, fields...)
+}
+
+func main() {
+	var v types.Paint
+	data, _ := paint(-1, 0).MarshalCBOR()
+	if err := v.FromPlutusData(paint(-1, 0)); err != nil {
+		fail("FromPlutusData: %v", err)
+	}
+	if err := v.UnmarshalCBOR(data); err != nil {
+		fail("UnmarshalCBOR: %v", err)
+	}
+
+	for name, field := range map[string]struct {
+		i     int
+		index uint64
+	}{"Color": {0, 1}, "None": {1, 1}, "Gap": {4, 5}, "Dot": {5, 0}} {
+		pd := paint(field.i, field.index)
+		data, _ := pd.MarshalCBOR()
+		if err := v.FromPlutusData(pd); err == nil {
+			fail("%s: FromPlutusData accepted fields", name)
+		}
+		if err := v.UnmarshalCBOR(data); err == nil {
+			fail("%s: UnmarshalCBOR accepted fields", name)
+		}
+		if _, err := types.DecodeCBORWithOptions[types.Paint](data, types.DecodeOptions{Strict: true}); err == nil {
+			fail("%s: strict DecodeCBORWithOptions accepted fields", name)
+		}
+	}
+}
+`
+	for _, constEnums := range []bool{false, true} {
+		opts := GeneratorOptions{PackageName: "types", ConstEnums: constEnums}
+		tmpDir := setupTypesModule(t, blueprintPath, opts, map[string]string{"main.go": testProgram})
+
+		cmd := exec.Command("go", "run", "main.go")
+		cmd.Dir = tmpDir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("ConstEnums %v: test program failed: %v\n%s", constEnums, err, output)
+		}
+	}
+}
+
+// TestConstEnumsEligibility tests which enums become constants: only with
+// ConstEnums, without UnknownVariants, and when no constructor has fields.
+func TestConstEnumsEligibility(t *testing.T) {
+	bp := loadBlueprintFromJSON(t, constEnumsBlueprint)
+
+	tests := []struct {
+		opts GeneratorOptions
+		want bool
+	}{
+		{GeneratorOptions{}, false},
+		{GeneratorOptions{ConstEnums: true}, true},
+		{GeneratorOptions{ConstEnums: true, Generics: true}, true},
+		{GeneratorOptions{ConstEnums: true, UnknownVariants: true}, false},
+	}
+	for _, tt := range tests {
+		code, _, err := NewGenerator(bp, tt.opts).Generate()
+		if err != nil {
+			t.Fatalf("%+v: failed to generate code: %v", tt.opts, err)
+		}
+		if got := strings.Contains(code, "type Color uint8\n"); got != tt.want {
+			t.Errorf("%+v: got Color constants %v, want %v", tt.opts, got, tt.want)
+		}
+		if strings.Contains(code, "type Shape uint8") {
+			t.Errorf("%+v: Shape has a variant with fields and can't be constants", tt.opts)
+		}
+		if tt.want && !strings.Contains(code, "\tGapNear Gap = 0\n\tGapFar Gap = 5\n") {
+			t.Errorf("%+v: expected explicit Gap values", tt.opts)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package blueprint
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// opcodeBlueprint returns a blueprint with an ops/Opcode enum of n
+// constructors, odd ones carrying an Int, and an ops/Program record holding
+// a list of them.
+func opcodeBlueprint(n int) map[string]interface{} {
+	variants := make([]map[string]interface{}, n)
+	for i := range variants {
+		fields := []map[string]interface{}{}
+		if i%2 == 1 {
+			fields = append(fields, map[string]interface{}{"title": "arg", "$ref": "#/definitions/Int"})
+		}
+		variants[i] = map[string]interface{}{
+			"title": fmt.Sprintf("Op%d", i), "dataType": "constructor", "index": i, "fields": fields,
+		}
+	}
+	return map[string]interface{}{
+		"preamble":   map[string]interface{}{"title": "test/opcodes"},
+		"validators": []interface{}{},
+		"definitions": map[string]interface{}{
+			"Int":             map[string]interface{}{"dataType": "integer"},
+			"List$ops/Opcode": map[string]interface{}{"dataType": "list", "items": map[string]interface{}{"$ref": "#/definitions/ops~1Opcode"}},
+			"ops/Opcode":      map[string]interface{}{"title": "Opcode", "anyOf": variants},
+			"ops/Program": map[string]interface{}{
+				"title": "Program",
+				"anyOf": []map[string]interface{}{{
+					"title": "Program", "dataType": "constructor", "index": 0,
+					"fields": []map[string]interface{}{{"title": "ops", "$ref": "#/definitions/List$ops~1Opcode"}},
+				}},
+			},
+		},
+	}
+}
+
+// TestGeneratedCode_ConstructorTags tests that generated enums with more
+// than 128 constructors encode each index with the right tag and decode on
+// both paths.
+func TestGeneratedCode_ConstructorTags(t *testing.T) {
+	data, err := json.Marshal(opcodeBlueprint(200))
+	if err != nil {
+		t.Fatal(err)
+	}
+	blueprintPath := filepath.Join(t.TempDir(), "plutus.json")
+	if err := os.WriteFile(blueprintPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testProgram := `package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+
+	"testpkg/types"
+)
+
+func main() {
+	program := types.OpsProgram{Ops: []types.OpsOpcode{
+		types.OpsOpcodeOp6{},
+		types.OpsOpcodeOp7{Arg: big.NewInt(7)},
+		types.OpsOpcodeOp127{Arg: big.NewInt(127)},
+		types.OpsOpcodeOp128{},
+		types.OpsOpcodeOp199{Arg: big.NewInt(199)},
+	}}
+	want := []string{
+		"d87f80",
+		"d905009f07ff",
+		"d905789f187fff",
+		"d86682188080",
+		"d8668218c79f18c7ff",
+	}
+	for i, op := range program.Ops {
+		pd, err := op.ToPlutusData()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ToPlutusData %T: %v\n", op, err)
+			os.Exit(1)
+		}
+		data, err := pd.MarshalCBOR()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "MarshalCBOR %T: %v\n", op, err)
+			os.Exit(1)
+		}
+		if got := hex.EncodeToString(data); got != want[i] {
+			fmt.Fprintf(os.Stderr, "%T encoded as %s, want %s\n", op, got, want[i])
+			os.Exit(1)
+		}
+	}
+
+	data, err := program.MarshalCBOR()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "MarshalCBOR: %v\n", err)
+		os.Exit(1)
+	}
+	var pd types.PlutusData
+	if err := pd.UnmarshalCBOR(data); err != nil {
+		fmt.Fprintf(os.Stderr, "UnmarshalCBOR: %v\n", err)
+		os.Exit(1)
+	}
+	if encoded, _ := pd.MarshalCBOR(); hex.EncodeToString(encoded) != hex.EncodeToString(data) {
+		fmt.Fprintf(os.Stderr, "MarshalCBOR %x differs from PlutusData encoding %x\n", data, encoded)
+		os.Exit(1)
+	}
+	var viaPlutusData, streamed types.OpsProgram
+	if err := viaPlutusData.FromPlutusData(pd); err != nil {
+		fmt.Fprintf(os.Stderr, "FromPlutusData: %v\n", err)
+		os.Exit(1)
+	}
+	if err := streamed.UnmarshalCBOR(data); err != nil {
+		fmt.Fprintf(os.Stderr, "UnmarshalCBOR: %v\n", err)
+		os.Exit(1)
+	}
+	if !viaPlutusData.Equals(program) || !streamed.Equals(program) {
+		fmt.Fprintln(os.Stderr, "decoded program differs")
+		os.Exit(1)
+	}
+	fmt.Println("OK")
+}
+`
+	tmpDir := setupTypesModule(t, blueprintPath, GeneratorOptions{PackageName: "types"}, map[string]string{"main.go": testProgram})
+
+	cmd := exec.Command("go", "run", ".")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("test program failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "OK") {
+		t.Errorf("unexpected output: %s", output)
+	}
+}
@@ -0,0 +1,204 @@
+package blueprint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pgrange/aiken_to_go/pkg/ir"
+)
+
+// Containers are the lists and maps a field holds directly as a Go slice or
+// map: List$X and Pairs$K_V instances (outside generics mode) and inline
+// lists and maps. Their ToPlutusData and FromPlutusData code composes the
+// encodePlutus and decodePlutus runtime functions, so that containers nest
+// to any depth, e.g. List<Option<Pairs<K, List<V>>>>.
+
+// isContainer reports whether a value of t is a Go slice or map.
+func (g *Generator) isContainer(t ir.Type) bool {
+	switch t := t.(type) {
+	case *ir.List:
+		return !g.isGeneric(t) && (t.Name == "" || strings.HasPrefix(t.Name, "List$"))
+	case *ir.Pairs:
+		return !g.isGeneric(t) && (t.Name == "" || strings.HasPrefix(t.Name, "Pairs$"))
+	default:
+		return false
+	}
+}
+
+// mapKeyGoType is the Go type of map keys of type key: []byte can't be a
+// map key in Go, so byte strings are kept as string.
+func (g *Generator) mapKeyGoType(key ir.Type) string {
+	if goType := g.goType(key); goType != "[]byte" {
+		return goType
+	}
+	return "string"
+}
+
+// plutusEncodeCall returns a call encoding expr, a list or a map of type t,
+// to (PlutusData, error).
+func (g *Generator) plutusEncodeCall(t ir.Type, expr string) string {
+	if list, ok := t.(*ir.List); ok {
+		return fmt.Sprintf("encodePlutusList(%s, %s)", expr, g.plutusEncoder(list.Elem))
+	}
+	pairs := t.(*ir.Pairs)
+	return fmt.Sprintf("encodePlutusMap(%s, %s, %s)", expr, g.plutusKeyEncoder(pairs.Key), g.plutusEncoder(pairs.Value))
+}
+
+// plutusDecodeCall returns a call decoding the PlutusData pd into the list
+// or map of type t pointed to by dst, returning an error.
+func (g *Generator) plutusDecodeCall(t ir.Type, pd, dst string) string {
+	if list, ok := t.(*ir.List); ok {
+		return fmt.Sprintf("decodePlutusList(%s, %s, %s)", pd, dst, g.plutusDecoder(list.Elem))
+	}
+	pairs := t.(*ir.Pairs)
+	return fmt.Sprintf("decodePlutusMap(%s, %s, %s, %s)", pd, dst, g.plutusKeyDecoder(pairs.Key), g.plutusDecoder(pairs.Value))
+}
+
+// plutusEncoder returns a Go expression for a func(T) (PlutusData, error)
+// encoding a value of t's Go type, as cborEncoder does for CBOR.
+func (g *Generator) plutusEncoder(t ir.Type) string {
+	switch {
+	case g.isContainer(t):
+		return fmt.Sprintf("func(v %s) (PlutusData, error) { return %s }", g.goType(t), g.plutusEncodeCall(t, "v"))
+	case isInt(t):
+		return "encodePlutusInt"
+	case isBytes(t):
+		return "encodePlutusBytes"
+	case t == ir.Bool:
+		return "encodePlutusBool"
+	case t == ir.Void:
+		return "encodePlutusVoid"
+	default:
+		return fmt.Sprintf("Encode[%s]", g.goType(t))
+	}
+}
+
+// plutusDecoder returns a Go expression for a func(PlutusData, *T) error
+// decoding a value of t's Go type, as cborDecoder does for CBOR.
+func (g *Generator) plutusDecoder(t ir.Type) string {
+	switch {
+	case g.isContainer(t):
+		return fmt.Sprintf("func(pd PlutusData, v *%s) error { return %s }", g.goType(t), g.plutusDecodeCall(t, "pd", "v"))
+	case isInt(t):
+		return "decodePlutusInt"
+	case isBytes(t):
+		return "decodePlutusBytes"
+	case t == ir.Bool:
+		return "decodePlutusBool"
+	case t == ir.Void:
+		return "decodePlutusVoid"
+	case g.isEnum(t):
+		return fmt.Sprintf("decodePlutusEnum(%sFromPlutusData)", g.goType(t))
+	default:
+		return fmt.Sprintf("decodePlutusValue[%s]", g.goType(t))
+	}
+}
+
+func (g *Generator) plutusKeyEncoder(key ir.Type) string {
+	if g.goType(key) == "[]byte" {
+		return "encodePlutusBytesString"
+	}
+	return g.plutusEncoder(key)
+}
+
+func (g *Generator) plutusKeyDecoder(key ir.Type) string {
+	if g.goType(key) == "[]byte" {
+		return "decodePlutusBytesString"
+	}
+	return g.plutusDecoder(key)
+}
+
+// plutusEqualsCall returns a bool expression comparing the container values
+// a and b by their encodings, which also holds for maps keyed by pointers.
+func (g *Generator) plutusEqualsCall(t ir.Type, a, b string) string {
+	return fmt.Sprintf("equalPlutusEncodings(%s, %s, %s)", a, b, g.plutusEncoder(t))
+}
+
+// equalsByEncoding reports whether t is a container whose values can't be
+// compared element by element: a map, or a list of containers.
+func (g *Generator) equalsByEncoding(t ir.Type) bool {
+	if !g.isContainer(t) {
+		return false
+	}
+	list, ok := t.(*ir.List)
+	return !ok || g.isContainer(list.Elem)
+}
+
+// plutusValidateCall returns an error expression validating expr, a value
+// of t's Go type, or "" if every value is valid. Lists are checked to be
+// set.
+func (g *Generator) plutusValidateCall(t ir.Type, expr string) string {
+	switch {
+	case g.isContainer(t):
+		return g.containerValidateCall(t, expr)
+	case isInt(t):
+		return fmt.Sprintf("validatePlutusInt(%s)", expr)
+	case g.isEnum(t):
+		return fmt.Sprintf("validatePlutusEnum(%s)", expr)
+	case g.hasValidate(t):
+		return expr + ".Validate()"
+	default:
+		return ""
+	}
+}
+
+// containerValidateCall is plutusValidateCall for expr, a Go slice or map
+// of the list or map type t.
+func (g *Generator) containerValidateCall(t ir.Type, expr string) string {
+	if list, ok := t.(*ir.List); ok {
+		validator := g.plutusValidator(list.Elem)
+		if validator == "" {
+			validator = "nil"
+		}
+		return fmt.Sprintf("validatePlutusList(%s, %s)", expr, validator)
+	}
+	pairs := t.(*ir.Pairs)
+	var keyValidator string
+	if g.goType(pairs.Key) != "[]byte" {
+		keyValidator = g.plutusValidator(pairs.Key)
+	}
+	valueValidator := g.plutusValidator(pairs.Value)
+	if keyValidator == "" && valueValidator == "" {
+		return ""
+	}
+	if keyValidator == "" {
+		keyValidator = "nil"
+	}
+	if valueValidator == "" {
+		valueValidator = "nil"
+	}
+	return fmt.Sprintf("validatePlutusMap(%s, %s, %s)", expr, keyValidator, valueValidator)
+}
+
+// plutusValidator returns a Go expression for a func(T) error validating a
+// value of t's Go type, or "" if every value can be encoded.
+func (g *Generator) plutusValidator(t ir.Type) string {
+	switch {
+	case g.isContainer(t):
+		call := g.plutusValidateCall(t, "v")
+		if call == "" {
+			return ""
+		}
+		return fmt.Sprintf("func(v %s) error { return %s }", g.goType(t), call)
+	case isInt(t):
+		return "validatePlutusInt"
+	case g.isEnum(t):
+		return fmt.Sprintf("validatePlutusEnum[%s]", g.goType(t))
+	case g.hasValidate(t):
+		return g.goType(t) + ".Validate"
+	default:
+		return ""
+	}
+}
+
+// hasValidate reports whether t is a generated type with a Validate method.
+func (g *Generator) hasValidate(t ir.Type) bool {
+	if g.isGeneric(t) {
+		return true
+	}
+	switch t.(type) {
+	case *ir.Option, *ir.Record, *ir.Enum, *ir.Tuple, *ir.List, *ir.Pairs:
+		return typeName(t) != ""
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package blueprint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severity tells how much of a definition a diagnostic leaves unsupported.
+type Severity int
+
+const (
+	// SeverityWarning marks a schema generated as PlutusData instead of a
+	// typed value; the generated code compiles but loses type information.
+	SeverityWarning Severity = iota
+	// SeverityError marks a schema for which no usable Go type could be
+	// generated; the generated code may not compile.
+	SeverityError
+)
+
+func (s Severity) String() string {
+	if s == SeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// Diagnostic reports a part of the blueprint the generator couldn't fully
+// support.
+type Diagnostic struct {
+	Severity Severity
+	// Definition is the name of the definition, e.g. "types/Payout".
+	Definition string
+	// Path locates the schema within the definition, in the style of
+	// decode errors (e.g. "Routes[].Key"); empty for the definition itself.
+	Path    string
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	if d.Path == "" {
+		return fmt.Sprintf("%s: %s: %s", d.Severity, d.Definition, d.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s: %s", d.Severity, d.Definition, d.Path, d.Message)
+}
+
+// diagnose records a diagnostic for the schema at path in definition.
+func (g *Generator) diagnose(severity Severity, definition, path, format string, args ...interface{}) {
+	g.diagnostics = append(g.diagnostics, Diagnostic{
+		Severity:   severity,
+		Definition: definition,
+		Path:       path,
+		Message:    fmt.Sprintf(format, args...),
+	})
+}
+
+// checkDefinitions records a diagnostic for every schema nested in the
+// definitions that the generator can't map to a Go type: unknown data
+// types, which are kept as PlutusData, and references to missing
+// definitions. It runs on the blueprint as loaded, before inline schemas
+// are hoisted, so that paths follow the blueprint.
+func (g *Generator) checkDefinitions() {
+	names := make([]string, 0, len(g.bp.Definitions))
+	for name := range g.bp.Definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		g.checkSchema(name, "", g.bp.Definitions[name])
+	}
+}
+
+func (g *Generator) checkSchema(definition, path string, schema *Schema) {
+	switch {
+	case schema.IsRef():
+		g.checkRef(definition, path, schema.RefName())
+	case len(schema.AnyOf) > 0:
+		for i := range schema.AnyOf {
+			variantPath := path
+			if len(schema.AnyOf) > 1 {
+				variantPath = joinSchemaPath(path, g.toGoIdentifier(schema.AnyOf[i].Title))
+				if !schema.IsOption() {
+					g.checkVariantFields(definition, variantPath, schema.AnyOf[i].Fields)
+				}
+			}
+			g.checkFields(definition, variantPath, schema.AnyOf[i].Fields)
+		}
+	case schema.IsConstructor():
+		g.checkFields(definition, path, schema.Fields)
+	case schema.IsList():
+		if len(schema.Items) == 1 {
+			g.checkSchema(definition, path+"[]", schema.Items[0])
+			return
+		}
+		for i, item := range schema.Items {
+			g.checkSchema(definition, fmt.Sprintf("%s[%d]", path, i), item)
+		}
+	case schema.IsMap():
+		if schema.Keys != nil {
+			g.checkSchema(definition, joinSchemaPath(path, "Key"), schema.Keys)
+		}
+		if schema.Values != nil {
+			g.checkSchema(definition, joinSchemaPath(path, "Value"), schema.Values)
+		}
+	case schema.IsInteger(), schema.IsBytes(), schema.DataType == "":
+		// Primitives, and opaque Data for schemas without a data type
+	case path == "":
+		// A whole definition of an unknown data type is reported by
+		// writeTypeDef, which generates nothing for it
+	default:
+		g.diagnose(SeverityWarning, definition, path, "unsupported data type %q, generated as PlutusData", schema.DataType)
+	}
+}
+
+func (g *Generator) checkFields(definition, path string, fields []Schema) {
+	for j := range fields {
+		g.checkSchema(definition, joinSchemaPath(path, g.normalizeFieldName(fields[j].Title, j)), &fields[j])
+	}
+}
+
+// checkVariantFields reports the fields of an enum variant renamed because
+// they clash with its Index and VariantName methods.
+func (g *Generator) checkVariantFields(definition, path string, fields []Schema) {
+	for j := range fields {
+		name := g.normalizeFieldName(fields[j].Title, j)
+		if renamed := variantFieldName(name); renamed != name {
+			g.diagnose(SeverityWarning, definition, joinSchemaPath(path, name), "field generated as %s, as %s is a method of enum variants", renamed, name)
+		}
+	}
+}
+
+// checkRef reports a reference to a definition the blueprint doesn't have,
+// unless its type can be told from its name alone.
+func (g *Generator) checkRef(definition, path, refName string) {
+	if _, ok := g.bp.Definitions[refName]; ok || g.isStandardTypeName(refName) {
+		return
+	}
+	// Some blueprints keep the JSON Pointer escaping in definition names
+	if _, ok := g.bp.Definitions[strings.ReplaceAll(refName, "/", "~1")]; ok {
+		return
+	}
+	if strings.HasPrefix(refName, "List$") || strings.HasPrefix(refName, "Pairs$") {
+		return
+	}
+	if g.opts.Generics && strings.HasPrefix(refName, "Option$") {
+		return
+	}
+	g.diagnose(SeverityError, definition, path, "reference to undefined definition %q", refName)
+}
+
+func joinSchemaPath(path, part string) string {
+	if path == "" {
+		return part
+	}
+	return path + "." + part
+}
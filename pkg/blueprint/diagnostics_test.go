@@ -0,0 +1,107 @@
+package blueprint
+
+import (
+	"testing"
+)
+
+// TestDiagnostics checks that schemas the generator can't fully support
+// are reported with their definition and path instead of being dropped.
+func TestDiagnostics(t *testing.T) {
+	bp := loadBlueprintFromJSON(t, `{
+  "preamble": {"title": "test/diagnostics", "version": "1.0.0", "plutusVersion": "v3"},
+  "validators": [],
+  "definitions": {
+    "Int": {"dataType": "integer"},
+    "Label": {"title": "Label", "dataType": "#string"},
+    "Amount": {"$ref": "#/definitions/Int"},
+    "Alias": {"$ref": "#/definitions/Datum"},
+    "Datum": {
+      "title": "Datum",
+      "anyOf": [{
+        "title": "Datum",
+        "dataType": "constructor",
+        "index": 0,
+        "fields": [
+          {"title": "amount", "$ref": "#/definitions/Amount"},
+          {"title": "tags", "dataType": "list", "items": {"dataType": "#string"}},
+          {"title": "owner", "$ref": "#/definitions/types~1Owner"}
+        ]
+      }]
+    }
+  }
+}`)
+
+	_, diagnostics, err := NewGenerator(bp, GeneratorOptions{PackageName: "diag"}).Generate()
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+
+	want := []Diagnostic{
+		{SeverityWarning, "Datum", "Tags[]", `unsupported data type "#string", generated as PlutusData`},
+		{SeverityError, "Datum", "Owner", `reference to undefined definition "types/Owner"`},
+		{SeverityError, "Alias", "", "unsupported schema, no type generated"},
+		{SeverityError, "Label", "", "unsupported schema, no type generated"},
+	}
+	if len(diagnostics) != len(want) {
+		t.Fatalf("got %d diagnostics, want %d: %v", len(diagnostics), len(want), diagnostics)
+	}
+	for i := range want {
+		if diagnostics[i] != want[i] {
+			t.Errorf("diagnostic %d: got %q, want %q", i, diagnostics[i], want[i])
+		}
+	}
+
+	if got := want[0].String(); got != `warning: Datum: Tags[]: unsupported data type "#string", generated as PlutusData` {
+		t.Errorf("unexpected String(): %s", got)
+	}
+	if got := want[2].String(); got != "error: Alias: unsupported schema, no type generated" {
+		t.Errorf("unexpected String(): %s", got)
+	}
+}
+
+// TestDiagnostics_OptionWithoutSomeField checks that an Option whose Some
+// constructor has no field fails the generation in both modes, instead of
+// getting an untyped value.
+func TestDiagnostics_OptionWithoutSomeField(t *testing.T) {
+	bp := loadBlueprintFromJSON(t, `{
+  "preamble": {"title": "test/diagnostics", "version": "1.0.0", "plutusVersion": "v3"},
+  "validators": [],
+  "definitions": {
+    "Int": {"dataType": "integer"},
+    "Option$Int": {
+      "title": "Option",
+      "anyOf": [
+        {"title": "Some", "dataType": "constructor", "index": 0, "fields": []},
+        {"title": "None", "dataType": "constructor", "index": 1, "fields": []}
+      ]
+    }
+  }
+}`)
+
+	for _, generics := range []bool{false, true} {
+		_, _, err := NewGenerator(bp, GeneratorOptions{PackageName: "diag", Generics: generics}).Generate()
+		if err == nil || err.Error() != "option type Option$Int has no Some field" {
+			t.Errorf("generics=%v: got error %v", generics, err)
+		}
+	}
+}
+
+// TestDiagnostics_SupportedBlueprints checks that the test blueprints, which
+// the generator fully supports, produce no diagnostics.
+func TestDiagnostics_SupportedBlueprints(t *testing.T) {
+	for _, name := range []string{"simple", "complex", "tuple", "all_types", "advanced_types", "map_types", "generics", "inline", "nested", "versioned"} {
+		bp, err := LoadBlueprint("../../testdata/" + name + "/plutus.json")
+		if err != nil {
+			t.Fatalf("%s: failed to load blueprint: %v", name, err)
+		}
+		for _, generics := range []bool{false, true} {
+			_, diagnostics, err := NewGenerator(bp, GeneratorOptions{Generics: generics}).Generate()
+			if err != nil {
+				t.Fatalf("%s: failed to generate code: %v", name, err)
+			}
+			if len(diagnostics) > 0 {
+				t.Errorf("%s (generics=%v): unexpected diagnostics: %v", name, generics, diagnostics)
+			}
+		}
+	}
+}
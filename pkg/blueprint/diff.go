@@ -0,0 +1,292 @@
+package blueprint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Compatibility classifies a change between two versions of a blueprint.
+type Compatibility int
+
+const (
+	// Compatible changes keep data encoded for the old blueprint decodable
+	// with the new one.
+	Compatible Compatibility = iota
+
+	// Breaking changes alter the on-chain encoding of existing values or the
+	// script they are locked by, so data written against the old blueprint
+	// no longer decodes or is no longer found.
+	Breaking
+)
+
+func (c Compatibility) String() string {
+	if c == Breaking {
+		return "breaking"
+	}
+	return "compatible"
+}
+
+// Change is a single difference between two blueprints.
+type Change struct {
+	// Path locates the change: a definition name such as "types/Payout",
+	// followed by a constructor and field ("types/Payout.Status"), or
+	// "validator <title>".
+	Path          string
+	Description   string
+	Compatibility Compatibility
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s: %s", c.Compatibility, c.Path, c.Description)
+}
+
+// Diff compares the definitions and validators of two blueprints and returns
+// the changes, breaking or not, in a stable order.
+//
+// Only what reaches the wire is breaking: removed or reordered constructors,
+// changed field counts and field types, and changed validator hashes.
+// Renamed constructors and fields and added constructors, definitions and
+// validators are compatible.
+func Diff(old, new *Blueprint) []Change {
+	d := &differ{}
+	d.definitions(old.Definitions, new.Definitions)
+	d.validators(old.Validators, new.Validators)
+	return d.changes
+}
+
+// HasBreaking reports whether any of the changes is breaking.
+func HasBreaking(changes []Change) bool {
+	for _, c := range changes {
+		if c.Compatibility == Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+type differ struct {
+	changes []Change
+}
+
+func (d *differ) add(compat Compatibility, path, format string, args ...interface{}) {
+	d.changes = append(d.changes, Change{
+		Path:          path,
+		Description:   fmt.Sprintf(format, args...),
+		Compatibility: compat,
+	})
+}
+
+func (d *differ) definitions(old, new map[string]*Schema) {
+	names := make([]string, 0, len(old)+len(new))
+	for name := range old {
+		names = append(names, name)
+	}
+	for name := range new {
+		if _, ok := old[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		oldDef, newDef := old[name], new[name]
+		switch {
+		case newDef == nil:
+			d.add(Breaking, name, "definition removed")
+		case oldDef == nil:
+			d.add(Compatible, name, "definition added")
+		default:
+			d.schema(name, oldDef, newDef)
+		}
+	}
+}
+
+// schema compares two versions of a definition.
+func (d *differ) schema(path string, old, new *Schema) {
+	if old.IsEnum() && new.IsEnum() {
+		d.constructors(path, old.AnyOf, new.AnyOf)
+		return
+	}
+	if old.IsConstructor() && new.IsConstructor() {
+		d.constructor(path, old, new)
+		return
+	}
+	if oldType, newType := schemaTypeString(old), schemaTypeString(new); oldType != newType {
+		d.add(Breaking, path, "type changed from %s to %s", oldType, newType)
+	}
+}
+
+// constructors matches the constructors of two versions of an enum by title,
+// then pairs the remaining ones sharing an index as renames.
+func (d *differ) constructors(path string, old, new []Schema) {
+	newByTitle := make(map[string]int, len(new))
+	newByIndex := make(map[int]int, len(new))
+	for i := range new {
+		newByTitle[new[i].Title] = i
+		newByIndex[constrIndex(&new[i], i)] = i
+	}
+	oldTitles := make(map[string]bool, len(old))
+	for i := range old {
+		oldTitles[old[i].Title] = true
+	}
+
+	matched := make(map[int]bool, len(new))
+	for i := range old {
+		c := &old[i]
+		index := constrIndex(c, i)
+		ctorPath := path + "." + c.Title
+		if j, ok := newByTitle[c.Title]; ok {
+			matched[j] = true
+			if newIndex := constrIndex(&new[j], j); newIndex != index {
+				d.add(Breaking, ctorPath, "constructor moved from index %d to %d", index, newIndex)
+			}
+			d.constructor(ctorPath, c, &new[j])
+			continue
+		}
+		if j, ok := newByIndex[index]; ok && !oldTitles[new[j].Title] {
+			matched[j] = true
+			d.add(Compatible, ctorPath, "constructor renamed to %s", new[j].Title)
+			d.constructor(ctorPath, c, &new[j])
+			continue
+		}
+		d.add(Breaking, ctorPath, "constructor removed (index %d)", index)
+	}
+
+	for j := range new {
+		if !matched[j] {
+			d.add(Compatible, path+"."+new[j].Title, "constructor added (index %d)", constrIndex(&new[j], j))
+		}
+	}
+}
+
+// constructor compares the fields of two versions of a constructor.
+func (d *differ) constructor(path string, old, new *Schema) {
+	if len(old.Fields) != len(new.Fields) {
+		d.add(Breaking, path, "field count changed from %d to %d", len(old.Fields), len(new.Fields))
+	}
+	for i := 0; i < len(old.Fields) && i < len(new.Fields); i++ {
+		oldField, newField := &old.Fields[i], &new.Fields[i]
+		fieldPath := path + "." + fieldLabel(oldField, i)
+		if oldField.Title != newField.Title {
+			d.add(Compatible, fieldPath, "field renamed to %s", fieldLabel(newField, i))
+		}
+		if oldType, newType := schemaTypeString(oldField), schemaTypeString(newField); oldType != newType {
+			d.add(Breaking, fieldPath, "field type changed from %s to %s", oldType, newType)
+		}
+	}
+}
+
+func (d *differ) validators(old, new []Validator) {
+	newByTitle := make(map[string]*Validator, len(new))
+	for i := range new {
+		newByTitle[new[i].Title] = &new[i]
+	}
+	oldTitles := make(map[string]bool, len(old))
+
+	for i := range old {
+		v := &old[i]
+		oldTitles[v.Title] = true
+		path := "validator " + v.Title
+		nv, ok := newByTitle[v.Title]
+		if !ok {
+			d.add(Breaking, path, "validator removed")
+			continue
+		}
+		if v.Hash != nv.Hash {
+			d.add(Breaking, path, "hash changed from %s to %s", v.Hash, nv.Hash)
+		}
+		if oldType, newType := parameterTypeString(v.Datum), parameterTypeString(nv.Datum); oldType != newType {
+			d.add(Breaking, path, "datum type changed from %s to %s", oldType, newType)
+		}
+		if oldType, newType := schemaTypeString(&v.Redeemer.Schema), schemaTypeString(&nv.Redeemer.Schema); oldType != newType {
+			d.add(Breaking, path, "redeemer type changed from %s to %s", oldType, newType)
+		}
+		if len(v.Parameters) != len(nv.Parameters) {
+			d.add(Breaking, path, "parameter count changed from %d to %d", len(v.Parameters), len(nv.Parameters))
+		}
+		for j := 0; j < len(v.Parameters) && j < len(nv.Parameters); j++ {
+			oldType, newType := schemaTypeString(&v.Parameters[j].Schema), schemaTypeString(&nv.Parameters[j].Schema)
+			if oldType != newType {
+				d.add(Breaking, path, "parameter %s type changed from %s to %s", v.Parameters[j].Title, oldType, newType)
+			}
+		}
+	}
+
+	for i := range new {
+		if !oldTitles[new[i].Title] {
+			d.add(Compatible, "validator "+new[i].Title, "validator added")
+		}
+	}
+}
+
+// constrIndex returns the constructor index of the i-th constructor of an
+// enum, which defaults to its position.
+func constrIndex(s *Schema, i int) int {
+	if s.Index != nil {
+		return *s.Index
+	}
+	return i
+}
+
+func fieldLabel(s *Schema, i int) string {
+	if s.Title != "" {
+		return s.Title
+	}
+	return fmt.Sprintf("[%d]", i)
+}
+
+func parameterTypeString(p *Parameter) string {
+	if p == nil {
+		return "none"
+	}
+	return schemaTypeString(&p.Schema)
+}
+
+// schemaTypeString renders the wire shape of a schema. Referenced
+// definitions are rendered by name: changes to them are reported on the
+// definition itself.
+func schemaTypeString(s *Schema) string {
+	switch {
+	case s.IsRef():
+		return s.RefName()
+	case s.IsInteger():
+		return "Int"
+	case s.IsBytes():
+		return "ByteArray"
+	case s.IsList() && s.Items.IsTuple():
+		items := make([]string, len(s.Items))
+		for i, item := range s.Items {
+			items[i] = schemaTypeString(item)
+		}
+		return "Tuple<" + strings.Join(items, ", ") + ">"
+	case s.IsList():
+		if item := s.Items.Single(); item != nil {
+			return "List<" + schemaTypeString(item) + ">"
+		}
+		return "List<Data>"
+	case s.IsMap():
+		key, value := "Data", "Data"
+		if s.Keys != nil {
+			key = schemaTypeString(s.Keys)
+		}
+		if s.Values != nil {
+			value = schemaTypeString(s.Values)
+		}
+		return "Map<" + key + ", " + value + ">"
+	case s.IsEnum():
+		constrs := make([]string, len(s.AnyOf))
+		for i := range s.AnyOf {
+			constrs[i] = schemaTypeString(&s.AnyOf[i])
+		}
+		return "AnyOf<" + strings.Join(constrs, " | ") + ">"
+	case s.IsConstructor():
+		fields := make([]string, len(s.Fields))
+		for i := range s.Fields {
+			fields[i] = schemaTypeString(&s.Fields[i])
+		}
+		return fmt.Sprintf("Constr%d<%s>", constrIndex(s, 0), strings.Join(fields, ", "))
+	default:
+		return "Data"
+	}
+}
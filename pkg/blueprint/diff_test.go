@@ -0,0 +1,184 @@
+package blueprint
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const diffBaseBlueprint = `{
+  "validators": [
+    {
+      "title": "vault.spend",
+      "datum": {"schema": {"$ref": "#/definitions/types~1Datum"}},
+      "redeemer": {"schema": {"$ref": "#/definitions/types~1Action"}},
+      "hash": "aa"
+    }
+  ],
+  "definitions": {
+    "Int": {"dataType": "integer"},
+    "ByteArray": {"dataType": "bytes"},
+    "types/Datum": {
+      "anyOf": [{
+        "title": "Datum", "dataType": "constructor", "index": 0,
+        "fields": [
+          {"title": "owner", "$ref": "#/definitions/ByteArray"},
+          {"title": "amount", "$ref": "#/definitions/Int"}
+        ]
+      }]
+    },
+    "types/Action": {
+      "anyOf": [
+        {"title": "Deposit", "dataType": "constructor", "index": 0, "fields": [{"title": "amount", "$ref": "#/definitions/Int"}]},
+        {"title": "Withdraw", "dataType": "constructor", "index": 1, "fields": []}
+      ]
+    }
+  }
+}`
+
+// diffBlueprints returns the base blueprint and a copy modified by edit.
+func diffBlueprints(t *testing.T, edit func(bp *Blueprint)) (*Blueprint, *Blueprint) {
+	t.Helper()
+	var old, new Blueprint
+	if err := json.Unmarshal([]byte(diffBaseBlueprint), &old); err != nil {
+		t.Fatalf("failed to parse blueprint: %v", err)
+	}
+	if err := json.Unmarshal([]byte(diffBaseBlueprint), &new); err != nil {
+		t.Fatalf("failed to parse blueprint: %v", err)
+	}
+	edit(&new)
+	return &old, &new
+}
+
+func TestDiff(t *testing.T) {
+	intRef := Schema{Ref: "#/definitions/Int"}
+
+	tests := []struct {
+		name string
+		edit func(bp *Blueprint)
+		want []Change
+	}{
+		{
+			name: "identical",
+			edit: func(bp *Blueprint) {},
+		},
+		{
+			name: "constructor added",
+			edit: func(bp *Blueprint) {
+				action := bp.Definitions["types/Action"]
+				two := 2
+				action.AnyOf = append(action.AnyOf, Schema{Title: "Close", DataType: "constructor", Index: &two})
+			},
+			want: []Change{{Path: "types/Action.Close", Description: "constructor added (index 2)", Compatibility: Compatible}},
+		},
+		{
+			name: "constructors reordered",
+			edit: func(bp *Blueprint) {
+				action := bp.Definitions["types/Action"]
+				action.AnyOf[0], action.AnyOf[1] = action.AnyOf[1], action.AnyOf[0]
+				action.AnyOf[0].Index, action.AnyOf[1].Index = action.AnyOf[1].Index, action.AnyOf[0].Index
+			},
+			want: []Change{
+				{Path: "types/Action.Deposit", Description: "constructor moved from index 0 to 1", Compatibility: Breaking},
+				{Path: "types/Action.Withdraw", Description: "constructor moved from index 1 to 0", Compatibility: Breaking},
+			},
+		},
+		{
+			name: "constructor removed",
+			edit: func(bp *Blueprint) {
+				action := bp.Definitions["types/Action"]
+				action.AnyOf = action.AnyOf[:1]
+			},
+			want: []Change{{Path: "types/Action.Withdraw", Description: "constructor removed (index 1)", Compatibility: Breaking}},
+		},
+		{
+			name: "constructor and field renamed",
+			edit: func(bp *Blueprint) {
+				action := bp.Definitions["types/Action"]
+				action.AnyOf[0].Title = "Deposited"
+				action.AnyOf[0].Fields[0].Title = "lovelace"
+			},
+			want: []Change{
+				{Path: "types/Action.Deposit", Description: "constructor renamed to Deposited", Compatibility: Compatible},
+				{Path: "types/Action.Deposit.amount", Description: "field renamed to lovelace", Compatibility: Compatible},
+			},
+		},
+		{
+			name: "field type changed",
+			edit: func(bp *Blueprint) {
+				bp.Definitions["types/Datum"].AnyOf[0].Fields[0] = Schema{Title: "owner", Ref: "#/definitions/Int"}
+			},
+			want: []Change{{Path: "types/Datum.Datum.owner", Description: "field type changed from ByteArray to Int", Compatibility: Breaking}},
+		},
+		{
+			name: "field added",
+			edit: func(bp *Blueprint) {
+				datum := bp.Definitions["types/Datum"]
+				datum.AnyOf[0].Fields = append(datum.AnyOf[0].Fields, Schema{Title: "deadline", Ref: intRef.Ref})
+			},
+			want: []Change{{Path: "types/Datum.Datum", Description: "field count changed from 2 to 3", Compatibility: Breaking}},
+		},
+		{
+			name: "definitions added and removed",
+			edit: func(bp *Blueprint) {
+				delete(bp.Definitions, "ByteArray")
+				bp.Definitions["List$Int"] = &Schema{DataType: "list", Items: SchemaItems{&intRef}}
+			},
+			want: []Change{
+				{Path: "ByteArray", Description: "definition removed", Compatibility: Breaking},
+				{Path: "List$Int", Description: "definition added", Compatibility: Compatible},
+			},
+		},
+		{
+			name: "definition type changed",
+			edit: func(bp *Blueprint) {
+				bp.Definitions["ByteArray"] = &Schema{DataType: "list", Items: SchemaItems{&intRef}}
+			},
+			want: []Change{{Path: "ByteArray", Description: "type changed from ByteArray to List<Int>", Compatibility: Breaking}},
+		},
+		{
+			name: "validator hash and datum changed",
+			edit: func(bp *Blueprint) {
+				bp.Validators[0].Hash = "bb"
+				bp.Validators[0].Datum = nil
+			},
+			want: []Change{
+				{Path: "validator vault.spend", Description: "hash changed from aa to bb", Compatibility: Breaking},
+				{Path: "validator vault.spend", Description: "datum type changed from types/Datum to none", Compatibility: Breaking},
+			},
+		},
+		{
+			name: "validator added",
+			edit: func(bp *Blueprint) {
+				bp.Validators = append(bp.Validators, Validator{Title: "vault.mint", Hash: "cc"})
+			},
+			want: []Change{{Path: "validator vault.mint", Description: "validator added", Compatibility: Compatible}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := Diff(diffBlueprints(t, tt.edit))
+			if !reflect.DeepEqual(changes, tt.want) {
+				t.Errorf("Diff() =\n%v\nwant\n%v", changes, tt.want)
+			}
+			wantBreaking := false
+			for _, c := range tt.want {
+				wantBreaking = wantBreaking || c.Compatibility == Breaking
+			}
+			if HasBreaking(changes) != wantBreaking {
+				t.Errorf("HasBreaking() = %v, want %v", HasBreaking(changes), wantBreaking)
+			}
+		})
+	}
+}
+
+func TestDiffSameBlueprint(t *testing.T) {
+	bp, err := LoadBlueprint("../../testdata/complex/plutus.json")
+	if err != nil {
+		t.Fatalf("failed to load blueprint: %v", err)
+	}
+	if changes := Diff(bp, bp); len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+}
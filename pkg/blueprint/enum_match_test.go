@@ -0,0 +1,146 @@
+package blueprint
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestEnumMatch tests that MatchX calls the function of each variant,
+// including the unknown one, and the Index and VariantName methods of the
+// enum interface.
+func TestEnumMatch(t *testing.T) {
+	testProgram := `package main
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+
+	"testpkg/types"
+)
+
+func describe(a types.NestedAction) string {
+	return types.MatchNestedAction(a,
+		func(v types.NestedActionMint) string { return fmt.Sprintf("mint %d policies", len(v.Amounts)) },
+		func(v types.NestedActionBurn) string { return fmt.Sprintf("burn %d rows", len(v.Value)) },
+		func(types.NestedActionNoop) string { return "noop" },
+		func(v types.NestedActionUnknown) string { return fmt.Sprintf("unknown %d", v.Constructor) },
+	)
+}
+
+func main() {
+	cases := []struct {
+		action types.NestedAction
+		want   string
+	}{
+		{types.NestedActionMint{Amounts: map[string]map[string]*big.Int{"p": {}}}, "mint 1 policies"},
+		{types.NestedActionBurn{Value: [][]*big.Int{{big.NewInt(1)}, {}}}, "burn 2 rows"},
+		{types.NestedActionNoop{}, "noop"},
+		{types.NestedActionUnknown{Constructor: 7}, "unknown 7"},
+	}
+	for _, c := range cases {
+		if got := describe(c.action); got != c.want {
+			fmt.Fprintf(os.Stderr, "%T: got %q, want %q\n", c.action, got, c.want)
+			os.Exit(1)
+		}
+	}
+
+	for i, v := range []types.NestedAction{types.NestedActionMint{}, types.NestedActionBurn{}, types.NestedActionNoop{}, types.NestedActionUnknown{Constructor: 3}} {
+		if v.Index() != uint64(i) {
+			fmt.Fprintf(os.Stderr, "%T: got index %d, want %d\n", v, v.Index(), i)
+			os.Exit(1)
+		}
+		fmt.Printf("%q\n", v.VariantName())
+	}
+
+	defer func() {
+		if recover() == nil {
+			fmt.Fprintln(os.Stderr, "MatchNestedAction(nil) did not panic")
+			os.Exit(1)
+		}
+	}()
+	describe(nil)
+}
+`
+	opts := GeneratorOptions{PackageName: "types", UnknownVariants: true}
+	tmpDir := setupTypesModule(t, "../../testdata/nested/plutus.json", opts, map[string]string{"main.go": testProgram})
+
+	cmd := exec.Command("go", "run", "main.go")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("test program failed: %v\n%s", err, output)
+	}
+	if got := string(output); got != "\"Mint\"\n\"Burn\"\n\"Noop\"\n\"\"\n" {
+		t.Errorf("unexpected variant names:\n%s", got)
+	}
+}
+
+// TestEnumMatchFieldNames tests that a field of a variant named Index or
+// VariantName is renamed, and reported, so that the variant keeps the
+// methods of the enum interface.
+func TestEnumMatchFieldNames(t *testing.T) {
+	blueprintPath := filepath.Join(t.TempDir(), "plutus.json")
+	blueprintJSON := `{
+  "preamble": {"title": "test/enum_match"},
+  "validators": [],
+  "definitions": {
+    "Int": {"dataType": "integer"},
+    "Step": {
+      "title": "Step",
+      "anyOf": [
+        {"title": "Goto", "dataType": "constructor", "index": 0, "fields": [{"title": "index", "$ref": "#/definitions/Int"}]},
+        {"title": "Label", "dataType": "constructor", "index": 1, "fields": [{"title": "variant_name", "$ref": "#/definitions/Int"}]}
+      ]
+    }
+  }
+}`
+	if err := os.WriteFile(blueprintPath, []byte(blueprintJSON), 0644); err != nil {
+		t.Fatalf("failed to write blueprint: %v", err)
+	}
+
+	testProgram := `package main
+
+import (
+	"fmt"
+	"math/big"
+
+	"testpkg/types"
+)
+
+func main() {
+	var step types.Step = types.StepGoto{Index_: big.NewInt(4)}
+	fmt.Println(step.VariantName(), step.(types.StepGoto).Index_, types.StepLabel{VariantName_: big.NewInt(5)}.Index())
+}
+`
+	bp, err := LoadBlueprint(blueprintPath)
+	if err != nil {
+		t.Fatalf("failed to load blueprint: %v", err)
+	}
+	_, diagnostics, err := NewGenerator(bp, GeneratorOptions{PackageName: "types"}).Generate()
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+	want := []Diagnostic{
+		{SeverityWarning, "Step", "Goto.Index", "field generated as Index_, as Index is a method of enum variants"},
+		{SeverityWarning, "Step", "Label.VariantName", "field generated as VariantName_, as VariantName is a method of enum variants"},
+	}
+	if len(diagnostics) != len(want) || diagnostics[0] != want[0] || diagnostics[1] != want[1] {
+		t.Errorf("got diagnostics %v, want %v", diagnostics, want)
+	}
+
+	tmpDir := setupTypesModule(t, blueprintPath, GeneratorOptions{PackageName: "types"}, map[string]string{"main.go": testProgram})
+
+	cmd := exec.Command("go", "run", "main.go")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("test program failed: %v\n%s", err, output)
+	}
+	if got := strings.TrimSpace(string(output)); got != "Goto 4 1" {
+		t.Errorf("got %q, want %q", got, "Goto 4 1")
+	}
+}
@@ -1,39 +1,86 @@
 package blueprint
 
 import (
-	"embed"
+	"bytes"
+	_ "embed"
 	"fmt"
+	"go/token"
+	"io/fs"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 	"unicode"
+
+	"github.com/pgrange/aiken_to_go/pkg/ir"
 )
 
 //go:embed plutusdata.go
 var plutusDataSource string
 
-//go:embed templates/*.tmpl
-var templateFS embed.FS
-
-var templates *template.Template
-
-func init() {
-	templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
-}
-
 // GeneratorOptions configures the code generator.
 type GeneratorOptions struct {
 	// PackageName is the Go package name for generated code.
 	PackageName string
+
+	// Generics emits Go type parameters for Aiken parametric types:
+	// Option$X, List$X and Pairs$K_V become Option[X], List[X] and
+	// Pairs[K, V], and user-defined types instantiated more than once
+	// become a single generic type.
+	Generics bool
+
+	// UnknownVariants adds an XxxUnknown variant to every enum, which
+	// keeps constructors with an index the blueprint doesn't declare
+	// instead of failing to decode them, and re-encodes them unchanged.
+	UnknownVariants bool
+
+	// ConstEnums generates enums whose constructors all lack fields as
+	// `type X uint8` with a constant per variant, String, ParseX and text
+	// marshaling, instead of an interface and empty variant structs. It
+	// has no effect with UnknownVariants, as an undeclared constructor may
+	// have fields.
+	ConstEnums bool
+
+	// Migrations converts between versions of a type kept side by side in
+	// the blueprint (v0_1/types/Settings, v0_3/types/Settings): the newer
+	// version gets a FromV01TypesSettings method, or for enums a
+	// V03TypesStatusFromV01TypesStatus function, matching fields by name.
+	Migrations bool
+
+	// TemplateFS overrides the templates the code is generated from: a
+	// *.tmpl file at its root replaces the built-in template of the same
+	// name, and may define templates for others to include. See templates.go
+	// for the data each template is executed with, and for the per-field
+	// code that templates receive ready-made and can't change.
+	TemplateFS fs.FS
 }
 
 // Generator produces Go source code from a Blueprint.
 type Generator struct {
-	bp         *Blueprint
-	opts       GeneratorOptions
-	buf        strings.Builder
-	indent     int
-	generated  map[string]bool // track which types have been generated
+	bp        *Blueprint
+	opts      GeneratorOptions
+	buf       bytes.Buffer
+	indent    int
+	templates *template.Template
+	generated map[string]bool // track which types have been generated
+
+	// Generic families and their instantiations, keyed by definition name.
+	// Only populated when GeneratorOptions.Generics is set.
+	families  map[string]*genericFamily
+	instances map[string]*genericInstance
+
+	// Versioned record and enum definitions, keyed by definition name.
+	// Only populated when GeneratorOptions.Migrations is set.
+	versioned map[string]*versionedDef
+
+	// What Generate couldn't fully support, in the order found.
+	diagnostics []Diagnostic
+
+	// The resolved definitions, once inline schemas are hoisted. They
+	// decide which writer generates each definition; the writers read the
+	// raw schemas.
+	module *ir.Module
 }
 
 // NewGenerator creates a new code generator.
@@ -48,12 +95,26 @@ func NewGenerator(bp *Blueprint, opts GeneratorOptions) *Generator {
 	}
 }
 
-// Generate produces Go source code from the blueprint.
-func (g *Generator) Generate() (string, error) {
+// Generate produces Go source code from the blueprint, along with the
+// diagnostics for the parts of it that couldn't be fully supported. Code is
+// still generated for those; errors are reserved for failures to generate.
+func (g *Generator) Generate() (string, []Diagnostic, error) {
+	g.checkDefinitions()
+
+	// Give the anonymous schemas nested in definitions a name
+	g.bp = hoistInlineSchemas(g.bp)
+	g.module = Resolve(g.bp)
+
+	templates, err := loadTemplates(g.opts.TemplateFS)
+	if err != nil {
+		return "", nil, err
+	}
+	g.templates = templates
+
 	// Write the generated file header
-	g.writeLine("// Code generated by aiken2go. DO NOT EDIT.")
-	g.writeLine(fmt.Sprintf("// Source: %s", g.bp.Preamble.Title))
-	g.writeLine("")
+	if err := g.writeTemplate("file_header.go.tmpl", g.bp); err != nil {
+		return "", nil, err
+	}
 
 	// Patch plutusdata.go: replace package name
 	code := plutusDataSource
@@ -61,12 +122,29 @@ func (g *Generator) Generate() (string, error) {
 	g.buf.WriteString(code)
 	g.writeLine("")
 
+	if err := g.writeTemplate("blueprint_info.go.tmpl", g.bp.Preamble); err != nil {
+		return "", nil, err
+	}
+
+	if g.opts.Generics {
+		g.collectGenericFamilies()
+		if err := g.writeTemplate("generics.go.tmpl", nil); err != nil {
+			return "", nil, err
+		}
+	}
+
 	// Generate type definitions specific to the blueprint
 	if err := g.writeTypeDefinitions(); err != nil {
-		return "", err
+		return "", nil, err
+	}
+
+	if g.opts.Migrations {
+		if err := g.writeMigrations(); err != nil {
+			return "", nil, err
+		}
 	}
 
-	return g.buf.String(), nil
+	return g.buf.String(), g.diagnostics, nil
 }
 
 func (g *Generator) writeTypeDefinitions() error {
@@ -87,6 +165,22 @@ func (g *Generator) writeTypeDefinitions() error {
 		if strings.HasPrefix(name, "List$") || strings.HasPrefix(name, "Pairs$") {
 			continue
 		}
+		if _, ok := g.definitionType(name).(*ir.Option); ok && schema.OptionInnerType() == nil {
+			return fmt.Errorf("option type %s has no Some field", name)
+		}
+		if g.opts.Generics {
+			// Option$ is covered by the generic Option[T]; instantiations
+			// of a generic family are emitted once as the family type
+			if strings.HasPrefix(name, "Option$") {
+				continue
+			}
+			if inst, ok := g.instances[name]; ok {
+				if err := g.writeGenericFamily(inst.family); err != nil {
+					return err
+				}
+				continue
+			}
+		}
 		if err := g.writeTypeDef(name, schema); err != nil {
 			return err
 		}
@@ -102,242 +196,174 @@ func (g *Generator) writeTypeDef(name string, schema *Schema) error {
 		return nil
 	}
 
-	// Handle different schema types
+	// Handle different types
 	// Only mark as generated AFTER we confirm we will generate something
-	switch {
-	case schema.IsBoolean():
-		// Generate Bool enum type with BoolFromPlutusData
-		g.generated[goName] = true
-		return g.writeBoolType(goName, schema)
-	case schema.IsUnit():
-		// Generate empty struct for Unit/Void
-		g.generated[goName] = true
-		g.writeUnitType(goName)
+	switch t := g.definitionType(name).(type) {
+	case ir.Primitive:
+		switch t {
+		case ir.Bool:
+			// Generate Bool enum type with BoolFromPlutusData
+			g.generated[goName] = true
+			return g.writeBoolType(goName)
+		case ir.Void:
+			// Generate empty struct for Unit/Void
+			g.generated[goName] = true
+			return g.writeUnitType(goName)
+		}
 		return nil
-	case schema.IsOption():
+	case *ir.Option:
 		// Generate Option type with IsSet + Value
 		g.generated[goName] = true
-		return g.writeOptionType(goName, schema)
-	case schema.IsSingleConstructor():
+		return g.writeOptionType(goName, t)
+	case *ir.Record:
 		// Single constructor - generate struct
 		g.generated[goName] = true
-		return g.writeStructType(goName, &schema.AnyOf[0], 0)
-	case schema.IsEnum():
-		// Multiple constructors - generate interface + variants
-		g.generated[goName] = true
-		return g.writeEnumType(goName, schema)
-	case schema.IsConstructor():
-		// Direct constructor
+		return g.writeStructType(goName, t)
+	case *ir.Enum:
 		g.generated[goName] = true
-		return g.writeStructType(goName, schema, 0)
-	case schema.IsList() && len(schema.Items) > 1:
-		// Tuple type (list with multiple items)
+		if g.isConstEnum(t) {
+			// Fieldless constructors - generate constants
+			return g.writeConstEnumType(goName, t)
+		}
+		// Multiple constructors - generate interface + variants
+		return g.writeEnumType(goName, t)
+	case *ir.Tuple:
+		// Tuple type (list with multiple items), with fields named after
+		// the definitions its items refer to
 		g.generated[goName] = true
-		return g.writeTupleType(goName, schema)
-	case schema.IsList() && len(schema.Items) == 1:
+		return g.writeTupleType(goName, t, g.getTupleFieldNames(schema.Items))
+	case *ir.List:
 		// Named list type (single item) - generate type alias
+		if schema.Items.Single() == nil {
+			return fmt.Errorf("list type %s has no inner type", goName)
+		}
 		g.generated[goName] = true
-		return g.writeListTypeAlias(goName, schema)
+		return g.writeListTypeAlias(goName, t)
+	case *ir.Pairs:
+		// Named map type (hoisted from a nested inline map)
+		g.generated[goName] = true
+		return g.writeMapType(goName, t)
+	case *ir.Alias:
+		switch ir.Underlying(t) {
+		case ir.Bool:
+			// A Bool under another name, generated as a type of its own
+			g.generated[goName] = true
+			return g.writeBoolType(goName)
+		case ir.Void:
+			g.generated[goName] = true
+			return g.writeUnitType(goName)
+		}
+		if _, ok := ir.Underlying(t).(ir.Primitive); ok {
+			// Primitive wrapper - skip (handled inline)
+			return nil
+		}
+		g.diagnose(SeverityError, name, "", "unsupported schema, no type generated")
+		return nil
 	default:
-		// Primitive or reference - skip (handled inline)
+		if schema.DataType != "" {
+			// An unknown data type
+			g.diagnose(SeverityError, name, "", "unsupported schema, no type generated")
+		}
+		// Data - skip (handled inline)
 		// Don't mark as generated since we're not generating anything
 		return nil
 	}
 }
 
-func (g *Generator) writeUnitType(name string) {
-	g.executeTemplate("unit_type.go.tmpl", map[string]string{"Name": name})
-	g.writeLine("")
+// definitionType returns the resolved type of the definition name, or nil.
+func (g *Generator) definitionType(name string) ir.Type {
+	if g.module == nil {
+		return nil
+	}
+	if def := g.module.Lookup(unescapeRef(name)); def != nil {
+		return def.Type
+	}
+	return nil
 }
 
-func (g *Generator) writeOptionType(name string, schema *Schema) error {
-	// Option is an enum with Some (0) and None (1) constructors
-	// Get the inner type from the Some variant
-	innerType := "interface{}"
-	if len(schema.AnyOf) > 0 && len(schema.AnyOf[0].Fields) > 0 {
-		innerType = g.schemaToGoType(&schema.AnyOf[0].Fields[0])
+func (g *Generator) writeUnitType(name string) error {
+	data := TypeData{Name: name, Receiver: name, Kind: "unit"}
+	if err := g.writeTemplate("unit_type.go.tmpl", data); err != nil {
+		return err
 	}
-
-	// Get the inner serialization/deserialization code
-	toPlutusDataInner := g.getOptionInnerToPlutusDataCode(name, schema)
-	fromPlutusDataInner := g.getOptionInnerFromPlutusDataCode(name, schema)
-
-	g.executeTemplate("option_type.go.tmpl", map[string]string{
-		"Name":                name,
-		"InnerType":           innerType,
-		"ToPlutusDataInner":   toPlutusDataInner,
-		"FromPlutusDataInner": fromPlutusDataInner,
-	})
-	g.writeLine("")
-
-	// Equals method
-	g.writeOptionEquals(name, schema)
-
-	return nil
+	return g.writeExtras(data)
 }
 
-func (g *Generator) getOptionInnerToPlutusDataCode(optionName string, schema *Schema) string {
-	// Get the actual inner schema
-	var innerSchema *Schema
-	if len(schema.AnyOf) > 0 && len(schema.AnyOf[0].Fields) > 0 {
-		innerSchema = &schema.AnyOf[0].Fields[0]
-	}
-
-	if innerSchema != nil && innerSchema.IsRef() {
-		refName := innerSchema.RefName()
-		switch refName {
-		case "Int":
-			return "\treturn NewConstrPlutusData(0, NewIntPlutusData(v.Value)), nil\n"
-		case "ByteArray":
-			return "\treturn NewConstrPlutusData(0, NewBytesPlutusData(v.Value)), nil\n"
-		case "Data":
-			return "\treturn NewConstrPlutusData(0, v.Value), nil\n"
-		default:
-			if g.isPrimitiveWrapper(refName, "bytes") {
-				return "\treturn NewConstrPlutusData(0, NewBytesPlutusData(v.Value)), nil\n"
-			}
-			if g.isPrimitiveWrapper(refName, "integer") {
-				return "\treturn NewConstrPlutusData(0, NewIntPlutusData(v.Value)), nil\n"
-			}
-		}
+func (g *Generator) writeOptionType(name string, option *ir.Option) error {
+	// Option is an enum with Some (0) and None (1) constructors; the
+	// definitions without a Some field were rejected before
+	inner := option.Elem
+	data := &OptionData{
+		TypeData:  TypeData{Name: name, Receiver: name, Kind: "option"},
+		InnerType: g.goType(inner),
+		// Get the inner serialization/deserialization code
+		ToPlutusDataInner:   g.getOptionInnerToPlutusDataCode(inner),
+		FromPlutusDataInner: g.getOptionInnerFromPlutusDataCode(inner),
+		EqualsInner:         g.capture(1, func() { g.writeOptionValueEquals(inner) }),
 	}
+	data.ValidateInner = g.plutusValidateCall(inner, "v.Value")
+	// Streaming CBOR decoder and direct encoder
+	data.CBORDecoder, _ = g.cborDecoder(inner)
+	data.CBOREncoder, _ = g.cborEncoder(inner)
 
-	if innerSchema != nil && innerSchema.IsInteger() {
-		return "\treturn NewConstrPlutusData(0, NewIntPlutusData(v.Value)), nil\n"
+	if err := g.writeTemplate("option_type.go.tmpl", data); err != nil {
+		return err
 	}
+	return g.writeExtras(data.TypeData)
+}
 
-	if innerSchema != nil && innerSchema.IsBytes() {
+func (g *Generator) getOptionInnerToPlutusDataCode(inner ir.Type) string {
+	switch {
+	case g.isContainer(inner):
+		return fmt.Sprintf("\tinnerPd, err := %s\n\tif err != nil {\n\t\treturn PlutusData{}, validationErrorAt(err, \"Some\")\n\t}\n\treturn NewConstrPlutusData(0, innerPd), nil\n", g.plutusEncodeCall(inner, "v.Value"))
+	case isInt(inner):
+		return optionNilIntCheck + "\treturn NewConstrPlutusData(0, NewIntPlutusData(v.Value)), nil\n"
+	case isBytes(inner):
 		return "\treturn NewConstrPlutusData(0, NewBytesPlutusData(v.Value)), nil\n"
+	case isData(inner):
+		return "\treturn NewConstrPlutusData(0, v.Value), nil\n"
 	}
 
 	// Complex inner type - need to check for nil enum
 	var buf strings.Builder
-	if innerSchema != nil && innerSchema.IsRef() {
-		refName := innerSchema.RefName()
-		if defSchema, ok := g.bp.Definitions[g.unescapeRef(refName)]; ok && defSchema.IsEnum() && !defSchema.IsSingleConstructor() {
-			buf.WriteString("\tif v.Value == nil {\n")
-			buf.WriteString(fmt.Sprintf("\t\treturn PlutusData{}, fmt.Errorf(\"%s.Value: value is nil (expected %s)\")\n", optionName, g.normalizeTypeName(refName)))
-			buf.WriteString("\t}\n")
-		}
+	if g.isEnum(inner) {
+		buf.WriteString("\tif v.Value == nil {\n")
+		buf.WriteString(fmt.Sprintf("\t\treturn PlutusData{}, validationErrorAt(nilValueError(%q), \"Some\")\n", g.goType(inner)))
+		buf.WriteString("\t}\n")
 	}
 	buf.WriteString("\tinnerPd, err := v.Value.ToPlutusData()\n")
 	buf.WriteString("\tif err != nil {\n")
-	buf.WriteString(fmt.Sprintf("\t\treturn PlutusData{}, fmt.Errorf(\"%s.Value: %%w\", err)\n", optionName))
+	buf.WriteString("\t\treturn PlutusData{}, validationErrorAt(err, \"Some\")\n")
 	buf.WriteString("\t}\n")
 	buf.WriteString("\treturn NewConstrPlutusData(0, innerPd), nil\n")
 	return buf.String()
 }
 
-func (g *Generator) getOptionInnerFromPlutusDataCode(optionName string, schema *Schema) string {
-	// Get the actual inner schema
-	var innerSchema *Schema
-	if len(schema.AnyOf) > 0 && len(schema.AnyOf[0].Fields) > 0 {
-		innerSchema = &schema.AnyOf[0].Fields[0]
-	}
-
-	if innerSchema != nil && innerSchema.IsRef() {
-		refName := innerSchema.RefName()
-		switch refName {
-		case "Int":
-			return fmt.Sprintf("\tif pd.Constr.Fields[0].Integer == nil {\n\t\treturn fmt.Errorf(\"%s: expected integer for Some value, got %%s\", plutusDataTypeString(pd.Constr.Fields[0]))\n\t}\n\tv.Value = pd.Constr.Fields[0].Integer\n", optionName)
-		case "ByteArray":
-			return fmt.Sprintf("\tif pd.Constr.Fields[0].ByteString == nil {\n\t\treturn fmt.Errorf(\"%s: expected bytes for Some value, got %%s\", plutusDataTypeString(pd.Constr.Fields[0]))\n\t}\n\tv.Value = pd.Constr.Fields[0].ByteString\n", optionName)
-		case "Data":
-			return "\tv.Value = pd.Constr.Fields[0]\n"
-		default:
-			if g.isPrimitiveWrapper(refName, "bytes") {
-				return fmt.Sprintf("\tif pd.Constr.Fields[0].ByteString == nil {\n\t\treturn fmt.Errorf(\"%s: expected bytes for Some value, got %%s\", plutusDataTypeString(pd.Constr.Fields[0]))\n\t}\n\tv.Value = pd.Constr.Fields[0].ByteString\n", optionName)
-			}
-			if g.isPrimitiveWrapper(refName, "integer") {
-				return fmt.Sprintf("\tif pd.Constr.Fields[0].Integer == nil {\n\t\treturn fmt.Errorf(\"%s: expected integer for Some value, got %%s\", plutusDataTypeString(pd.Constr.Fields[0]))\n\t}\n\tv.Value = pd.Constr.Fields[0].Integer\n", optionName)
-			}
-		}
-	}
-
-	if innerSchema != nil && innerSchema.IsInteger() {
-		return fmt.Sprintf("\tif pd.Constr.Fields[0].Integer == nil {\n\t\treturn fmt.Errorf(\"%s: expected integer for Some value, got %%s\", plutusDataTypeString(pd.Constr.Fields[0]))\n\t}\n\tv.Value = pd.Constr.Fields[0].Integer\n", optionName)
-	}
-
-	if innerSchema != nil && innerSchema.IsBytes() {
-		return fmt.Sprintf("\tif pd.Constr.Fields[0].ByteString == nil {\n\t\treturn fmt.Errorf(\"%s: expected bytes for Some value, got %%s\", plutusDataTypeString(pd.Constr.Fields[0]))\n\t}\n\tv.Value = pd.Constr.Fields[0].ByteString\n", optionName)
-	}
-
-	// Complex inner type - check if it's an enum
-	if innerSchema != nil && innerSchema.IsRef() {
-		refName := innerSchema.RefName()
-		unescaped := g.unescapeRef(refName)
-		if def, ok := g.bp.Definitions[unescaped]; ok && def.IsEnum() && !def.IsSingleConstructor() {
-			typeName := g.normalizeTypeName(refName)
-			return fmt.Sprintf("\tinnerVal, err := %sFromPlutusData(pd.Constr.Fields[0])\n\tif err != nil {\n\t\treturn fmt.Errorf(\"%s: %%w\", err)\n\t}\n\tv.Value = innerVal\n", typeName, optionName)
-		}
+func (g *Generator) getOptionInnerFromPlutusDataCode(inner ir.Type) string {
+	switch {
+	case g.isContainer(inner):
+		return fmt.Sprintf("\tif err := %s; err != nil {\n\t\treturn decodeErrorAt(err, \"Some\")\n\t}\n", g.plutusDecodeCall(inner, "pd.Constr.Fields[0]", "&v.Value"))
+	case isInt(inner):
+		return "\tif pd.Constr.Fields[0].Kind() != KindInteger {\n\t\treturn decodeErrorAt(decodeKindError(\"*big.Int\", \"integer\", pd.Constr.Fields[0]), \"Some\")\n\t}\n\tv.Value = pd.Constr.Fields[0].Integer\n"
+	case isBytes(inner):
+		return "\tif pd.Constr.Fields[0].Kind() != KindBytes {\n\t\treturn decodeErrorAt(decodeKindError(\"[]byte\", \"bytes\", pd.Constr.Fields[0]), \"Some\")\n\t}\n\tv.Value = pd.Constr.Fields[0].ByteString\n"
+	case isData(inner):
+		return "\tv.Value = pd.Constr.Fields[0]\n"
+	case g.isEnum(inner):
+		return fmt.Sprintf("\tinnerVal, err := %sFromPlutusData(pd.Constr.Fields[0])\n\tif err != nil {\n\t\treturn decodeErrorAt(err, \"Some\")\n\t}\n\tv.Value = innerVal\n", g.goType(inner))
+	default:
+		// Non-enum complex type
+		return "\tif err := v.Value.FromPlutusData(pd.Constr.Fields[0]); err != nil {\n\t\treturn decodeErrorAt(err, \"Some\")\n\t}\n"
 	}
-
-	// Non-enum complex type
-	return fmt.Sprintf("\tif err := v.Value.FromPlutusData(pd.Constr.Fields[0]); err != nil {\n\t\treturn fmt.Errorf(\"%s: %%w\", err)\n\t}\n", optionName)
 }
 
-func (g *Generator) writeOptionEquals(name string, schema *Schema) {
-	g.writeLine(fmt.Sprintf("func (v %s) Equals(other %s) bool {", name, name))
-	g.indentInc()
-	g.writeLine("if v.IsSet != other.IsSet {")
-	g.indentInc()
-	g.writeLine("return false")
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("if !v.IsSet {")
-	g.indentInc()
-	g.writeLine("return true // Both are None")
-	g.indentDec()
-	g.writeLine("}")
-
-	// Get the actual inner schema
-	var innerSchema *Schema
-	if len(schema.AnyOf) > 0 && len(schema.AnyOf[0].Fields) > 0 {
-		innerSchema = &schema.AnyOf[0].Fields[0]
-	}
-
-	if innerSchema != nil && innerSchema.IsRef() {
-		refName := innerSchema.RefName()
-		switch refName {
-		case "Int":
-			g.writeLine("if v.Value == nil && other.Value == nil {")
-			g.indentInc()
-			g.writeLine("return true")
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine("if v.Value == nil || other.Value == nil {")
-			g.indentInc()
-			g.writeLine("return false")
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine("return v.Value.Cmp(other.Value) == 0")
-		case "ByteArray":
-			g.writeLine("return bytes.Equal(v.Value, other.Value)")
-		case "Data":
-			g.writeLine("return v.Value.Equals(other.Value)")
-		default:
-			if g.isPrimitiveWrapper(refName, "bytes") {
-				g.writeLine("return bytes.Equal(v.Value, other.Value)")
-			} else if g.isPrimitiveWrapper(refName, "integer") {
-				g.writeLine("if v.Value == nil && other.Value == nil {")
-				g.indentInc()
-				g.writeLine("return true")
-				g.indentDec()
-				g.writeLine("}")
-				g.writeLine("if v.Value == nil || other.Value == nil {")
-				g.indentInc()
-				g.writeLine("return false")
-				g.indentDec()
-				g.writeLine("}")
-				g.writeLine("return v.Value.Cmp(other.Value) == 0")
-			} else if defSchema, ok := g.bp.Definitions[g.unescapeRef(refName)]; ok && defSchema.IsEnum() && !defSchema.IsSingleConstructor() {
-				typeName := g.normalizeTypeName(refName)
-				g.writeLine(fmt.Sprintf("return %sEquals(v.Value, other.Value)", typeName))
-			} else {
-				g.writeLine("return v.Value.Equals(other.Value)")
-			}
-		}
-	} else if innerSchema != nil && innerSchema.IsInteger() {
+// writeOptionValueEquals writes the statements returning whether the values
+// of two set options are equal.
+func (g *Generator) writeOptionValueEquals(inner ir.Type) {
+	switch {
+	case g.isContainer(inner):
+		g.writeLine("return " + g.plutusEqualsCall(inner, "v.Value", "other.Value"))
+	case isInt(inner):
 		g.writeLine("if v.Value == nil && other.Value == nil {")
 		g.indentInc()
 		g.writeLine("return true")
@@ -349,181 +375,101 @@ func (g *Generator) writeOptionEquals(name string, schema *Schema) {
 		g.indentDec()
 		g.writeLine("}")
 		g.writeLine("return v.Value.Cmp(other.Value) == 0")
-	} else if innerSchema != nil && innerSchema.IsBytes() {
+	case isBytes(inner):
 		g.writeLine("return bytes.Equal(v.Value, other.Value)")
-	} else {
+	case g.isEnum(inner):
+		g.writeLine(fmt.Sprintf("return %sEquals(v.Value, other.Value)", g.goType(inner)))
+	default:
 		g.writeLine("return v.Value.Equals(other.Value)")
 	}
-
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("")
-}
-
-func (g *Generator) writeBoolType(name string, _ *Schema) error {
-	g.executeTemplate("bool_type.go.tmpl", map[string]string{"Name": name})
-	g.writeLine("")
-	return nil
 }
 
-func (g *Generator) writeStructType(name string, schema *Schema, constrIndex int) error {
-	// Documentation
-	if schema.Title != "" {
-		g.writeLine(fmt.Sprintf("// %s represents the Aiken %s type.", name, schema.Title))
-	}
-
-	// Struct definition
-	g.writeLine(fmt.Sprintf("type %s struct {", name))
-	g.indentInc()
-
-	for i, field := range schema.Fields {
-		fieldName := g.normalizeFieldName(field.Title, i)
-		fieldType := g.schemaToGoType(&field)
-		g.writeLine(fmt.Sprintf("%s %s", fieldName, fieldType))
+func (g *Generator) writeBoolType(name string) error {
+	data := TypeData{Name: name, Receiver: name, Kind: "bool"}
+	if err := g.writeTemplate("bool_type.go.tmpl", data); err != nil {
+		return err
 	}
-
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("")
-
-	// Generate ToPlutusData method
-	g.writeStructToPlutusData(name, schema, constrIndex)
-
-	// Generate FromPlutusData method
-	g.writeStructFromPlutusData(name, schema, constrIndex)
-
-	// Generate Equals method
-	g.writeStructEquals(name, schema)
-
-	return nil
+	return g.writeExtras(data)
 }
 
-func (g *Generator) writeStructEquals(name string, schema *Schema) {
-	g.writeLine(fmt.Sprintf("func (v %s) Equals(other %s) bool {", name, name))
-	g.indentInc()
-
-	if len(schema.Fields) == 0 {
-		g.writeLine("return true")
-	} else {
-		for i, field := range schema.Fields {
-			fieldName := g.normalizeFieldName(field.Title, i)
-			g.writeFieldEquals(fieldName, &field)
-		}
-		g.writeLine("return true")
+func (g *Generator) writeStructType(name string, record *ir.Record) error {
+	data := g.structData(name, "record", record.Title, record.Fields, record.Index)
+	if err := g.writeTemplate("struct_type.go.tmpl", data); err != nil {
+		return err
 	}
-
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("")
+	return g.writeExtras(data.TypeData)
 }
 
-func (g *Generator) writeFieldEquals(fieldName string, schema *Schema) {
-	switch {
-	case schema.IsRef():
-		refName := schema.RefName()
-		switch refName {
-		case "Int":
-			g.writeLine(fmt.Sprintf("if v.%s == nil && other.%s == nil {", fieldName, fieldName))
-			g.writeLine(fmt.Sprintf("} else if v.%s == nil || other.%s == nil || v.%s.Cmp(other.%s) != 0 {", fieldName, fieldName, fieldName, fieldName))
-			g.indentInc()
-			g.writeLine("return false")
-			g.indentDec()
-			g.writeLine("}")
-		case "ByteArray":
-			g.writeLine(fmt.Sprintf("if !bytes.Equal(v.%s, other.%s) {", fieldName, fieldName))
-			g.indentInc()
-			g.writeLine("return false")
-			g.indentDec()
-			g.writeLine("}")
-		case "Bool":
-			g.writeLine(fmt.Sprintf("if v.%s != other.%s {", fieldName, fieldName))
-			g.indentInc()
-			g.writeLine("return false")
-			g.indentDec()
-			g.writeLine("}")
-		case "Data":
-			// Data type is PlutusData - use its Equals method
-			g.writeLine(fmt.Sprintf("if !v.%s.Equals(other.%s) {", fieldName, fieldName))
-			g.indentInc()
-			g.writeLine("return false")
-			g.indentDec()
-			g.writeLine("}")
-		default:
-			if strings.HasPrefix(refName, "List$") {
-				g.writeListFieldEquals(fieldName, refName)
-			} else if strings.HasPrefix(refName, "Pairs$") {
-				// Map type - use reflect.DeepEqual
-				g.writeLine(fmt.Sprintf("if !reflect.DeepEqual(v.%s, other.%s) {", fieldName, fieldName))
-				g.indentInc()
-				g.writeLine("return false")
-				g.indentDec()
-				g.writeLine("}")
-			} else if strings.HasPrefix(refName, "Option$") {
-				g.writeOptionFieldEquals(fieldName, refName)
-			} else if g.isPrimitiveWrapper(refName, "bytes") {
-				g.writeLine(fmt.Sprintf("if !bytes.Equal(v.%s, other.%s) {", fieldName, fieldName))
-				g.indentInc()
-				g.writeLine("return false")
-				g.indentDec()
-				g.writeLine("}")
-			} else if g.isPrimitiveWrapper(refName, "integer") {
-				g.writeLine(fmt.Sprintf("if v.%s == nil && other.%s == nil {", fieldName, fieldName))
-				g.writeLine(fmt.Sprintf("} else if v.%s == nil || other.%s == nil || v.%s.Cmp(other.%s) != 0 {", fieldName, fieldName, fieldName, fieldName))
-				g.indentInc()
-				g.writeLine("return false")
-				g.indentDec()
-				g.writeLine("}")
-			} else {
-				// Check if it's an enum type (interface)
-				if defSchema, ok := g.bp.Definitions[g.unescapeRef(refName)]; ok && defSchema.IsEnum() && !defSchema.IsSingleConstructor() {
-					typeName := g.normalizeTypeName(refName)
-					g.writeLine(fmt.Sprintf("if !%sEquals(v.%s, other.%s) {", typeName, fieldName, fieldName))
-					g.indentInc()
-					g.writeLine("return false")
-					g.indentDec()
-					g.writeLine("}")
-				} else {
-					// Struct type with Equals method
-					g.writeLine(fmt.Sprintf("if !v.%s.Equals(other.%s) {", fieldName, fieldName))
-					g.indentInc()
-					g.writeLine("return false")
-					g.indentDec()
-					g.writeLine("}")
-				}
-			}
+// structData returns the data of a record or an enum variant, with the
+// statements encoding, decoding and comparing each of its fields.
+func (g *Generator) structData(name, kind, title string, fields []*ir.Field, constrIndex int) *StructData {
+	data := &StructData{
+		TypeData:    TypeData{Name: name, Receiver: name, Kind: kind},
+		Title:       title,
+		ConstrIndex: constrIndex,
+	}
+	types := make([]ir.Type, len(fields))
+	for i, field := range fields {
+		fieldName := g.normalizeFieldName(field.Title, i)
+		if kind == "variant" {
+			fieldName = variantFieldName(fieldName)
 		}
-	case schema.IsInteger():
+		types[i] = field.Type
+		data.Fields = append(data.Fields, &FieldData{
+			Name:           fieldName,
+			Title:          field.Title,
+			GoType:         g.goType(field.Type),
+			Index:          i,
+			ToPlutusData:   g.capture(1, func() { g.writeFieldToPlutusData(fieldName, field.Type, i) }),
+			FromPlutusData: g.capture(1, func() { g.writeFieldFromPlutusData(fieldName, field.Type, i) }),
+			Equals:         g.capture(1, func() { g.writeFieldEquals(fieldName, field.Type) }),
+			Validate:       g.fieldValidate(fieldName, field.Type),
+			Param:          constructorParam(fieldName),
+		})
+	}
+	g.setCBORCodecs(data, types)
+	return data
+}
+
+func (g *Generator) writeFieldEquals(fieldName string, t ir.Type) {
+	switch {
+	case g.equalsByEncoding(t):
+		g.writeLine(fmt.Sprintf("if !%s {", g.plutusEqualsCall(t, "v."+fieldName, "other."+fieldName)))
+		g.indentInc()
+		g.writeLine("return false")
+		g.indentDec()
+		g.writeLine("}")
+	case isInt(t):
 		g.writeLine(fmt.Sprintf("if v.%s == nil && other.%s == nil {", fieldName, fieldName))
 		g.writeLine(fmt.Sprintf("} else if v.%s == nil || other.%s == nil || v.%s.Cmp(other.%s) != 0 {", fieldName, fieldName, fieldName, fieldName))
 		g.indentInc()
 		g.writeLine("return false")
 		g.indentDec()
 		g.writeLine("}")
-	case schema.IsBytes():
+	case isBytes(t):
 		g.writeLine(fmt.Sprintf("if !bytes.Equal(v.%s, other.%s) {", fieldName, fieldName))
 		g.indentInc()
 		g.writeLine("return false")
 		g.indentDec()
 		g.writeLine("}")
-	case schema.IsList():
-		g.writeInlineListFieldEquals(fieldName, schema)
-	case schema.IsMap():
-		// Map type - use reflect.DeepEqual
-		g.writeLine(fmt.Sprintf("if !reflect.DeepEqual(v.%s, other.%s) {", fieldName, fieldName))
+	case t == ir.Bool:
+		g.writeLine(fmt.Sprintf("if v.%s != other.%s {", fieldName, fieldName))
 		g.indentInc()
 		g.writeLine("return false")
 		g.indentDec()
 		g.writeLine("}")
-	case schema.IsBoolean():
-		g.writeLine(fmt.Sprintf("if v.%s != other.%s {", fieldName, fieldName))
+	case g.isContainer(t):
+		// A list, compared item by item
+		g.writeListFieldEquals(fieldName, t.(*ir.List).Elem)
+	case g.isEnum(t):
+		// Enum types are interfaces, compared by XxxEquals
+		g.writeLine(fmt.Sprintf("if !%sEquals(v.%s, other.%s) {", g.goType(t), fieldName, fieldName))
 		g.indentInc()
 		g.writeLine("return false")
 		g.indentDec()
 		g.writeLine("}")
-	case schema.IsOption():
-		g.writeInlineOptionFieldEquals(fieldName, schema)
 	default:
-		// Try struct equals
+		// Data, options and structs have an Equals method
 		g.writeLine(fmt.Sprintf("if !v.%s.Equals(other.%s) {", fieldName, fieldName))
 		g.indentInc()
 		g.writeLine("return false")
@@ -532,10 +478,7 @@ func (g *Generator) writeFieldEquals(fieldName string, schema *Schema) {
 	}
 }
 
-func (g *Generator) writeListFieldEquals(fieldName string, refName string) {
-	inner := strings.TrimPrefix(refName, "List$")
-	inner = strings.ReplaceAll(inner, "~1", "/")
-
+func (g *Generator) writeListFieldEquals(fieldName string, item ir.Type) {
 	g.writeLine(fmt.Sprintf("if len(v.%s) != len(other.%s) {", fieldName, fieldName))
 	g.indentInc()
 	g.writeLine("return false")
@@ -544,204 +487,34 @@ func (g *Generator) writeListFieldEquals(fieldName string, refName string) {
 	g.writeLine(fmt.Sprintf("for i := range v.%s {", fieldName))
 	g.indentInc()
 
-	switch inner {
-	case "Int":
+	switch {
+	case isInt(item):
 		g.writeLine(fmt.Sprintf("if v.%s[i] == nil && other.%s[i] == nil {", fieldName, fieldName))
 		g.writeLine(fmt.Sprintf("} else if v.%s[i] == nil || other.%s[i] == nil || v.%s[i].Cmp(other.%s[i]) != 0 {", fieldName, fieldName, fieldName, fieldName))
 		g.indentInc()
 		g.writeLine("return false")
 		g.indentDec()
 		g.writeLine("}")
-	case "ByteArray":
+	case isBytes(item):
 		g.writeLine(fmt.Sprintf("if !bytes.Equal(v.%s[i], other.%s[i]) {", fieldName, fieldName))
 		g.indentInc()
 		g.writeLine("return false")
 		g.indentDec()
 		g.writeLine("}")
-	case "Bool":
+	case item == ir.Bool:
 		g.writeLine(fmt.Sprintf("if v.%s[i] != other.%s[i] {", fieldName, fieldName))
 		g.indentInc()
 		g.writeLine("return false")
 		g.indentDec()
 		g.writeLine("}")
-	default:
-		if g.isPrimitiveWrapper(inner, "bytes") {
-			g.writeLine(fmt.Sprintf("if !bytes.Equal(v.%s[i], other.%s[i]) {", fieldName, fieldName))
-			g.indentInc()
-			g.writeLine("return false")
-			g.indentDec()
-			g.writeLine("}")
-		} else if g.isPrimitiveWrapper(inner, "integer") {
-			g.writeLine(fmt.Sprintf("if v.%s[i] == nil && other.%s[i] == nil {", fieldName, fieldName))
-			g.writeLine(fmt.Sprintf("} else if v.%s[i] == nil || other.%s[i] == nil || v.%s[i].Cmp(other.%s[i]) != 0 {", fieldName, fieldName, fieldName, fieldName))
-			g.indentInc()
-			g.writeLine("return false")
-			g.indentDec()
-			g.writeLine("}")
-		} else {
-			// Check if enum
-			if defSchema, ok := g.bp.Definitions[g.unescapeRef(inner)]; ok && defSchema.IsEnum() && !defSchema.IsSingleConstructor() {
-				typeName := g.normalizeTypeName(inner)
-				g.writeLine(fmt.Sprintf("if !%sEquals(v.%s[i], other.%s[i]) {", typeName, fieldName, fieldName))
-				g.indentInc()
-				g.writeLine("return false")
-				g.indentDec()
-				g.writeLine("}")
-			} else {
-				g.writeLine(fmt.Sprintf("if !v.%s[i].Equals(other.%s[i]) {", fieldName, fieldName))
-				g.indentInc()
-				g.writeLine("return false")
-				g.indentDec()
-				g.writeLine("}")
-			}
-		}
-	}
-
-	g.indentDec()
-	g.writeLine("}")
-}
-
-func (g *Generator) writeOptionFieldEquals(fieldName string, refName string) {
-	g.writeLine(fmt.Sprintf("if !v.%s.Equals(other.%s) {", fieldName, fieldName))
-	g.indentInc()
-	g.writeLine("return false")
-	g.indentDec()
-	g.writeLine("}")
-}
-
-func (g *Generator) writeInlineListFieldEquals(fieldName string, schema *Schema) {
-	g.writeLine(fmt.Sprintf("if len(v.%s) != len(other.%s) {", fieldName, fieldName))
-	g.indentInc()
-	g.writeLine("return false")
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine(fmt.Sprintf("for i := range v.%s {", fieldName))
-	g.indentInc()
-
-	if len(schema.Items) > 0 && schema.Items.Single() != nil {
-		itemSchema := schema.Items.Single()
-		switch {
-		case itemSchema.IsRef():
-			refName := itemSchema.RefName()
-			switch refName {
-			case "Int":
-				g.writeLine(fmt.Sprintf("if v.%s[i] == nil && other.%s[i] == nil {", fieldName, fieldName))
-				g.writeLine(fmt.Sprintf("} else if v.%s[i] == nil || other.%s[i] == nil || v.%s[i].Cmp(other.%s[i]) != 0 {", fieldName, fieldName, fieldName, fieldName))
-				g.indentInc()
-				g.writeLine("return false")
-				g.indentDec()
-				g.writeLine("}")
-			case "ByteArray":
-				g.writeLine(fmt.Sprintf("if !bytes.Equal(v.%s[i], other.%s[i]) {", fieldName, fieldName))
-				g.indentInc()
-				g.writeLine("return false")
-				g.indentDec()
-				g.writeLine("}")
-			default:
-				g.writeLine(fmt.Sprintf("if !v.%s[i].Equals(other.%s[i]) {", fieldName, fieldName))
-				g.indentInc()
-				g.writeLine("return false")
-				g.indentDec()
-				g.writeLine("}")
-			}
-		case itemSchema.IsInteger():
-			g.writeLine(fmt.Sprintf("if v.%s[i] == nil && other.%s[i] == nil {", fieldName, fieldName))
-			g.writeLine(fmt.Sprintf("} else if v.%s[i] == nil || other.%s[i] == nil || v.%s[i].Cmp(other.%s[i]) != 0 {", fieldName, fieldName, fieldName, fieldName))
-			g.indentInc()
-			g.writeLine("return false")
-			g.indentDec()
-			g.writeLine("}")
-		case itemSchema.IsBytes():
-			g.writeLine(fmt.Sprintf("if !bytes.Equal(v.%s[i], other.%s[i]) {", fieldName, fieldName))
-			g.indentInc()
-			g.writeLine("return false")
-			g.indentDec()
-			g.writeLine("}")
-		default:
-			g.writeLine(fmt.Sprintf("if !v.%s[i].Equals(other.%s[i]) {", fieldName, fieldName))
-			g.indentInc()
-			g.writeLine("return false")
-			g.indentDec()
-			g.writeLine("}")
-		}
-	} else {
-		g.writeLine(fmt.Sprintf("if !v.%s[i].Equals(other.%s[i]) {", fieldName, fieldName))
+	case g.isEnum(item):
+		g.writeLine(fmt.Sprintf("if !%sEquals(v.%s[i], other.%s[i]) {", g.goType(item), fieldName, fieldName))
 		g.indentInc()
 		g.writeLine("return false")
 		g.indentDec()
 		g.writeLine("}")
-	}
-
-	g.indentDec()
-	g.writeLine("}")
-}
-
-func (g *Generator) writeInlineOptionFieldEquals(fieldName string, schema *Schema) {
-	g.writeLine(fmt.Sprintf("if v.%s.IsSet != other.%s.IsSet {", fieldName, fieldName))
-	g.indentInc()
-	g.writeLine("return false")
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine(fmt.Sprintf("if v.%s.IsSet {", fieldName))
-	g.indentInc()
-
-	inner := schema.OptionInnerType()
-	if inner != nil {
-		switch {
-		case inner.IsRef():
-			refName := inner.RefName()
-			switch refName {
-			case "Int":
-				g.writeLine(fmt.Sprintf("if v.%s.Value == nil && other.%s.Value == nil {", fieldName, fieldName))
-				g.writeLine(fmt.Sprintf("} else if v.%s.Value == nil || other.%s.Value == nil || v.%s.Value.Cmp(other.%s.Value) != 0 {", fieldName, fieldName, fieldName, fieldName))
-				g.indentInc()
-				g.writeLine("return false")
-				g.indentDec()
-				g.writeLine("}")
-			case "ByteArray":
-				g.writeLine(fmt.Sprintf("if !bytes.Equal(v.%s.Value, other.%s.Value) {", fieldName, fieldName))
-				g.indentInc()
-				g.writeLine("return false")
-				g.indentDec()
-				g.writeLine("}")
-			default:
-				if defSchema, ok := g.bp.Definitions[g.unescapeRef(refName)]; ok && defSchema.IsEnum() && !defSchema.IsSingleConstructor() {
-					typeName := g.normalizeTypeName(refName)
-					g.writeLine(fmt.Sprintf("if !%sEquals(v.%s.Value, other.%s.Value) {", typeName, fieldName, fieldName))
-					g.indentInc()
-					g.writeLine("return false")
-					g.indentDec()
-					g.writeLine("}")
-				} else {
-					g.writeLine(fmt.Sprintf("if !v.%s.Value.Equals(other.%s.Value) {", fieldName, fieldName))
-					g.indentInc()
-					g.writeLine("return false")
-					g.indentDec()
-					g.writeLine("}")
-				}
-			}
-		case inner.IsInteger():
-			g.writeLine(fmt.Sprintf("if v.%s.Value == nil && other.%s.Value == nil {", fieldName, fieldName))
-			g.writeLine(fmt.Sprintf("} else if v.%s.Value == nil || other.%s.Value == nil || v.%s.Value.Cmp(other.%s.Value) != 0 {", fieldName, fieldName, fieldName, fieldName))
-			g.indentInc()
-			g.writeLine("return false")
-			g.indentDec()
-			g.writeLine("}")
-		case inner.IsBytes():
-			g.writeLine(fmt.Sprintf("if !bytes.Equal(v.%s.Value, other.%s.Value) {", fieldName, fieldName))
-			g.indentInc()
-			g.writeLine("return false")
-			g.indentDec()
-			g.writeLine("}")
-		default:
-			g.writeLine(fmt.Sprintf("if !v.%s.Value.Equals(other.%s.Value) {", fieldName, fieldName))
-			g.indentInc()
-			g.writeLine("return false")
-			g.indentDec()
-			g.writeLine("}")
-		}
-	} else {
-		g.writeLine(fmt.Sprintf("if !v.%s.Value.Equals(other.%s.Value) {", fieldName, fieldName))
+	default:
+		g.writeLine(fmt.Sprintf("if !v.%s[i].Equals(other.%s[i]) {", fieldName, fieldName))
 		g.indentInc()
 		g.writeLine("return false")
 		g.indentDec()
@@ -752,110 +525,23 @@ func (g *Generator) writeInlineOptionFieldEquals(fieldName string, schema *Schem
 	g.writeLine("}")
 }
 
-func (g *Generator) writeStructToPlutusData(name string, schema *Schema, constrIndex int) {
-	g.writeLine(fmt.Sprintf("func (v %s) ToPlutusData() (PlutusData, error) {", name))
-	g.indentInc()
-
-	if len(schema.Fields) == 0 {
-		g.writeLine(fmt.Sprintf("return NewConstrPlutusData(%d), nil", constrIndex))
-	} else {
-		g.writeLine(fmt.Sprintf("fields := make([]PlutusData, %d)", len(schema.Fields)))
-		for i, field := range schema.Fields {
-			fieldName := g.normalizeFieldName(field.Title, i)
-			g.writeFieldToPlutusData(fieldName, &field, i)
-		}
-		g.writeLine(fmt.Sprintf("return NewConstrPlutusData(%d, fields...), nil", constrIndex))
-	}
-
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("")
-}
-
-func (g *Generator) writeFieldToPlutusData(fieldName string, schema *Schema, index int) {
+func (g *Generator) writeFieldToPlutusData(fieldName string, t ir.Type, index int) {
 	switch {
-	case schema.IsRef():
-		refName := schema.RefName()
-		switch refName {
-		case "Int":
-			g.writeLine(fmt.Sprintf("fields[%d] = NewIntPlutusData(v.%s)", index, fieldName))
-		case "ByteArray":
-			g.writeLine(fmt.Sprintf("fields[%d] = NewBytesPlutusData(v.%s)", index, fieldName))
-		case "Bool":
-			g.writeLine(fmt.Sprintf("if v.%s {", fieldName))
-			g.indentInc()
-			g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(1)", index))
-			g.indentDec()
-			g.writeLine("} else {")
-			g.indentInc()
-			g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(0)", index))
-			g.indentDec()
-			g.writeLine("}")
-		case "Data":
-			// Data type is raw PlutusData
-			g.writeLine(fmt.Sprintf("fields[%d] = v.%s", index, fieldName))
-		default:
-			if strings.HasPrefix(refName, "List$") {
-				// List type - handle inline
-				g.writeListFieldToPlutusData(fieldName, refName, index)
-			} else if strings.HasPrefix(refName, "Pairs$") {
-				// Map type - serialize as PlutusData map
-				g.writeMapFieldToPlutusData(fieldName, refName, index)
-			} else if strings.HasPrefix(refName, "Option$") {
-				// Option type - handle as pointer
-				g.writeOptionRefToPlutusData(fieldName, refName, index)
-			} else if g.isPrimitiveWrapper(refName, "bytes") {
-				// Primitive wrapper for bytes
-				g.writeLine(fmt.Sprintf("fields[%d] = NewBytesPlutusData(v.%s)", index, fieldName))
-			} else if g.isPrimitiveWrapper(refName, "integer") {
-				// Primitive wrapper for integer
-				g.writeLine(fmt.Sprintf("fields[%d] = NewIntPlutusData(v.%s)", index, fieldName))
-			} else {
-				// Custom type with ToPlutusData
-				// Check if it's an enum type (interface) that could be nil
-				if defSchema, ok := g.bp.Definitions[g.unescapeRef(refName)]; ok && defSchema.IsEnum() && !defSchema.IsSingleConstructor() {
-					g.writeLine(fmt.Sprintf("if v.%s == nil {", fieldName))
-					g.indentInc()
-					g.writeLine(fmt.Sprintf(`return PlutusData{}, fmt.Errorf("field %s: value is nil (expected %s)")`, fieldName, g.normalizeTypeName(refName)))
-					g.indentDec()
-					g.writeLine("}")
-				}
-				g.writeLine(fmt.Sprintf("field%d, err := v.%s.ToPlutusData()", index, fieldName))
-				g.writeLine("if err != nil {")
-				g.indentInc()
-				g.writeLine(fmt.Sprintf(`return PlutusData{}, fmt.Errorf("field %s: %%w", err)`, fieldName))
-				g.indentDec()
-				g.writeLine("}")
-				g.writeLine(fmt.Sprintf("fields[%d] = field%d", index, index))
-			}
-		}
-	case schema.IsInteger():
-		g.writeLine(fmt.Sprintf("fields[%d] = NewIntPlutusData(v.%s)", index, fieldName))
-	case schema.IsBytes():
-		g.writeLine(fmt.Sprintf("fields[%d] = NewBytesPlutusData(v.%s)", index, fieldName))
-	case schema.IsList():
-		g.writeLine(fmt.Sprintf("list%d := make([]PlutusData, len(v.%s))", index, fieldName))
-		g.writeLine(fmt.Sprintf("for i, item := range v.%s {", fieldName))
+	case g.isContainer(t):
+		// List or map, encoded item by item
+		g.writeLine(fmt.Sprintf("field%d, err := %s", index, g.plutusEncodeCall(t, "v."+fieldName)))
+		g.writeLine("if err != nil {")
 		g.indentInc()
-		if len(schema.Items) > 0 && schema.Items.Single() != nil {
-			itemSchema := schema.Items.Single()
-			g.writeListItemToPlutusData(itemSchema, index)
-		} else {
-			g.writeLine("itemPd, err := item.ToPlutusData()")
-			g.writeLine("if err != nil {")
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return PlutusData{}, fmt.Errorf("field %s[%%d]: %%w", i, err)`, fieldName))
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine(fmt.Sprintf("list%d[i] = itemPd", index))
-		}
+		g.writeLine(fmt.Sprintf(`return PlutusData{}, validationErrorAt(err, "%s")`, fieldName))
 		g.indentDec()
 		g.writeLine("}")
-		g.writeLine(fmt.Sprintf("fields[%d] = NewListPlutusData(list%d...)", index, index))
-	case schema.IsMap():
-		// Map type - serialize inline
-		g.writeInlineMapFieldToPlutusData(fieldName, schema, index)
-	case schema.IsBoolean():
+		g.writeLine(fmt.Sprintf("fields[%d] = field%d", index, index))
+	case isInt(t):
+		g.writeNilCheck("v."+fieldName, "*big.Int", strconv.Quote(fieldName))
+		g.writeLine(fmt.Sprintf("fields[%d] = NewIntPlutusData(v.%s)", index, fieldName))
+	case isBytes(t):
+		g.writeLine(fmt.Sprintf("fields[%d] = NewBytesPlutusData(v.%s)", index, fieldName))
+	case t == ir.Bool:
 		g.writeLine(fmt.Sprintf("if v.%s {", fieldName))
 		g.indentInc()
 		g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(1)", index))
@@ -865,1184 +551,336 @@ func (g *Generator) writeFieldToPlutusData(fieldName string, schema *Schema, ind
 		g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(0)", index))
 		g.indentDec()
 		g.writeLine("}")
-	case schema.IsOption():
-		inner := schema.OptionInnerType()
-		g.writeLine(fmt.Sprintf("if v.%s.IsSet {", fieldName))
-		g.indentInc()
-		if inner != nil && (inner.IsRef() || inner.IsInteger() || inner.IsBytes()) {
-			// Simple inner type
-			g.writeOptionSomeValue(fieldName, inner, index)
-		} else {
-			// Check if inner type is an enum (interface) that could be nil
-			if inner != nil && inner.IsRef() {
-				refName := inner.RefName()
-				if defSchema, ok := g.bp.Definitions[g.unescapeRef(refName)]; ok && defSchema.IsEnum() && !defSchema.IsSingleConstructor() {
-					g.writeLine(fmt.Sprintf("if v.%s.Value == nil {", fieldName))
-					g.indentInc()
-					g.writeLine(fmt.Sprintf(`return PlutusData{}, fmt.Errorf("field %s.Value: value is nil (expected %s)")`, fieldName, g.normalizeTypeName(refName)))
-					g.indentDec()
-					g.writeLine("}")
-				}
-			}
-			g.writeLine(fmt.Sprintf("innerPd, err := v.%s.Value.ToPlutusData()", fieldName))
-			g.writeLine("if err != nil {")
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return PlutusData{}, fmt.Errorf("field %s.Value: %%w", err)`, fieldName))
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(0, innerPd)", index))
-		}
-		g.indentDec()
-		g.writeLine("} else {")
-		g.indentInc()
-		g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(1)", index)) // None
-		g.indentDec()
-		g.writeLine("}")
+	case isData(t):
+		// Data type is raw PlutusData
+		g.writeLine(fmt.Sprintf("fields[%d] = v.%s", index, fieldName))
+	case g.isOption(t):
+		g.writeOptionFieldToPlutusData(fieldName, t.(*ir.Option).Elem, index)
 	default:
-		// Custom type
+		// Custom type with ToPlutusData; an enum is an interface that
+		// could be nil
+		if g.isEnum(t) {
+			g.writeNilCheck("v."+fieldName, g.goType(t), strconv.Quote(fieldName))
+		}
 		g.writeLine(fmt.Sprintf("field%d, err := v.%s.ToPlutusData()", index, fieldName))
 		g.writeLine("if err != nil {")
 		g.indentInc()
-		g.writeLine(fmt.Sprintf(`return PlutusData{}, fmt.Errorf("field %s: %%w", err)`, fieldName))
+		g.writeLine(fmt.Sprintf(`return PlutusData{}, validationErrorAt(err, "%s")`, fieldName))
 		g.indentDec()
 		g.writeLine("}")
 		g.writeLine(fmt.Sprintf("fields[%d] = field%d", index, index))
 	}
 }
 
-func (g *Generator) writeListFieldToPlutusData(fieldName, refName string, index int) {
-	// Extract inner type from List$Type
-	inner := strings.TrimPrefix(refName, "List$")
-	inner = strings.ReplaceAll(inner, "~1", "/")
-
-	g.writeLine(fmt.Sprintf("list%d := make([]PlutusData, len(v.%s))", index, fieldName))
-	g.writeLine(fmt.Sprintf("for i, item := range v.%s {", fieldName))
+func (g *Generator) writeOptionFieldToPlutusData(fieldName string, inner ir.Type, index int) {
+	g.writeLine(fmt.Sprintf("if v.%s.IsSet {", fieldName))
 	g.indentInc()
 
-	switch inner {
-	case "Int":
-		g.writeLine(fmt.Sprintf("list%d[i] = NewIntPlutusData(item)", index))
-	case "ByteArray":
-		g.writeLine(fmt.Sprintf("list%d[i] = NewBytesPlutusData(item)", index))
-	case "Bool":
-		g.writeLine("if item {")
-		g.indentInc()
-		g.writeLine(fmt.Sprintf("list%d[i] = NewConstrPlutusData(1)", index))
-		g.indentDec()
-		g.writeLine("} else {")
+	switch {
+	case isInt(inner):
+		g.writeNilCheck("v."+fieldName+".Value", "*big.Int", strconv.Quote(fieldName), `"Some"`)
+		g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(0, NewIntPlutusData(v.%s.Value))", index, fieldName))
+	case isBytes(inner):
+		g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(0, NewBytesPlutusData(v.%s.Value))", index, fieldName))
+	case g.isContainer(inner):
+		g.writeLine(fmt.Sprintf("innerPd, err := %s", g.plutusEncodeCall(inner, "v."+fieldName+".Value")))
+		g.writeLine("if err != nil {")
 		g.indentInc()
-		g.writeLine(fmt.Sprintf("list%d[i] = NewConstrPlutusData(0)", index))
+		g.writeLine(fmt.Sprintf(`return PlutusData{}, validationErrorAt(err, "%s", "Some")`, fieldName))
 		g.indentDec()
 		g.writeLine("}")
+		g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(0, innerPd)", index))
 	default:
-		if g.isPrimitiveWrapper(inner, "bytes") {
-			g.writeLine(fmt.Sprintf("list%d[i] = NewBytesPlutusData(item)", index))
-		} else if g.isPrimitiveWrapper(inner, "integer") {
-			g.writeLine(fmt.Sprintf("list%d[i] = NewIntPlutusData(item)", index))
-		} else {
-			g.writeLine("itemPd, err := item.ToPlutusData()")
-			g.writeLine("if err != nil {")
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return PlutusData{}, fmt.Errorf("field %s[%%d]: %%w", i, err)`, fieldName))
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine(fmt.Sprintf("list%d[i] = itemPd", index))
+		// Complex inner type - call ToPlutusData
+		// Check if it's an enum (interface) that could be nil
+		if g.isEnum(inner) {
+			g.writeNilCheck("v."+fieldName+".Value", g.goType(inner), strconv.Quote(fieldName), `"Some"`)
 		}
+		g.writeLine(fmt.Sprintf("innerPd, err := v.%s.Value.ToPlutusData()", fieldName))
+		g.writeLine("if err != nil {")
+		g.indentInc()
+		g.writeLine(fmt.Sprintf(`return PlutusData{}, validationErrorAt(err, "%s", "Some")`, fieldName))
+		g.indentDec()
+		g.writeLine("}")
+		g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(0, innerPd)", index))
 	}
 
+	g.indentDec()
+	g.writeLine("} else {")
+	g.indentInc()
+	g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(1)", index)) // None
 	g.indentDec()
 	g.writeLine("}")
-	g.writeLine(fmt.Sprintf("fields[%d] = NewListPlutusData(list%d...)", index, index))
 }
 
-func (g *Generator) writeMapFieldToPlutusData(fieldName string, refName string, index int) {
-	// Parse Pairs$keyType_valueType
-	inner := strings.TrimPrefix(refName, "Pairs$")
-	inner = strings.ReplaceAll(inner, "~1", "/")
-	parts := strings.SplitN(inner, "_", 2)
-
-	var keyType, valueType string
-	if len(parts) == 2 {
-		keyType = parts[0]
-		valueType = parts[1]
-	} else {
-		keyType = "ByteArray"
-		valueType = "ByteArray"
-	}
-
-	g.writeLine(fmt.Sprintf("mapEntries%d := make([]PlutusDataMapEntry, 0, len(v.%s))", index, fieldName))
-	g.writeLine(fmt.Sprintf("for k, val := range v.%s {", fieldName))
+func (g *Generator) writeOptionFieldFromPlutusData(fieldName string, inner ir.Type, index int) {
+	// Check if it's a constructor (Option is encoded as constructor 0 for Some, 1 for None)
+	g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].Kind() != KindConstr {", index))
 	g.indentInc()
+	g.writeLine(fmt.Sprintf(`return decodeErrorAt(decodeKindError("Option", "constructor", pd.Constr.Fields[%d]), "%s")`, index, fieldName))
+	g.indentDec()
+	g.writeLine("}")
 
-	// Generate key serialization
-	g.writeMapKeyToPlutusData(keyType, index)
-
-	// Generate value serialization
-	g.writeMapValueToPlutusData(valueType, fieldName, index)
-
-	g.writeLine(fmt.Sprintf("mapEntries%d = append(mapEntries%d, PlutusDataMapEntry{Key: keyPd%d, Value: valPd%d})", index, index, index, index))
+	g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].Constr.Index == 1 {", index))
+	g.indentInc()
+	g.writeLine(fmt.Sprintf("if len(pd.Constr.Fields[%d].Constr.Fields) != 0 {", index))
+	g.indentInc()
+	g.writeLine(fmt.Sprintf(`return decodeErrorAt(decodeCountError("Option", "fields", 0, len(pd.Constr.Fields[%d].Constr.Fields)), "%s")`, index, fieldName))
 	g.indentDec()
 	g.writeLine("}")
-	g.writeLine(fmt.Sprintf("fields[%d] = NewMapPlutusData(mapEntries%d...)", index, index))
-}
+	g.writeLine(fmt.Sprintf("v.%s.IsSet = false // None", fieldName))
+	g.indentDec()
+	g.writeLine(fmt.Sprintf("} else if pd.Constr.Fields[%d].Constr.Index == 0 {", index))
+	g.indentInc()
 
-func (g *Generator) writeMapKeyToPlutusData(keyType string, index int) {
-	switch keyType {
-	case "Int":
-		g.writeLine(fmt.Sprintf("keyPd%d := NewIntPlutusData(k)", index))
-	case "ByteArray":
-		// If keyType is []byte, but Go map keys can't be []byte, so it's likely string
-		g.writeLine(fmt.Sprintf("keyPd%d := NewBytesPlutusData([]byte(k))", index))
-	default:
-		if g.isPrimitiveWrapper(keyType, "bytes") {
-			g.writeLine(fmt.Sprintf("keyPd%d := NewBytesPlutusData([]byte(k))", index))
-		} else if g.isPrimitiveWrapper(keyType, "integer") {
-			g.writeLine(fmt.Sprintf("keyPd%d := NewIntPlutusData(k)", index))
-		} else {
-			// Complex key type
-			g.writeLine(fmt.Sprintf("keyPd%d, err := k.ToPlutusData()", index))
-			g.writeLine("if err != nil {")
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return PlutusData{}, fmt.Errorf("map key: %%w", err)`))
-			g.indentDec()
-			g.writeLine("}")
-		}
-	}
-}
-
-func (g *Generator) writeMapValueToPlutusData(valueType string, fieldName string, index int) {
-	switch valueType {
-	case "Int":
-		g.writeLine(fmt.Sprintf("valPd%d := NewIntPlutusData(val)", index))
-	case "ByteArray":
-		g.writeLine(fmt.Sprintf("valPd%d := NewBytesPlutusData(val)", index))
-	default:
-		if g.isPrimitiveWrapper(valueType, "bytes") {
-			g.writeLine(fmt.Sprintf("valPd%d := NewBytesPlutusData(val)", index))
-		} else if g.isPrimitiveWrapper(valueType, "integer") {
-			g.writeLine(fmt.Sprintf("valPd%d := NewIntPlutusData(val)", index))
-		} else if strings.HasPrefix(valueType, "Pairs$") {
-			// Nested map - parse inner types and serialize
-			innerDef := strings.TrimPrefix(valueType, "Pairs$")
-			innerDef = strings.ReplaceAll(innerDef, "~1", "/")
-			innerParts := strings.SplitN(innerDef, "_", 2)
-			innerKeyType := "ByteArray"
-			innerValueType := "ByteArray"
-			if len(innerParts) == 2 {
-				innerKeyType = innerParts[0]
-				innerValueType = innerParts[1]
-			}
-			g.writeLine(fmt.Sprintf("innerEntries%d := make([]PlutusDataMapEntry, 0, len(val))", index))
-			g.writeLine("for ik, iv := range val {")
-			g.indentInc()
-			// Serialize inner key
-			switch innerKeyType {
-			case "Int":
-				g.writeLine(fmt.Sprintf("ikPd%d := NewIntPlutusData(ik)", index))
-			case "ByteArray":
-				g.writeLine(fmt.Sprintf("ikPd%d := NewBytesPlutusData([]byte(ik))", index))
-			default:
-				if g.isPrimitiveWrapper(innerKeyType, "bytes") {
-					g.writeLine(fmt.Sprintf("ikPd%d := NewBytesPlutusData([]byte(ik))", index))
-				} else if g.isPrimitiveWrapper(innerKeyType, "integer") {
-					g.writeLine(fmt.Sprintf("ikPd%d := NewIntPlutusData(ik)", index))
-				} else {
-					g.writeLine(fmt.Sprintf("ikPd%d := NewBytesPlutusData([]byte(ik))", index))
-				}
-			}
-			// Serialize inner value
-			switch innerValueType {
-			case "Int":
-				g.writeLine(fmt.Sprintf("ivPd%d := NewIntPlutusData(iv)", index))
-			case "ByteArray":
-				g.writeLine(fmt.Sprintf("ivPd%d := NewBytesPlutusData(iv)", index))
-			default:
-				if g.isPrimitiveWrapper(innerValueType, "bytes") {
-					g.writeLine(fmt.Sprintf("ivPd%d := NewBytesPlutusData(iv)", index))
-				} else if g.isPrimitiveWrapper(innerValueType, "integer") {
-					g.writeLine(fmt.Sprintf("ivPd%d := NewIntPlutusData(iv)", index))
-				} else {
-					g.writeLine(fmt.Sprintf("ivPd%d, err := iv.ToPlutusData()", index))
-					g.writeLine("if err != nil {")
-					g.indentInc()
-					g.writeLine(fmt.Sprintf(`return PlutusData{}, fmt.Errorf("field %s: nested map value: %%w", err)`, fieldName))
-					g.indentDec()
-					g.writeLine("}")
-				}
-			}
-			g.writeLine(fmt.Sprintf("innerEntries%d = append(innerEntries%d, PlutusDataMapEntry{Key: ikPd%d, Value: ivPd%d})", index, index, index, index))
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine(fmt.Sprintf("valPd%d := NewMapPlutusData(innerEntries%d...)", index, index))
-		} else {
-			// Complex value type
-			g.writeLine(fmt.Sprintf("valPd%d, err := val.ToPlutusData()", index))
-			g.writeLine("if err != nil {")
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return PlutusData{}, fmt.Errorf("field %s: map value: %%w", err)`, fieldName))
-			g.indentDec()
-			g.writeLine("}")
-		}
-	}
-}
-
-func (g *Generator) writeInlineMapFieldToPlutusData(fieldName string, schema *Schema, index int) {
-	// For inline map schemas
-	keySchema := schema.Keys
-	valueSchema := schema.Values
-
-	g.writeLine(fmt.Sprintf("mapEntries%d := make([]PlutusDataMapEntry, 0, len(v.%s))", index, fieldName))
-	g.writeLine(fmt.Sprintf("for k, val := range v.%s {", fieldName))
+	// Check that Some has exactly 1 field
+	g.writeLine(fmt.Sprintf("if len(pd.Constr.Fields[%d].Constr.Fields) != 1 {", index))
 	g.indentInc()
-
-	// Generate key serialization based on schema
-	g.writeInlineMapKeyToPlutusData(keySchema, index)
-
-	// Generate value serialization based on schema
-	g.writeInlineMapValueToPlutusData(valueSchema, fieldName, index)
-
-	g.writeLine(fmt.Sprintf("mapEntries%d = append(mapEntries%d, PlutusDataMapEntry{Key: keyPd%d, Value: valPd%d})", index, index, index, index))
+	g.writeLine(fmt.Sprintf(`return decodeErrorAt(decodeCountError("Option", "fields", 1, len(pd.Constr.Fields[%d].Constr.Fields)), "%s")`, index, fieldName))
 	g.indentDec()
 	g.writeLine("}")
-	g.writeLine(fmt.Sprintf("fields[%d] = NewMapPlutusData(mapEntries%d...)", index, index))
-}
-
-func (g *Generator) writeInlineMapKeyToPlutusData(keySchema *Schema, index int) {
-	if keySchema == nil {
-		g.writeLine(fmt.Sprintf("keyPd%d := NewBytesPlutusData([]byte(k))", index))
-		return
-	}
-
-	switch {
-	case keySchema.IsRef():
-		refName := keySchema.RefName()
-		switch refName {
-		case "Int":
-			g.writeLine(fmt.Sprintf("keyPd%d := NewIntPlutusData(k)", index))
-		case "ByteArray":
-			g.writeLine(fmt.Sprintf("keyPd%d := NewBytesPlutusData([]byte(k))", index))
-		default:
-			if g.isPrimitiveWrapper(refName, "bytes") {
-				g.writeLine(fmt.Sprintf("keyPd%d := NewBytesPlutusData([]byte(k))", index))
-			} else if g.isPrimitiveWrapper(refName, "integer") {
-				g.writeLine(fmt.Sprintf("keyPd%d := NewIntPlutusData(k)", index))
-			} else {
-				g.writeLine(fmt.Sprintf("keyPd%d, err := k.ToPlutusData()", index))
-				g.writeLine("if err != nil {")
-				g.indentInc()
-				g.writeLine(`return PlutusData{}, fmt.Errorf("map key: %w", err)`)
-				g.indentDec()
-				g.writeLine("}")
-			}
-		}
-	case keySchema.IsInteger():
-		g.writeLine(fmt.Sprintf("keyPd%d := NewIntPlutusData(k)", index))
-	case keySchema.IsBytes():
-		g.writeLine(fmt.Sprintf("keyPd%d := NewBytesPlutusData([]byte(k))", index))
-	default:
-		g.writeLine(fmt.Sprintf("keyPd%d := NewBytesPlutusData([]byte(k))", index))
-	}
-}
-
-func (g *Generator) writeInlineMapValueToPlutusData(valueSchema *Schema, fieldName string, index int) {
-	if valueSchema == nil {
-		g.writeLine(fmt.Sprintf("valPd%d := NewBytesPlutusData(val)", index))
-		return
-	}
+	g.writeLine(fmt.Sprintf("v.%s.IsSet = true", fieldName))
 
+	// Extract the inner value based on type
 	switch {
-	case valueSchema.IsRef():
-		refName := valueSchema.RefName()
-		switch refName {
-		case "Int":
-			g.writeLine(fmt.Sprintf("valPd%d := NewIntPlutusData(val)", index))
-		case "ByteArray":
-			g.writeLine(fmt.Sprintf("valPd%d := NewBytesPlutusData(val)", index))
-		default:
-			if g.isPrimitiveWrapper(refName, "bytes") {
-				g.writeLine(fmt.Sprintf("valPd%d := NewBytesPlutusData(val)", index))
-			} else if g.isPrimitiveWrapper(refName, "integer") {
-				g.writeLine(fmt.Sprintf("valPd%d := NewIntPlutusData(val)", index))
-			} else {
-				g.writeLine(fmt.Sprintf("valPd%d, err := val.ToPlutusData()", index))
-				g.writeLine("if err != nil {")
-				g.indentInc()
-				g.writeLine(fmt.Sprintf(`return PlutusData{}, fmt.Errorf("field %s: map value: %%w", err)`, fieldName))
-				g.indentDec()
-				g.writeLine("}")
-			}
-		}
-	case valueSchema.IsInteger():
-		g.writeLine(fmt.Sprintf("valPd%d := NewIntPlutusData(val)", index))
-	case valueSchema.IsBytes():
-		g.writeLine(fmt.Sprintf("valPd%d := NewBytesPlutusData(val)", index))
-	case valueSchema.IsMap():
-		// Nested map - serialize inline
-		innerKeySchema := valueSchema.Keys
-		innerValueSchema := valueSchema.Values
-		g.writeLine(fmt.Sprintf("innerEntries%d := make([]PlutusDataMapEntry, 0, len(val))", index))
-		g.writeLine("for ik, iv := range val {")
+	case isInt(inner):
+		g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].Constr.Fields[0].Kind() != KindInteger {", index))
 		g.indentInc()
-		// Serialize inner key
-		if innerKeySchema != nil && innerKeySchema.IsInteger() {
-			g.writeLine(fmt.Sprintf("ikPd%d := NewIntPlutusData(ik)", index))
-		} else {
-			g.writeLine(fmt.Sprintf("ikPd%d := NewBytesPlutusData([]byte(ik))", index))
-		}
-		// Serialize inner value
-		if innerValueSchema != nil && innerValueSchema.IsInteger() {
-			g.writeLine(fmt.Sprintf("ivPd%d := NewIntPlutusData(iv)", index))
-		} else if innerValueSchema != nil && innerValueSchema.IsBytes() {
-			g.writeLine(fmt.Sprintf("ivPd%d := NewBytesPlutusData(iv)", index))
-		} else {
-			g.writeLine(fmt.Sprintf("ivPd%d, err := iv.ToPlutusData()", index))
-			g.writeLine("if err != nil {")
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return PlutusData{}, fmt.Errorf("field %s: nested map value: %%w", err)`, fieldName))
-			g.indentDec()
-			g.writeLine("}")
-		}
-		g.writeLine(fmt.Sprintf("innerEntries%d = append(innerEntries%d, PlutusDataMapEntry{Key: ikPd%d, Value: ivPd%d})", index, index, index, index))
+		g.writeLine(fmt.Sprintf(`return decodeErrorAt(decodeKindError("*big.Int", "integer", pd.Constr.Fields[%d].Constr.Fields[0]), "%s", "Some")`, index, fieldName))
 		g.indentDec()
 		g.writeLine("}")
-		g.writeLine(fmt.Sprintf("valPd%d := NewMapPlutusData(innerEntries%d...)", index, index))
-	default:
-		g.writeLine(fmt.Sprintf("valPd%d, err := val.ToPlutusData()", index))
-		g.writeLine("if err != nil {")
+		g.writeLine(fmt.Sprintf("v.%s.Value = pd.Constr.Fields[%d].Constr.Fields[0].Integer", fieldName, index))
+	case isBytes(inner):
+		g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].Constr.Fields[0].Kind() != KindBytes {", index))
 		g.indentInc()
-		g.writeLine(fmt.Sprintf(`return PlutusData{}, fmt.Errorf("field %s: map value: %%w", err)`, fieldName))
+		g.writeLine(fmt.Sprintf(`return decodeErrorAt(decodeKindError("[]byte", "bytes", pd.Constr.Fields[%d].Constr.Fields[0]), "%s", "Some")`, index, fieldName))
 		g.indentDec()
 		g.writeLine("}")
-	}
-}
-
-func (g *Generator) writeListItemToPlutusData(itemSchema *Schema, listIndex int) {
-	switch {
-	case itemSchema.IsRef():
-		refName := itemSchema.RefName()
-		switch refName {
-		case "Int":
-			g.writeLine(fmt.Sprintf("list%d[i] = NewIntPlutusData(item)", listIndex))
-		case "ByteArray":
-			g.writeLine(fmt.Sprintf("list%d[i] = NewBytesPlutusData(item)", listIndex))
-		case "Bool":
-			g.writeLine("if item {")
-			g.indentInc()
-			g.writeLine(fmt.Sprintf("list%d[i] = NewConstrPlutusData(1)", listIndex))
-			g.indentDec()
-			g.writeLine("} else {")
-			g.indentInc()
-			g.writeLine(fmt.Sprintf("list%d[i] = NewConstrPlutusData(0)", listIndex))
-			g.indentDec()
-			g.writeLine("}")
-		default:
-			if g.isPrimitiveWrapper(refName, "bytes") {
-				g.writeLine(fmt.Sprintf("list%d[i] = NewBytesPlutusData(item)", listIndex))
-			} else if g.isPrimitiveWrapper(refName, "integer") {
-				g.writeLine(fmt.Sprintf("list%d[i] = NewIntPlutusData(item)", listIndex))
-			} else {
-				g.writeLine("itemPd, err := item.ToPlutusData()")
-				g.writeLine("if err != nil {")
-				g.indentInc()
-				g.writeLine(`return PlutusData{}, fmt.Errorf("list item[%d]: %w", i, err)`)
-				g.indentDec()
-				g.writeLine("}")
-				g.writeLine(fmt.Sprintf("list%d[i] = itemPd", listIndex))
-			}
-		}
-	case itemSchema.IsInteger():
-		g.writeLine(fmt.Sprintf("list%d[i] = NewIntPlutusData(item)", listIndex))
-	case itemSchema.IsBytes():
-		g.writeLine(fmt.Sprintf("list%d[i] = NewBytesPlutusData(item)", listIndex))
-	default:
-		g.writeLine("itemPd, err := item.ToPlutusData()")
-		g.writeLine("if err != nil {")
+		g.writeLine(fmt.Sprintf("v.%s.Value = pd.Constr.Fields[%d].Constr.Fields[0].ByteString", fieldName, index))
+	case g.isContainer(inner):
+		g.writeLine(fmt.Sprintf("if err := %s; err != nil {", g.plutusDecodeCall(inner, fmt.Sprintf("pd.Constr.Fields[%d].Constr.Fields[0]", index), "&v."+fieldName+".Value")))
 		g.indentInc()
-		g.writeLine(`return PlutusData{}, fmt.Errorf("list item[%d]: %w", i, err)`)
+		g.writeLine(fmt.Sprintf(`return decodeErrorAt(err, "%s", "Some")`, fieldName))
 		g.indentDec()
 		g.writeLine("}")
-		g.writeLine(fmt.Sprintf("list%d[i] = itemPd", listIndex))
-	}
-}
-
-func (g *Generator) writeOptionSomeValue(fieldName string, inner *Schema, index int) {
-	switch {
-	case inner.IsRef():
-		refName := inner.RefName()
-		switch refName {
-		case "Int":
-			g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(0, NewIntPlutusData(v.%s.Value))", index, fieldName))
-		case "ByteArray":
-			g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(0, NewBytesPlutusData(v.%s.Value))", index, fieldName))
-		default:
-			g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(0, NewBytesPlutusData(v.%s.Value))", index, fieldName))
-		}
-	case inner.IsInteger():
-		g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(0, NewIntPlutusData(v.%s.Value))", index, fieldName))
-	case inner.IsBytes():
-		g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(0, NewBytesPlutusData(v.%s.Value))", index, fieldName))
-	}
-}
-
-func (g *Generator) writeOptionRefToPlutusData(fieldName string, refName string, index int) {
-	// Extract inner type from Option$InnerType
-	innerRef := strings.TrimPrefix(refName, "Option$")
-	innerRef = strings.ReplaceAll(innerRef, "~1", "/")
-
-	g.writeLine(fmt.Sprintf("if v.%s.IsSet {", fieldName))
-	g.indentInc()
-
-	switch innerRef {
-	case "Int":
-		g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(0, NewIntPlutusData(v.%s.Value))", index, fieldName))
-	case "ByteArray":
-		g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(0, NewBytesPlutusData(v.%s.Value))", index, fieldName))
-	default:
-		if g.isPrimitiveWrapper(innerRef, "bytes") {
-			g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(0, NewBytesPlutusData(v.%s.Value))", index, fieldName))
-		} else if g.isPrimitiveWrapper(innerRef, "integer") {
-			g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(0, NewIntPlutusData(v.%s.Value))", index, fieldName))
-		} else {
-			// Complex inner type - call ToPlutusData
-			// Check if it's an enum (interface) that could be nil
-			if defSchema, ok := g.bp.Definitions[g.unescapeRef(innerRef)]; ok && defSchema.IsEnum() && !defSchema.IsSingleConstructor() {
-				g.writeLine(fmt.Sprintf("if v.%s.Value == nil {", fieldName))
-				g.indentInc()
-				g.writeLine(fmt.Sprintf(`return PlutusData{}, fmt.Errorf("field %s.Value: value is nil (expected %s)")`, fieldName, g.normalizeTypeName(innerRef)))
-				g.indentDec()
-				g.writeLine("}")
-			}
-			g.writeLine(fmt.Sprintf("innerPd, err := v.%s.Value.ToPlutusData()", fieldName))
-			g.writeLine("if err != nil {")
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return PlutusData{}, fmt.Errorf("field %s.Value: %%w", err)`, fieldName))
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(0, innerPd)", index))
-		}
-	}
-
-	g.indentDec()
-	g.writeLine("} else {")
-	g.indentInc()
-	g.writeLine(fmt.Sprintf("fields[%d] = NewConstrPlutusData(1)", index)) // None
-	g.indentDec()
-	g.writeLine("}")
-}
-
-func (g *Generator) writeOptionRefFromPlutusData(fieldName string, refName string, index int) {
-	// Extract inner type from Option$InnerType
-	innerRef := strings.TrimPrefix(refName, "Option$")
-	innerRef = strings.ReplaceAll(innerRef, "~1", "/")
-	goType := g.refToGoType(innerRef)
-
-	// Check if it's a constructor (Option is encoded as constructor 0 for Some, 1 for None)
-	g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].Constr == nil {", index))
-	g.indentInc()
-	g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected Option constructor, got %%s", plutusDataTypeString(pd.Constr.Fields[%d]))`, fieldName, index))
-	g.indentDec()
-	g.writeLine("}")
-
-	g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].Constr.Index == 1 {", index))
-	g.indentInc()
-	g.writeLine(fmt.Sprintf("v.%s.IsSet = false // None", fieldName))
-	g.indentDec()
-	g.writeLine(fmt.Sprintf("} else if pd.Constr.Fields[%d].Constr.Index == 0 {", index))
-	g.indentInc()
-
-	// Check that Some has exactly 1 field
-	g.writeLine(fmt.Sprintf("if len(pd.Constr.Fields[%d].Constr.Fields) != 1 {", index))
-	g.indentInc()
-	g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: Option Some should have 1 field, got %%d", len(pd.Constr.Fields[%d].Constr.Fields))`, fieldName, index))
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine(fmt.Sprintf("v.%s.IsSet = true", fieldName))
-
-	// Extract the inner value based on type
-	switch innerRef {
-	case "Int":
-		g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].Constr.Fields[0].Integer == nil {", index))
+	case g.isEnum(inner):
+		// Enum type - use factory function
+		g.writeLine(fmt.Sprintf("%sVal, err := %sFromPlutusData(pd.Constr.Fields[%d].Constr.Fields[0])", fieldName, g.goType(inner), index))
+		g.writeLine("if err != nil {")
 		g.indentInc()
-		g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected integer in Option Some, got %%s", plutusDataTypeString(pd.Constr.Fields[%d].Constr.Fields[0]))`, fieldName, index))
+		g.writeLine(fmt.Sprintf(`return decodeErrorAt(err, "%s", "Some")`, fieldName))
 		g.indentDec()
 		g.writeLine("}")
-		g.writeLine(fmt.Sprintf("v.%s.Value = pd.Constr.Fields[%d].Constr.Fields[0].Integer", fieldName, index))
-	case "ByteArray":
-		g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].Constr.Fields[0].ByteString == nil {", index))
+		g.writeLine(fmt.Sprintf("v.%s.Value = %sVal", fieldName, fieldName))
+	default:
+		// Complex inner type - call FromPlutusData on the Value,
+		// which constants assign whole
+		if !g.isConstEnum(inner) {
+			g.writeLine(fmt.Sprintf("v.%s.Value = %s{}", fieldName, g.goType(inner)))
+		}
+		g.writeLine(fmt.Sprintf("if err := v.%s.Value.FromPlutusData(pd.Constr.Fields[%d].Constr.Fields[0]); err != nil {", fieldName, index))
 		g.indentInc()
-		g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected bytes in Option Some, got %%s", plutusDataTypeString(pd.Constr.Fields[%d].Constr.Fields[0]))`, fieldName, index))
+		g.writeLine(fmt.Sprintf(`return decodeErrorAt(err, "%s", "Some")`, fieldName))
 		g.indentDec()
 		g.writeLine("}")
-		g.writeLine(fmt.Sprintf("v.%s.Value = pd.Constr.Fields[%d].Constr.Fields[0].ByteString", fieldName, index))
-	default:
-		if g.isPrimitiveWrapper(innerRef, "bytes") {
-			g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].Constr.Fields[0].ByteString == nil {", index))
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected bytes in Option Some, got %%s", plutusDataTypeString(pd.Constr.Fields[%d].Constr.Fields[0]))`, fieldName, index))
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine(fmt.Sprintf("v.%s.Value = pd.Constr.Fields[%d].Constr.Fields[0].ByteString", fieldName, index))
-		} else if g.isPrimitiveWrapper(innerRef, "integer") {
-			g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].Constr.Fields[0].Integer == nil {", index))
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected integer in Option Some, got %%s", plutusDataTypeString(pd.Constr.Fields[%d].Constr.Fields[0]))`, fieldName, index))
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine(fmt.Sprintf("v.%s.Value = pd.Constr.Fields[%d].Constr.Fields[0].Integer", fieldName, index))
-		} else {
-			// Check if it's an enum type (interface)
-			unescaped := g.unescapeRef(innerRef)
-			if def, ok := g.bp.Definitions[unescaped]; ok && def.IsEnum() && !def.IsSingleConstructor() {
-				// Enum type - use factory function
-				typeName := g.normalizeTypeName(innerRef)
-				g.writeLine(fmt.Sprintf("%sVal, err := %sFromPlutusData(pd.Constr.Fields[%d].Constr.Fields[0])", fieldName, typeName, index))
-				g.writeLine("if err != nil {")
-				g.indentInc()
-				g.writeLine("return err")
-				g.indentDec()
-				g.writeLine("}")
-				g.writeLine(fmt.Sprintf("v.%s.Value = %sVal", fieldName, fieldName))
-			} else {
-				// Complex inner type - call FromPlutusData on the Value
-				g.writeLine(fmt.Sprintf("v.%s.Value = %s{}", fieldName, goType))
-				g.writeLine(fmt.Sprintf("if err := v.%s.Value.FromPlutusData(pd.Constr.Fields[%d].Constr.Fields[0]); err != nil {", fieldName, index))
-				g.indentInc()
-				g.writeLine("return err")
-				g.indentDec()
-				g.writeLine("}")
-			}
-		}
 	}
 
 	g.indentDec()
 	g.writeLine("} else {")
 	g.indentInc()
-	g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: unknown Option constructor index %%d", pd.Constr.Fields[%d].Constr.Index)`, fieldName, index))
+	g.writeLine(fmt.Sprintf(`return decodeErrorAt(decodeIndexError("Option", pd.Constr.Fields[%d].Constr.Index, 0, 1), "%s")`, index, fieldName))
 	g.indentDec()
 	g.writeLine("}")
 }
 
-func (g *Generator) writeStructFromPlutusData(name string, schema *Schema, constrIndex int) {
-	g.writeLine(fmt.Sprintf("func (v *%s) FromPlutusData(pd PlutusData) error {", name))
-	g.indentInc()
-
-	g.writeLine("if pd.Constr == nil {")
-	g.indentInc()
-	g.writeLine(fmt.Sprintf(`return errors.New("expected constructor for %s")`, name))
-	g.indentDec()
-	g.writeLine("}")
-
-	g.writeLine(fmt.Sprintf("if pd.Constr.Index != %d {", constrIndex))
-	g.indentInc()
-	g.writeLine(fmt.Sprintf(`return fmt.Errorf("wrong constructor index for %s: expected %d, got %%d", pd.Constr.Index)`, name, constrIndex))
-	g.indentDec()
-	g.writeLine("}")
-
-	if len(schema.Fields) > 0 {
-		g.writeLine(fmt.Sprintf("if len(pd.Constr.Fields) != %d {", len(schema.Fields)))
+func (g *Generator) writeFieldFromPlutusData(fieldName string, t ir.Type, index int) {
+	switch {
+	case g.isContainer(t):
+		// List or map, decoded item by item
+		g.writeLine(fmt.Sprintf("if err := %s; err != nil {", g.plutusDecodeCall(t, fmt.Sprintf("pd.Constr.Fields[%d]", index), "&v."+fieldName)))
 		g.indentInc()
-		g.writeLine(fmt.Sprintf(`return fmt.Errorf("wrong number of fields for %s: expected %d, got %%d", len(pd.Constr.Fields))`, name, len(schema.Fields)))
+		g.writeLine(fmt.Sprintf(`return decodeErrorAt(err, "%s")`, fieldName))
 		g.indentDec()
 		g.writeLine("}")
-
-		for i, field := range schema.Fields {
-			fieldName := g.normalizeFieldName(field.Title, i)
-			g.writeFieldFromPlutusData(fieldName, &field, i)
-		}
-	}
-
-	g.writeLine("return nil")
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("")
-}
-
-func (g *Generator) writeFieldFromPlutusData(fieldName string, schema *Schema, index int) {
-	switch {
-	case schema.IsRef():
-		refName := schema.RefName()
-		switch refName {
-		case "Int":
-			g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].Integer == nil {", index))
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected integer, got %%s", plutusDataTypeString(pd.Constr.Fields[%d]))`, fieldName, index))
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine(fmt.Sprintf("v.%s = pd.Constr.Fields[%d].Integer", fieldName, index))
-		case "ByteArray":
-			g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].ByteString == nil {", index))
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected bytes, got %%s", plutusDataTypeString(pd.Constr.Fields[%d]))`, fieldName, index))
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine(fmt.Sprintf("v.%s = pd.Constr.Fields[%d].ByteString", fieldName, index))
-		case "Bool":
-			g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].Constr == nil {", index))
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected constructor for bool, got %%s", plutusDataTypeString(pd.Constr.Fields[%d]))`, fieldName, index))
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine(fmt.Sprintf("v.%s = pd.Constr.Fields[%d].Constr.Index == 1", fieldName, index))
-		case "Data":
-			// Data type is raw PlutusData - store directly
-			g.writeLine(fmt.Sprintf("v.%s = pd.Constr.Fields[%d]", fieldName, index))
-		default:
-			if strings.HasPrefix(refName, "List$") {
-				// List type - handle inline
-				g.writeListFieldFromPlutusData(fieldName, refName, index)
-			} else if strings.HasPrefix(refName, "Pairs$") {
-				// Map type - deserialize from PlutusData map
-				g.writeMapFieldFromPlutusData(fieldName, refName, index)
-			} else if strings.HasPrefix(refName, "Option$") {
-				// Option type - handle as pointer
-				g.writeOptionRefFromPlutusData(fieldName, refName, index)
-			} else if g.isPrimitiveWrapper(refName, "bytes") {
-				// Primitive wrapper for bytes
-				g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].ByteString == nil {", index))
-				g.indentInc()
-				g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected bytes, got %%s", plutusDataTypeString(pd.Constr.Fields[%d]))`, fieldName, index))
-				g.indentDec()
-				g.writeLine("}")
-				g.writeLine(fmt.Sprintf("v.%s = pd.Constr.Fields[%d].ByteString", fieldName, index))
-			} else if g.isPrimitiveWrapper(refName, "integer") {
-				// Primitive wrapper for integer
-				g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].Integer == nil {", index))
-				g.indentInc()
-				g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected integer, got %%s", plutusDataTypeString(pd.Constr.Fields[%d]))`, fieldName, index))
-				g.indentDec()
-				g.writeLine("}")
-				g.writeLine(fmt.Sprintf("v.%s = pd.Constr.Fields[%d].Integer", fieldName, index))
-			} else {
-				// Check if it's an enum type
-				if defSchema, ok := g.bp.Definitions[g.unescapeRef(refName)]; ok && defSchema.IsEnum() && !defSchema.IsSingleConstructor() {
-					typeName := g.normalizeTypeName(refName)
-					g.writeLine(fmt.Sprintf("%sVal, err := %sFromPlutusData(pd.Constr.Fields[%d])", fieldName, typeName, index))
-					g.writeLine("if err != nil {")
-					g.indentInc()
-					g.writeLine("return err")
-					g.indentDec()
-					g.writeLine("}")
-					g.writeLine(fmt.Sprintf("v.%s = %sVal", fieldName, fieldName))
-				} else {
-					g.writeLine(fmt.Sprintf("if err := v.%s.FromPlutusData(pd.Constr.Fields[%d]); err != nil {", fieldName, index))
-					g.indentInc()
-					g.writeLine("return err")
-					g.indentDec()
-					g.writeLine("}")
-				}
-			}
-		}
-	case schema.IsInteger():
-		g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].Integer == nil {", index))
+	case isInt(t):
+		g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].Kind() != KindInteger {", index))
 		g.indentInc()
-		g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected integer, got %%s", plutusDataTypeString(pd.Constr.Fields[%d]))`, fieldName, index))
+		g.writeLine(fmt.Sprintf(`return decodeErrorAt(decodeKindError("*big.Int", "integer", pd.Constr.Fields[%d]), "%s")`, index, fieldName))
 		g.indentDec()
 		g.writeLine("}")
 		g.writeLine(fmt.Sprintf("v.%s = pd.Constr.Fields[%d].Integer", fieldName, index))
-	case schema.IsBytes():
-		g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].ByteString == nil {", index))
+	case isBytes(t):
+		g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].Kind() != KindBytes {", index))
 		g.indentInc()
-		g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected bytes, got %%s", plutusDataTypeString(pd.Constr.Fields[%d]))`, fieldName, index))
+		g.writeLine(fmt.Sprintf(`return decodeErrorAt(decodeKindError("[]byte", "bytes", pd.Constr.Fields[%d]), "%s")`, index, fieldName))
 		g.indentDec()
 		g.writeLine("}")
 		g.writeLine(fmt.Sprintf("v.%s = pd.Constr.Fields[%d].ByteString", fieldName, index))
-	case schema.IsList():
-		g.writeListFieldFromPlutusDataInline(fieldName, schema, index)
-	case schema.IsMap():
-		g.writeInlineMapFieldFromPlutusData(fieldName, schema, index)
-	case schema.IsBoolean():
-		g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].Constr == nil {", index))
+	case t == ir.Bool:
+		g.writeLine(fmt.Sprintf("if err := decodePlutusBool(pd.Constr.Fields[%d], &v.%s); err != nil {", index, fieldName))
 		g.indentInc()
-		g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected constructor for bool, got %%s", plutusDataTypeString(pd.Constr.Fields[%d]))`, fieldName, index))
+		g.writeLine(fmt.Sprintf(`return decodeErrorAt(err, "%s")`, fieldName))
 		g.indentDec()
 		g.writeLine("}")
-		g.writeLine(fmt.Sprintf("v.%s = pd.Constr.Fields[%d].Constr.Index == 1", fieldName, index))
+	case isData(t):
+		// Data type is raw PlutusData - store directly
+		g.writeLine(fmt.Sprintf("v.%s = pd.Constr.Fields[%d]", fieldName, index))
+	case g.isOption(t):
+		g.writeOptionFieldFromPlutusData(fieldName, t.(*ir.Option).Elem, index)
+	case g.isEnum(t):
+		// Enum types are interfaces, built by their factory function
+		g.writeLine(fmt.Sprintf("%sVal, err := %sFromPlutusData(pd.Constr.Fields[%d])", fieldName, g.goType(t), index))
+		g.writeLine("if err != nil {")
+		g.indentInc()
+		g.writeLine(fmt.Sprintf(`return decodeErrorAt(err, "%s")`, fieldName))
+		g.indentDec()
+		g.writeLine("}")
+		g.writeLine(fmt.Sprintf("v.%s = %sVal", fieldName, fieldName))
 	default:
-		// Check if it might be an enum
+		// Custom type, generic instance or constant with FromPlutusData
 		g.writeLine(fmt.Sprintf("if err := v.%s.FromPlutusData(pd.Constr.Fields[%d]); err != nil {", fieldName, index))
 		g.indentInc()
-		g.writeLine("return err")
+		g.writeLine(fmt.Sprintf(`return decodeErrorAt(err, "%s")`, fieldName))
 		g.indentDec()
 		g.writeLine("}")
 	}
 }
 
-func (g *Generator) writeListFieldFromPlutusData(fieldName, refName string, index int) {
-	// Extract inner type from List$Type
-	inner := strings.TrimPrefix(refName, "List$")
-	inner = strings.ReplaceAll(inner, "~1", "/")
-
-	g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].List == nil {", index))
-	g.indentInc()
-	g.writeLine(fmt.Sprintf(`return errors.New("field %s: expected list")`, fieldName))
-	g.indentDec()
-	g.writeLine("}")
-
-	goType := g.refToGoType(inner)
-	g.writeLine(fmt.Sprintf("v.%s = make([]%s, len(pd.Constr.Fields[%d].List))", fieldName, goType, index))
-	g.writeLine(fmt.Sprintf("for i, item := range pd.Constr.Fields[%d].List {", index))
-	g.indentInc()
-
-	switch inner {
-	case "Int":
-		g.writeLine("if item.Integer == nil {")
-		g.indentInc()
-		g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: list item expected integer, got %%s", plutusDataTypeString(item))`, fieldName))
-		g.indentDec()
-		g.writeLine("}")
-		g.writeLine(fmt.Sprintf("v.%s[i] = item.Integer", fieldName))
-	case "ByteArray":
-		g.writeLine("if item.ByteString == nil {")
-		g.indentInc()
-		g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: list item expected bytes, got %%s", plutusDataTypeString(item))`, fieldName))
-		g.indentDec()
-		g.writeLine("}")
-		g.writeLine(fmt.Sprintf("v.%s[i] = item.ByteString", fieldName))
-	case "Bool":
-		g.writeLine("if item.Constr == nil {")
-		g.indentInc()
-		g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: list item expected bool constructor, got %%s", plutusDataTypeString(item))`, fieldName))
-		g.indentDec()
-		g.writeLine("}")
-		g.writeLine(fmt.Sprintf("v.%s[i] = item.Constr.Index == 1", fieldName))
-	default:
-		if g.isPrimitiveWrapper(inner, "bytes") {
-			g.writeLine("if item.ByteString == nil {")
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: list item expected bytes, got %%s", plutusDataTypeString(item))`, fieldName))
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine(fmt.Sprintf("v.%s[i] = item.ByteString", fieldName))
-		} else if g.isPrimitiveWrapper(inner, "integer") {
-			g.writeLine("if item.Integer == nil {")
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: list item expected integer, got %%s", plutusDataTypeString(item))`, fieldName))
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine(fmt.Sprintf("v.%s[i] = item.Integer", fieldName))
-		} else if defSchema, ok := g.bp.Definitions[inner]; ok && defSchema.IsEnum() && !defSchema.IsSingleConstructor() {
-			// Check if it's an enum (multi-constructor, not single-constructor)
-			typeName := g.normalizeTypeName(inner)
-			g.writeLine(fmt.Sprintf("itemVal, err := %sFromPlutusData(item)", typeName))
-			g.writeLine("if err != nil {")
-			g.indentInc()
-			g.writeLine("return err")
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine(fmt.Sprintf("v.%s[i] = itemVal", fieldName))
-		} else {
-			g.writeLine(fmt.Sprintf("if err := v.%s[i].FromPlutusData(item); err != nil {", fieldName))
-			g.indentInc()
-			g.writeLine("return err")
-			g.indentDec()
-			g.writeLine("}")
+func (g *Generator) writeEnumType(name string, enum *ir.Enum) error {
+	data := &EnumData{
+		TypeData:   TypeData{Name: name, Receiver: name, Kind: "enum"},
+		MethodName: fmt.Sprintf("is%s", name),
+	}
+	if g.opts.UnknownVariants {
+		unknownName := g.unknownVariantName(name, enum)
+		data.UnknownVariant = &StructData{
+			TypeData: TypeData{
+				Name:     unknownName,
+				Receiver: unknownName,
+				Kind:     "variant",
+				EnumName: name,
+			},
+			ConstrIndex: -1,
+			MethodName:  data.MethodName,
+			ShortName:   strings.TrimPrefix(unknownName, name),
 		}
 	}
 
-	g.indentDec()
-	g.writeLine("}")
-}
-
-func (g *Generator) writeListFieldFromPlutusDataInline(fieldName string, schema *Schema, index int) {
-	g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].List == nil {", index))
-	g.indentInc()
-	g.writeLine(fmt.Sprintf(`return errors.New("field %s: expected list")`, fieldName))
-	g.indentDec()
-	g.writeLine("}")
-
-	if len(schema.Items) > 0 && schema.Items.Single() != nil {
-		itemSchema := schema.Items.Single()
-		goType := g.schemaToGoType(itemSchema)
-		g.writeLine(fmt.Sprintf("v.%s = make([]%s, len(pd.Constr.Fields[%d].List))", fieldName, goType, index))
-		g.writeLine(fmt.Sprintf("for i, item := range pd.Constr.Fields[%d].List {", index))
-		g.indentInc()
+	// Variant structs, written after the interface
+	variantTemplates := make([]string, len(enum.Variants))
+	for i, variant := range enum.Variants {
+		shortName := g.toGoIdentifier(variant.Title)
+		variantName := name + shortName
 
+		var variantData *StructData
 		switch {
-		case itemSchema.IsRef():
-			refName := itemSchema.RefName()
-			switch refName {
-			case "Int":
-				g.writeLine(fmt.Sprintf("v.%s[i] = item.Integer", fieldName))
-			case "ByteArray":
-				g.writeLine(fmt.Sprintf("v.%s[i] = item.ByteString", fieldName))
-			case "Bool":
-				g.writeLine(fmt.Sprintf("v.%s[i] = item.Constr != nil && item.Constr.Index == 1", fieldName))
-			default:
-				if g.isPrimitiveWrapper(refName, "bytes") {
-					g.writeLine(fmt.Sprintf("v.%s[i] = item.ByteString", fieldName))
-				} else if g.isPrimitiveWrapper(refName, "integer") {
-					g.writeLine(fmt.Sprintf("v.%s[i] = item.Integer", fieldName))
-				} else if defSchema, ok := g.bp.Definitions[g.unescapeRef(refName)]; ok && defSchema.IsEnum() && !defSchema.IsSingleConstructor() {
-					typeName := g.normalizeTypeName(refName)
-					g.writeLine(fmt.Sprintf("itemVal, err := %sFromPlutusData(item)", typeName))
-					g.writeLine("if err != nil {")
-					g.indentInc()
-					g.writeLine("return err")
-					g.indentDec()
-					g.writeLine("}")
-					g.writeLine(fmt.Sprintf("v.%s[i] = itemVal", fieldName))
-				} else {
-					g.writeLine(fmt.Sprintf("if err := v.%s[i].FromPlutusData(item); err != nil {", fieldName))
-					g.indentInc()
-					g.writeLine("return err")
-					g.indentDec()
-					g.writeLine("}")
-				}
-			}
-		case itemSchema.IsInteger():
-			g.writeLine(fmt.Sprintf("v.%s[i] = item.Integer", fieldName))
-		case itemSchema.IsBytes():
-			g.writeLine(fmt.Sprintf("v.%s[i] = item.ByteString", fieldName))
+		case len(variant.Fields) == 0:
+			// Empty struct for enum variants without fields
+			variantTemplates[i] = "enum_variant_empty.go.tmpl"
+			variantData = g.structData(variantName, "variant", variant.Title, variant.Fields, variant.Index)
+		case len(variant.Fields) == 1 && variant.Fields[0].Title == "":
+			// Single unnamed field - wrapper type
+			variantTemplates[i] = "enum_variant_wrapper.go.tmpl"
+			variantData = g.wrapperData(variantName, variant.Fields[0].Type, variant.Index)
+			variantData.Title = variant.Title
 		default:
-			g.writeLine(fmt.Sprintf("if err := v.%s[i].FromPlutusData(item); err != nil {", fieldName))
-			g.indentInc()
-			g.writeLine("return err")
-			g.indentDec()
-			g.writeLine("}")
-		}
-
-		g.indentDec()
-		g.writeLine("}")
-	} else {
-		g.writeLine(fmt.Sprintf("// TODO: List deserialization for %s", fieldName))
-	}
-}
-
-func (g *Generator) writeMapFieldFromPlutusData(fieldName string, refName string, index int) {
-	// Parse Pairs$keyType_valueType
-	inner := strings.TrimPrefix(refName, "Pairs$")
-	inner = strings.ReplaceAll(inner, "~1", "/")
-	parts := strings.SplitN(inner, "_", 2)
-
-	var keyType, valueType string
-	if len(parts) == 2 {
-		keyType = parts[0]
-		valueType = parts[1]
-	} else {
-		keyType = "ByteArray"
-		valueType = "ByteArray"
-	}
-
-	goKeyType := g.refToGoType(keyType)
-	// []byte can't be a map key in Go, use string
-	if goKeyType == "[]byte" {
-		goKeyType = "string"
-	}
-	goValueType := g.refToGoType(valueType)
-
-	g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].Map == nil {", index))
-	g.indentInc()
-	g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected map, got %%s", plutusDataTypeString(pd.Constr.Fields[%d]))`, fieldName, index))
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine(fmt.Sprintf("v.%s = make(map[%s]%s)", fieldName, goKeyType, goValueType))
-	g.writeLine(fmt.Sprintf("for _, entry := range pd.Constr.Fields[%d].Map {", index))
-	g.indentInc()
-
-	// Generate key deserialization
-	g.writeMapKeyFromPlutusData(keyType, fieldName)
-
-	// Generate value deserialization
-	g.writeMapValueFromPlutusData(valueType, fieldName)
-
-	g.writeLine("v." + fieldName + "[mapKey] = mapVal")
-	g.indentDec()
-	g.writeLine("}")
-}
-
-func (g *Generator) writeMapKeyFromPlutusData(keyType string, fieldName string) {
-	switch keyType {
-	case "Int":
-		g.writeLine("mapKey := entry.Key.Integer")
-	case "ByteArray":
-		// Go map keys can't be []byte, so convert to string
-		g.writeLine("mapKey := string(entry.Key.ByteString)")
-	default:
-		if g.isPrimitiveWrapper(keyType, "bytes") {
-			g.writeLine("mapKey := string(entry.Key.ByteString)")
-		} else if g.isPrimitiveWrapper(keyType, "integer") {
-			g.writeLine("mapKey := entry.Key.Integer")
-		} else {
-			// Complex key type
-			goKeyType := g.refToGoType(keyType)
-			g.writeLine(fmt.Sprintf("var mapKey %s", goKeyType))
-			g.writeLine("if err := mapKey.FromPlutusData(entry.Key); err != nil {")
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: map key: %%w", err)`, fieldName))
-			g.indentDec()
-			g.writeLine("}")
+			// Struct with named fields
+			variantTemplates[i] = "enum_variant.go.tmpl"
+			variantData = g.structData(variantName, "variant", variant.Title, variant.Fields, variant.Index)
 		}
-	}
-}
-
-func (g *Generator) writeMapValueFromPlutusData(valueType string, fieldName string) {
-	switch valueType {
-	case "Int":
-		g.writeLine("mapVal := entry.Value.Integer")
-	case "ByteArray":
-		g.writeLine("mapVal := entry.Value.ByteString")
-	default:
-		if g.isPrimitiveWrapper(valueType, "bytes") {
-			g.writeLine("mapVal := entry.Value.ByteString")
-		} else if g.isPrimitiveWrapper(valueType, "integer") {
-			g.writeLine("mapVal := entry.Value.Integer")
-		} else if strings.HasPrefix(valueType, "Pairs$") {
-			// Nested map - needs type-specific handling
-			goValueType := g.pairsToGoType(valueType)
-			g.writeLine(fmt.Sprintf("var mapVal %s", goValueType))
-			g.writeLine("// Nested map deserialization")
-			g.writeLine("if entry.Value.Map != nil {")
-			g.indentInc()
-			g.writeLine("mapVal = make(" + goValueType + ")")
-			g.writeLine("// TODO: deserialize nested map entries")
-			g.indentDec()
-			g.writeLine("}")
-		} else {
-			// Complex value type
-			goValueType := g.refToGoType(valueType)
-			g.writeLine(fmt.Sprintf("var mapVal %s", goValueType))
-			g.writeLine("if err := mapVal.FromPlutusData(entry.Value); err != nil {")
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: map value: %%w", err)`, fieldName))
-			g.indentDec()
-			g.writeLine("}")
+		variantData.EnumName = name
+		variantData.MethodName = data.MethodName
+		variantData.ShortName = shortName
+		data.Variants = append(data.Variants, variantData)
+		if data.UnknownVariant != nil {
+			data.UnknownVariant.DeclaredIndexes = append(data.UnknownVariant.DeclaredIndexes, variant.Index)
 		}
 	}
-}
-
-func (g *Generator) writeInlineMapFieldFromPlutusData(fieldName string, schema *Schema, index int) {
-	// For inline map schemas
-	keySchema := schema.Keys
-	valueSchema := schema.Values
 
-	goKeyType := "string"
-	if keySchema != nil {
-		goKeyType = g.schemaToGoType(keySchema)
-		if goKeyType == "[]byte" {
-			goKeyType = "string"
-		}
-	}
-	goValueType := "interface{}"
-	if valueSchema != nil {
-		goValueType = g.schemaToGoType(valueSchema)
+	// Interface, FromPlutusData, streaming CBOR decoder, Equals and Match
+	// functions
+	if err := g.writeTemplate("enum_type.go.tmpl", data); err != nil {
+		return err
 	}
-
-	g.writeLine(fmt.Sprintf("if pd.Constr.Fields[%d].Map == nil {", index))
-	g.indentInc()
-	g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected map, got %%s", plutusDataTypeString(pd.Constr.Fields[%d]))`, fieldName, index))
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine(fmt.Sprintf("v.%s = make(map[%s]%s)", fieldName, goKeyType, goValueType))
-	g.writeLine(fmt.Sprintf("for _, entry := range pd.Constr.Fields[%d].Map {", index))
-	g.indentInc()
-
-	// Generate key deserialization based on schema
-	g.writeInlineMapKeyFromPlutusData(keySchema, fieldName)
-
-	// Generate value deserialization based on schema
-	g.writeInlineMapValueFromPlutusData(valueSchema, fieldName)
-
-	g.writeLine("v." + fieldName + "[mapKey] = mapVal")
-	g.indentDec()
-	g.writeLine("}")
-}
-
-func (g *Generator) writeInlineMapKeyFromPlutusData(keySchema *Schema, fieldName string) {
-	if keySchema == nil {
-		g.writeLine("mapKey := string(entry.Key.ByteString)")
-		return
+	if err := g.writeExtras(data.TypeData); err != nil {
+		return err
 	}
 
-	switch {
-	case keySchema.IsRef():
-		refName := keySchema.RefName()
-		switch refName {
-		case "Int":
-			g.writeLine("mapKey := entry.Key.Integer")
-		case "ByteArray":
-			g.writeLine("mapKey := string(entry.Key.ByteString)")
-		default:
-			if g.isPrimitiveWrapper(refName, "bytes") {
-				g.writeLine("mapKey := string(entry.Key.ByteString)")
-			} else if g.isPrimitiveWrapper(refName, "integer") {
-				g.writeLine("mapKey := entry.Key.Integer")
-			} else {
-				goKeyType := g.refToGoType(refName)
-				g.writeLine(fmt.Sprintf("var mapKey %s", goKeyType))
-				g.writeLine("if err := mapKey.FromPlutusData(entry.Key); err != nil {")
-				g.indentInc()
-				g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: map key: %%w", err)`, fieldName))
-				g.indentDec()
-				g.writeLine("}")
-			}
+	for i, variant := range data.Variants {
+		if err := g.writeTemplate(variantTemplates[i], variant); err != nil {
+			return err
 		}
-	case keySchema.IsInteger():
-		g.writeLine("mapKey := entry.Key.Integer")
-	case keySchema.IsBytes():
-		g.writeLine("mapKey := string(entry.Key.ByteString)")
-	default:
-		g.writeLine("mapKey := string(entry.Key.ByteString)")
-	}
-}
-
-func (g *Generator) writeInlineMapValueFromPlutusData(valueSchema *Schema, fieldName string) {
-	if valueSchema == nil {
-		g.writeLine("mapVal := entry.Value")
-		return
-	}
-
-	switch {
-	case valueSchema.IsRef():
-		refName := valueSchema.RefName()
-		switch refName {
-		case "Int":
-			g.writeLine("mapVal := entry.Value.Integer")
-		case "ByteArray":
-			g.writeLine("mapVal := entry.Value.ByteString")
-		default:
-			if g.isPrimitiveWrapper(refName, "bytes") {
-				g.writeLine("mapVal := entry.Value.ByteString")
-			} else if g.isPrimitiveWrapper(refName, "integer") {
-				g.writeLine("mapVal := entry.Value.Integer")
-			} else {
-				goValueType := g.refToGoType(refName)
-				g.writeLine(fmt.Sprintf("var mapVal %s", goValueType))
-				g.writeLine("if err := mapVal.FromPlutusData(entry.Value); err != nil {")
-				g.indentInc()
-				g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: map value: %%w", err)`, fieldName))
-				g.indentDec()
-				g.writeLine("}")
-			}
+		if err := g.writeExtras(variant.TypeData); err != nil {
+			return err
 		}
-	case valueSchema.IsInteger():
-		g.writeLine("mapVal := entry.Value.Integer")
-	case valueSchema.IsBytes():
-		g.writeLine("mapVal := entry.Value.ByteString")
-	case valueSchema.IsMap():
-		// Nested map
-		goValueType := g.schemaToGoType(valueSchema)
-		g.writeLine(fmt.Sprintf("var mapVal %s", goValueType))
-		g.writeLine("// TODO: deserialize nested map")
-	default:
-		goValueType := g.schemaToGoType(valueSchema)
-		g.writeLine(fmt.Sprintf("var mapVal %s", goValueType))
-		g.writeLine("if err := mapVal.FromPlutusData(entry.Value); err != nil {")
-		g.indentInc()
-		g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: map value: %%w", err)`, fieldName))
-		g.indentDec()
-		g.writeLine("}")
 	}
-}
-
-func (g *Generator) writeEnumType(name string, schema *Schema) error {
-	// Write interface
-	methodName := fmt.Sprintf("is%s", name)
-	g.executeTemplate("enum_interface.go.tmpl", map[string]string{
-		"Name":       name,
-		"MethodName": methodName,
-	})
-	g.writeLine("")
-
-	// Write FromPlutusData function for the enum
-	g.writeEnumFromPlutusData(name, schema)
 
-	// Write Equals function for the enum
-	g.writeEnumEquals(name, schema)
-
-	// Write variant structs
-	for i, variant := range schema.AnyOf {
-		variantName := name + g.toGoIdentifier(variant.Title)
-		constrIndex := i
-		if variant.Index != nil {
-			constrIndex = *variant.Index
-		}
-
-		if len(variant.Fields) == 0 {
-			// Empty struct for enum variants without fields
-			g.executeTemplate("enum_variant_empty.go.tmpl", map[string]interface{}{
-				"VariantName": variantName,
-				"EnumName":    name,
-				"MethodName":  methodName,
-				"ConstrIndex": constrIndex,
-			})
-			g.writeLine("")
-
-		} else if len(variant.Fields) == 1 && variant.Fields[0].Title == "" {
-			// Single unnamed field - wrapper type
-			fieldType := g.schemaToGoType(&variant.Fields[0])
-			g.writeLine(fmt.Sprintf("// %s is a variant of %s with a single value.", variantName, name))
-			g.writeLine(fmt.Sprintf("type %s struct {", variantName))
-			g.indentInc()
-			g.writeLine(fmt.Sprintf("Value %s", fieldType))
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine("")
-			g.writeLine(fmt.Sprintf("func (%s) %s() {}", variantName, methodName))
-			g.writeLine("")
-
-			// Write ToPlutusData/FromPlutusData for wrapper
-			g.writeWrapperToPlutusData(variantName, &variant.Fields[0], constrIndex)
-			g.writeWrapperFromPlutusData(variantName, &variant.Fields[0], constrIndex)
-
-			// Write Equals for wrapper
-			g.writeWrapperEquals(variantName, &variant.Fields[0])
-		} else {
-			// Struct with named fields
-			g.writeLine(fmt.Sprintf("// %s is a variant of %s.", variantName, name))
-			if err := g.writeStructType(variantName, &variant, constrIndex); err != nil {
-				return err
-			}
-			g.writeLine(fmt.Sprintf("func (%s) %s() {}", variantName, methodName))
-			g.writeLine("")
+	if unknown := data.UnknownVariant; unknown != nil {
+		if err := g.writeTemplate("enum_variant_unknown.go.tmpl", unknown); err != nil {
+			return err
 		}
-	}
-
-	return nil
-}
-
-func (g *Generator) writeEnumFromPlutusData(name string, schema *Schema) {
-	g.writeLine(fmt.Sprintf("// %sFromPlutusData decodes a %s from PlutusData.", name, name))
-	g.writeLine(fmt.Sprintf("func %sFromPlutusData(pd PlutusData) (%s, error) {", name, name))
-	g.indentInc()
-	g.writeLine("if pd.Constr == nil {")
-	g.indentInc()
-	g.writeLine(fmt.Sprintf(`return nil, errors.New("expected constructor for %s")`, name))
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("")
-	g.writeLine("switch pd.Constr.Index {")
-
-	for i, variant := range schema.AnyOf {
-		constrIndex := i
-		if variant.Index != nil {
-			constrIndex = *variant.Index
+		if err := g.writeExtras(unknown.TypeData); err != nil {
+			return err
 		}
-		variantName := name + g.toGoIdentifier(variant.Title)
-		g.writeLine(fmt.Sprintf("case %d:", constrIndex))
-		g.indentInc()
-		g.writeLine(fmt.Sprintf("var v %s", variantName))
-		g.writeLine("if err := v.FromPlutusData(pd); err != nil {")
-		g.indentInc()
-		g.writeLine("return nil, err")
-		g.indentDec()
-		g.writeLine("}")
-		g.writeLine("return v, nil")
-		g.indentDec()
 	}
-
-	g.writeLine("default:")
-	g.indentInc()
-	g.writeLine(fmt.Sprintf(`return nil, fmt.Errorf("unknown constructor index for %s: got %%d", pd.Constr.Index)`, name))
-	g.indentDec()
-	g.writeLine("}")
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("")
+
+	return nil
 }
 
-func (g *Generator) writeEnumEquals(name string, schema *Schema) {
-	g.executeTemplate("enum_equals.go.tmpl", map[string]string{"Name": name})
-	g.writeLine("")
+// writeConstEnumType writes an enum whose constructors have no fields as
+// constants of a uint8 type.
+func (g *Generator) writeConstEnumType(name string, enum *ir.Enum) error {
+	data := &EnumData{
+		TypeData: TypeData{Name: name, Receiver: name, Kind: "const_enum"},
+		Iota:     true,
+	}
+	for i, variant := range enum.Variants {
+		shortName := g.toGoIdentifier(variant.Title)
+		data.Variants = append(data.Variants, &StructData{
+			TypeData:    TypeData{Name: name + shortName, EnumName: name},
+			Title:       variant.Title,
+			ConstrIndex: variant.Index,
+			ShortName:   shortName,
+		})
+		data.Iota = data.Iota && variant.Index == i
+	}
+
+	if err := g.writeTemplate("const_enum_type.go.tmpl", data); err != nil {
+		return err
+	}
+	return g.writeExtras(data.TypeData)
+}
+
+// wrapperData returns the data of an enum variant with a single unnamed
+// field, which it wraps as Value.
+func (g *Generator) wrapperData(name string, field ir.Type, constrIndex int) *StructData {
+	data := &StructData{
+		TypeData:    TypeData{Name: name, Receiver: name, Kind: "variant"},
+		ConstrIndex: constrIndex,
+		Fields: []*FieldData{{
+			Name:           "Value",
+			GoType:         g.goType(field),
+			ToPlutusData:   g.capture(1, func() { g.writeWrapperToPlutusData(field, constrIndex) }),
+			FromPlutusData: g.capture(1, func() { g.writeWrapperFromPlutusData(field) }),
+			Equals:         g.capture(1, func() { g.writeWrapperEquals(field) }),
+			Validate:       g.fieldValidate("Value", field),
+			Param:          "value",
+		}},
+	}
+	g.setCBORCodecs(data, []ir.Type{field})
+	return data
+}
+
+// unknownVariantName returns the name of the catch-all variant of an enum,
+// avoiding a declared variant titled Unknown.
+func (g *Generator) unknownVariantName(name string, enum *ir.Enum) string {
+	unknown := name + "Unknown"
+	for _, variant := range enum.Variants {
+		if name+g.toGoIdentifier(variant.Title) == unknown {
+			return unknown + "Variant"
+		}
+	}
+	return unknown
 }
 
 // getTupleFieldNames extracts field names from tuple items.
@@ -2058,17 +896,22 @@ func (g *Generator) getTupleFieldNames(items []*Schema) []string {
 		if item.IsRef() {
 			// Extract type name from ref (e.g., "#/definitions/cardano~1assets~1PolicyId" -> "PolicyId")
 			refName := item.RefName()
-			// Get the last part after any slashes or tildes
-			parts := strings.Split(refName, "/")
-			fieldName = parts[len(parts)-1]
-			// Also handle tilde-encoded slashes
-			if strings.Contains(fieldName, "~1") {
-				subParts := strings.Split(fieldName, "~1")
-				fieldName = subParts[len(subParts)-1]
-			}
-			// Capitalize first letter
-			if len(fieldName) > 0 {
-				fieldName = strings.ToUpper(fieldName[:1]) + fieldName[1:]
+			if strings.Contains(refName, "$") {
+				// A generic instance, e.g. "List$ByteArray" -> "ListByteArray"
+				fieldName = g.normalizeTypeName(refName)
+			} else {
+				// Get the last part after any slashes or tildes
+				parts := strings.Split(refName, "/")
+				fieldName = parts[len(parts)-1]
+				// Also handle tilde-encoded slashes
+				if strings.Contains(fieldName, "~1") {
+					subParts := strings.Split(fieldName, "~1")
+					fieldName = subParts[len(subParts)-1]
+				}
+				// Capitalize first letter
+				if len(fieldName) > 0 {
+					fieldName = strings.ToUpper(fieldName[:1]) + fieldName[1:]
+				}
 			}
 		}
 
@@ -2091,131 +934,49 @@ func (g *Generator) getTupleFieldNames(items []*Schema) []string {
 	return names
 }
 
-func (g *Generator) writeTupleType(name string, schema *Schema) error {
-	g.writeLine(fmt.Sprintf("// %s represents a tuple type.", name))
-	g.writeLine(fmt.Sprintf("type %s struct {", name))
-	g.indentInc()
-
-	// Generate fields - use type names from refs when available
-	fieldNames := g.getTupleFieldNames(schema.Items)
-	for i, item := range schema.Items {
-		fieldName := fieldNames[i]
-		goType := g.schemaToGoType(item)
-		g.writeLine(fmt.Sprintf("%s %s", fieldName, goType))
-	}
-
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("")
-
-	// ToPlutusData
-	g.writeLine(fmt.Sprintf("func (v %s) ToPlutusData() (PlutusData, error) {", name))
-	g.indentInc()
-	g.writeLine(fmt.Sprintf("items := make([]PlutusData, %d)", len(schema.Items)))
-
-	for i, item := range schema.Items {
-		fieldName := fieldNames[i]
-		g.writeTupleFieldToPlutusData(fieldName, item, i)
+func (g *Generator) writeTupleType(name string, tuple *ir.Tuple, fieldNames []string) error {
+	data := &StructData{
+		TypeData:    TypeData{Name: name, Receiver: name, Kind: "tuple"},
+		ConstrIndex: -1,
 	}
 
-	g.writeLine("return NewListPlutusData(items...), nil")
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("")
-
-	// FromPlutusData
-	g.writeLine(fmt.Sprintf("func (v *%s) FromPlutusData(pd PlutusData) error {", name))
-	g.indentInc()
-	g.writeLine("if pd.List == nil {")
-	g.indentInc()
-	g.writeLine(fmt.Sprintf(`return errors.New("expected list for %s")`, name))
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine(fmt.Sprintf("if len(pd.List) != %d {", len(schema.Items)))
-	g.indentInc()
-	g.writeLine(fmt.Sprintf(`return fmt.Errorf("wrong number of items for %s: expected %d, got %%d", len(pd.List))`, name, len(schema.Items)))
-	g.indentDec()
-	g.writeLine("}")
-
-	for i, item := range schema.Items {
+	for i, item := range tuple.Items {
 		fieldName := fieldNames[i]
-		g.writeTupleFieldFromPlutusData(fieldName, item, i)
+		data.Fields = append(data.Fields, &FieldData{
+			Name:           fieldName,
+			GoType:         g.goType(item),
+			Index:          i,
+			ToPlutusData:   g.capture(1, func() { g.writeTupleFieldToPlutusData(fieldName, item, i) }),
+			FromPlutusData: g.capture(1, func() { g.writeTupleFieldFromPlutusData(fieldName, item, i) }),
+			Equals:         g.capture(1, func() { g.writeTupleFieldEquals(fieldName, item) }),
+			Validate:       g.fieldValidate(fieldName, item),
+			Param:          constructorParam(fieldName),
+		})
 	}
+	g.setCBORCodecs(data, tuple.Items)
 
-	g.writeLine("return nil")
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("")
-
-	// Equals method
-	g.writeTupleEquals(name, schema, fieldNames)
-
-	return nil
-}
-
-func (g *Generator) writeTupleEquals(name string, schema *Schema, fieldNames []string) {
-	g.writeLine(fmt.Sprintf("func (v %s) Equals(other %s) bool {", name, name))
-	g.indentInc()
-
-	for i, item := range schema.Items {
-		fieldName := fieldNames[i]
-		g.writeTupleFieldEquals(fieldName, item)
+	if err := g.writeTemplate("tuple_type.go.tmpl", data); err != nil {
+		return err
 	}
-
-	g.writeLine("return true")
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("")
+	return g.writeExtras(data.TypeData)
 }
 
-func (g *Generator) writeTupleFieldEquals(fieldName string, item *Schema) {
+func (g *Generator) writeTupleFieldEquals(fieldName string, item ir.Type) {
 	switch {
-	case item.IsRef():
-		refName := item.RefName()
-		switch refName {
-		case "Int":
-			g.writeLine(fmt.Sprintf("if v.%s == nil && other.%s == nil {", fieldName, fieldName))
-			g.writeLine(fmt.Sprintf("} else if v.%s == nil || other.%s == nil || v.%s.Cmp(other.%s) != 0 {", fieldName, fieldName, fieldName, fieldName))
-			g.indentInc()
-			g.writeLine("return false")
-			g.indentDec()
-			g.writeLine("}")
-		case "ByteArray":
-			g.writeLine(fmt.Sprintf("if !bytes.Equal(v.%s, other.%s) {", fieldName, fieldName))
-			g.indentInc()
-			g.writeLine("return false")
-			g.indentDec()
-			g.writeLine("}")
-		default:
-			if g.isPrimitiveWrapper(refName, "bytes") {
-				g.writeLine(fmt.Sprintf("if !bytes.Equal(v.%s, other.%s) {", fieldName, fieldName))
-				g.indentInc()
-				g.writeLine("return false")
-				g.indentDec()
-				g.writeLine("}")
-			} else if g.isPrimitiveWrapper(refName, "integer") {
-				g.writeLine(fmt.Sprintf("if v.%s == nil && other.%s == nil {", fieldName, fieldName))
-				g.writeLine(fmt.Sprintf("} else if v.%s == nil || other.%s == nil || v.%s.Cmp(other.%s) != 0 {", fieldName, fieldName, fieldName, fieldName))
-				g.indentInc()
-				g.writeLine("return false")
-				g.indentDec()
-				g.writeLine("}")
-			} else {
-				g.writeLine(fmt.Sprintf("if !v.%s.Equals(other.%s) {", fieldName, fieldName))
-				g.indentInc()
-				g.writeLine("return false")
-				g.indentDec()
-				g.writeLine("}")
-			}
-		}
-	case item.IsInteger():
+	case g.isContainer(item):
+		g.writeLine(fmt.Sprintf("if !%s {", g.plutusEqualsCall(item, "v."+fieldName, "other."+fieldName)))
+		g.indentInc()
+		g.writeLine("return false")
+		g.indentDec()
+		g.writeLine("}")
+	case isInt(item):
 		g.writeLine(fmt.Sprintf("if v.%s == nil && other.%s == nil {", fieldName, fieldName))
 		g.writeLine(fmt.Sprintf("} else if v.%s == nil || other.%s == nil || v.%s.Cmp(other.%s) != 0 {", fieldName, fieldName, fieldName, fieldName))
 		g.indentInc()
 		g.writeLine("return false")
 		g.indentDec()
 		g.writeLine("}")
-	case item.IsBytes():
+	case isBytes(item):
 		g.writeLine(fmt.Sprintf("if !bytes.Equal(v.%s, other.%s) {", fieldName, fieldName))
 		g.indentInc()
 		g.writeLine("return false")
@@ -2230,128 +991,55 @@ func (g *Generator) writeTupleFieldEquals(fieldName string, item *Schema) {
 	}
 }
 
-func (g *Generator) writeListTypeAlias(name string, schema *Schema) error {
-	// Get the inner type
-	innerSchema := schema.Items.Single()
-	if innerSchema == nil {
-		return fmt.Errorf("list type %s has no inner type", name)
+func (g *Generator) writeListTypeAlias(name string, list *ir.List) error {
+	item := list.Elem
+	data := &ListData{
+		TypeData:           TypeData{Name: name, Receiver: name, Kind: "list"},
+		ItemType:           g.goType(item),
+		ItemToPlutusData:   g.capture(2, func() { g.writeListAliasItemToPlutusData(item) }),
+		ItemFromPlutusData: g.capture(2, func() { g.writeListAliasItemFromPlutusData(item) }),
+		ItemEquals:         g.capture(2, func() { g.writeListAliasItemEquals(item) }),
+		Validate:           g.containerValidateCall(list, fmt.Sprintf("[]%s(v)", g.goType(item))),
 	}
+	// Streaming CBOR decoder and direct encoder
+	data.CBORDecoder, _ = g.cborDecoder(item)
+	data.CBOREncoder, _ = g.cborEncoder(item)
 
-	innerGoType := g.schemaToGoType(innerSchema)
-
-	g.writeLine(fmt.Sprintf("// %s represents a list of %s.", name, innerGoType))
-	g.writeLine(fmt.Sprintf("type %s []%s", name, innerGoType))
-	g.writeLine("")
-
-	// ToPlutusData
-	g.writeLine(fmt.Sprintf("func (v %s) ToPlutusData() (PlutusData, error) {", name))
-	g.indentInc()
-	g.writeLine("items := make([]PlutusData, len(v))")
-	g.writeLine("for i, item := range v {")
-	g.indentInc()
-	g.writeListAliasItemToPlutusData(innerSchema)
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("return NewListPlutusData(items...), nil")
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("")
-
-	// FromPlutusData
-	g.writeLine(fmt.Sprintf("func (v *%s) FromPlutusData(pd PlutusData) error {", name))
-	g.indentInc()
-	g.writeLine("if pd.List == nil {")
-	g.indentInc()
-	g.writeLine(fmt.Sprintf(`return errors.New("expected list for %s")`, name))
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine(fmt.Sprintf("*v = make(%s, len(pd.List))", name))
-	g.writeLine("for i, item := range pd.List {")
-	g.indentInc()
-	g.writeListAliasItemFromPlutusData(innerSchema, name)
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("return nil")
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("")
-
-	// Equals method
-	g.writeListAliasEquals(name, innerSchema)
-
-	return nil
+	if err := g.writeTemplate("list_type.go.tmpl", data); err != nil {
+		return err
+	}
+	return g.writeExtras(data.TypeData)
 }
 
-func (g *Generator) writeListAliasEquals(name string, innerSchema *Schema) {
-	g.writeLine(fmt.Sprintf("func (v %s) Equals(other %s) bool {", name, name))
-	g.indentInc()
-	g.writeLine("if len(v) != len(other) {")
-	g.indentInc()
-	g.writeLine("return false")
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("for i := range v {")
-	g.indentInc()
-
+// writeListAliasItemEquals writes the statements returning false if v[i]
+// and other[i] differ.
+func (g *Generator) writeListAliasItemEquals(item ir.Type) {
 	switch {
-	case innerSchema.IsRef():
-		refName := innerSchema.RefName()
-		switch refName {
-		case "Int":
-			g.writeLine("if v[i] == nil && other[i] == nil {")
-			g.writeLine("} else if v[i] == nil || other[i] == nil || v[i].Cmp(other[i]) != 0 {")
-			g.indentInc()
-			g.writeLine("return false")
-			g.indentDec()
-			g.writeLine("}")
-		case "ByteArray":
-			g.writeLine("if !bytes.Equal(v[i], other[i]) {")
-			g.indentInc()
-			g.writeLine("return false")
-			g.indentDec()
-			g.writeLine("}")
-		default:
-			if g.isPrimitiveWrapper(refName, "bytes") {
-				g.writeLine("if !bytes.Equal(v[i], other[i]) {")
-				g.indentInc()
-				g.writeLine("return false")
-				g.indentDec()
-				g.writeLine("}")
-			} else if g.isPrimitiveWrapper(refName, "integer") {
-				g.writeLine("if v[i] == nil && other[i] == nil {")
-				g.writeLine("} else if v[i] == nil || other[i] == nil || v[i].Cmp(other[i]) != 0 {")
-				g.indentInc()
-				g.writeLine("return false")
-				g.indentDec()
-				g.writeLine("}")
-			} else if defSchema, ok := g.bp.Definitions[g.unescapeRef(refName)]; ok && defSchema.IsEnum() && !defSchema.IsSingleConstructor() {
-				typeName := g.normalizeTypeName(refName)
-				g.writeLine(fmt.Sprintf("if !%sEquals(v[i], other[i]) {", typeName))
-				g.indentInc()
-				g.writeLine("return false")
-				g.indentDec()
-				g.writeLine("}")
-			} else {
-				g.writeLine("if !v[i].Equals(other[i]) {")
-				g.indentInc()
-				g.writeLine("return false")
-				g.indentDec()
-				g.writeLine("}")
-			}
-		}
-	case innerSchema.IsInteger():
+	case g.isContainer(item):
+		g.writeLine(fmt.Sprintf("if !%s {", g.plutusEqualsCall(item, "v[i]", "other[i]")))
+		g.indentInc()
+		g.writeLine("return false")
+		g.indentDec()
+		g.writeLine("}")
+	case isInt(item):
 		g.writeLine("if v[i] == nil && other[i] == nil {")
 		g.writeLine("} else if v[i] == nil || other[i] == nil || v[i].Cmp(other[i]) != 0 {")
 		g.indentInc()
 		g.writeLine("return false")
 		g.indentDec()
 		g.writeLine("}")
-	case innerSchema.IsBytes():
+	case isBytes(item):
 		g.writeLine("if !bytes.Equal(v[i], other[i]) {")
 		g.indentInc()
 		g.writeLine("return false")
 		g.indentDec()
 		g.writeLine("}")
+	case g.isEnum(item):
+		g.writeLine(fmt.Sprintf("if !%sEquals(v[i], other[i]) {", g.goType(item)))
+		g.indentInc()
+		g.writeLine("return false")
+		g.indentDec()
+		g.writeLine("}")
 	default:
 		g.writeLine("if !v[i].Equals(other[i]) {")
 		g.indentInc()
@@ -2359,441 +1047,224 @@ func (g *Generator) writeListAliasEquals(name string, innerSchema *Schema) {
 		g.indentDec()
 		g.writeLine("}")
 	}
-
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("return true")
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("")
 }
 
-func (g *Generator) writeListAliasItemToPlutusData(innerSchema *Schema) {
+func (g *Generator) writeListAliasItemToPlutusData(item ir.Type) {
 	switch {
-	case innerSchema.IsRef():
-		refName := innerSchema.RefName()
-		switch refName {
-		case "Int":
-			g.writeLine("items[i] = NewIntPlutusData(item)")
-		case "ByteArray":
-			g.writeLine("items[i] = NewBytesPlutusData(item)")
-		default:
-			if g.isPrimitiveWrapper(refName, "bytes") {
-				g.writeLine("items[i] = NewBytesPlutusData(item)")
-			} else if g.isPrimitiveWrapper(refName, "integer") {
-				g.writeLine("items[i] = NewIntPlutusData(item)")
-			} else {
-				g.writeLine("pd, err := item.ToPlutusData()")
-				g.writeLine("if err != nil {")
-				g.indentInc()
-				g.writeLine(`return PlutusData{}, fmt.Errorf("item[%d]: %w", i, err)`)
-				g.indentDec()
-				g.writeLine("}")
-				g.writeLine("items[i] = pd")
-			}
-		}
-	case innerSchema.IsInteger():
+	case g.isContainer(item):
+		g.writeLine(fmt.Sprintf("pd, err := %s", g.plutusEncodeCall(item, "item")))
+		g.writeLine("if err != nil {")
+		g.indentInc()
+		g.writeLine(`return PlutusData{}, validationErrorAt(err, pathItem(i))`)
+		g.indentDec()
+		g.writeLine("}")
+		g.writeLine("items[i] = pd")
+	case isInt(item):
+		g.writeNilCheck("item", "*big.Int", "pathItem(i)")
 		g.writeLine("items[i] = NewIntPlutusData(item)")
-	case innerSchema.IsBytes():
+	case isBytes(item):
 		g.writeLine("items[i] = NewBytesPlutusData(item)")
 	default:
+		if g.isEnum(item) {
+			g.writeNilCheck("item", g.goType(item), "pathItem(i)")
+		}
 		g.writeLine("pd, err := item.ToPlutusData()")
 		g.writeLine("if err != nil {")
 		g.indentInc()
-		g.writeLine(`return PlutusData{}, fmt.Errorf("item[%d]: %w", i, err)`)
+		g.writeLine(`return PlutusData{}, validationErrorAt(err, pathItem(i))`)
 		g.indentDec()
 		g.writeLine("}")
 		g.writeLine("items[i] = pd")
 	}
 }
 
-func (g *Generator) writeListAliasItemFromPlutusData(innerSchema *Schema, listName string) {
+func (g *Generator) writeListAliasItemFromPlutusData(item ir.Type) {
 	switch {
-	case innerSchema.IsRef():
-		refName := innerSchema.RefName()
-		switch refName {
-		case "Int":
-			g.writeLine("if item.Integer == nil {")
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return fmt.Errorf("%s: list item expected integer, got %%s", plutusDataTypeString(item))`, listName))
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine("(*v)[i] = item.Integer")
-		case "ByteArray":
-			g.writeLine("if item.ByteString == nil {")
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return fmt.Errorf("%s: list item expected bytes, got %%s", plutusDataTypeString(item))`, listName))
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine("(*v)[i] = item.ByteString")
-		default:
-			if g.isPrimitiveWrapper(refName, "bytes") {
-				g.writeLine("if item.ByteString == nil {")
-				g.indentInc()
-				g.writeLine(fmt.Sprintf(`return fmt.Errorf("%s: list item expected bytes, got %%s", plutusDataTypeString(item))`, listName))
-				g.indentDec()
-				g.writeLine("}")
-				g.writeLine("(*v)[i] = item.ByteString")
-			} else if g.isPrimitiveWrapper(refName, "integer") {
-				g.writeLine("if item.Integer == nil {")
-				g.indentInc()
-				g.writeLine(fmt.Sprintf(`return fmt.Errorf("%s: list item expected integer, got %%s", plutusDataTypeString(item))`, listName))
-				g.indentDec()
-				g.writeLine("}")
-				g.writeLine("(*v)[i] = item.Integer")
-			} else {
-				goType := g.refToGoType(refName)
-				// Check if it's an enum type
-				unescaped := g.unescapeRef(refName)
-				if def, ok := g.bp.Definitions[unescaped]; ok && def.IsEnum() {
-					factoryFunc := goType + "FromPlutusData"
-					g.writeLine(fmt.Sprintf("val, err := %s(item)", factoryFunc))
-					g.writeLine("if err != nil {")
-					g.indentInc()
-					g.writeLine("return err")
-					g.indentDec()
-					g.writeLine("}")
-					g.writeLine("(*v)[i] = val")
-				} else {
-					g.writeLine(fmt.Sprintf("var val %s", goType))
-					g.writeLine("if err := val.FromPlutusData(item); err != nil {")
-					g.indentInc()
-					g.writeLine("return err")
-					g.indentDec()
-					g.writeLine("}")
-					g.writeLine("(*v)[i] = val")
-				}
-			}
-		}
-	case innerSchema.IsInteger():
-		g.writeLine("if item.Integer == nil {")
+	case g.isContainer(item):
+		g.writeLine(fmt.Sprintf("if err := %s; err != nil {", g.plutusDecodeCall(item, "item", "&(*v)[i]")))
+		g.indentInc()
+		g.writeLine("return decodeErrorAt(err, pathItem(i))")
+		g.indentDec()
+		g.writeLine("}")
+	case isInt(item):
+		g.writeLine("if item.Kind() != KindInteger {")
 		g.indentInc()
-		g.writeLine(fmt.Sprintf(`return fmt.Errorf("%s: list item expected integer, got %%s", plutusDataTypeString(item))`, listName))
+		g.writeLine(`return decodeErrorAt(decodeKindError("*big.Int", "integer", item), pathItem(i))`)
 		g.indentDec()
 		g.writeLine("}")
 		g.writeLine("(*v)[i] = item.Integer")
-	case innerSchema.IsBytes():
-		g.writeLine("if item.ByteString == nil {")
+	case isBytes(item):
+		g.writeLine("if item.Kind() != KindBytes {")
 		g.indentInc()
-		g.writeLine(fmt.Sprintf(`return fmt.Errorf("%s: list item expected bytes, got %%s", plutusDataTypeString(item))`, listName))
+		g.writeLine(`return decodeErrorAt(decodeKindError("[]byte", "bytes", item), pathItem(i))`)
 		g.indentDec()
 		g.writeLine("}")
 		g.writeLine("(*v)[i] = item.ByteString")
+	case g.isEnum(item):
+		g.writeLine(fmt.Sprintf("val, err := %sFromPlutusData(item)", g.goType(item)))
+		g.writeLine("if err != nil {")
+		g.indentInc()
+		g.writeLine("return decodeErrorAt(err, pathItem(i))")
+		g.indentDec()
+		g.writeLine("}")
+		g.writeLine("(*v)[i] = val")
 	default:
-		g.writeLine("var val " + g.schemaToGoType(innerSchema))
+		g.writeLine("var val " + g.goType(item))
 		g.writeLine("if err := val.FromPlutusData(item); err != nil {")
 		g.indentInc()
-		g.writeLine("return err")
+		g.writeLine("return decodeErrorAt(err, pathItem(i))")
 		g.indentDec()
 		g.writeLine("}")
 		g.writeLine("(*v)[i] = val")
 	}
 }
 
-func (g *Generator) writeTupleFieldToPlutusData(fieldName string, item *Schema, index int) {
+func (g *Generator) writeTupleFieldToPlutusData(fieldName string, item ir.Type, index int) {
 	switch {
-	case item.IsRef():
-		refName := item.RefName()
-		switch refName {
-		case "Int":
-			g.writeLine(fmt.Sprintf("items[%d] = NewIntPlutusData(v.%s)", index, fieldName))
-		case "ByteArray":
-			g.writeLine(fmt.Sprintf("items[%d] = NewBytesPlutusData(v.%s)", index, fieldName))
-		default:
-			if g.isPrimitiveWrapper(refName, "bytes") {
-				g.writeLine(fmt.Sprintf("items[%d] = NewBytesPlutusData(v.%s)", index, fieldName))
-			} else if g.isPrimitiveWrapper(refName, "integer") {
-				g.writeLine(fmt.Sprintf("items[%d] = NewIntPlutusData(v.%s)", index, fieldName))
-			} else {
-				g.writeLine(fmt.Sprintf("item%d, err := v.%s.ToPlutusData()", index, fieldName))
-				g.writeLine("if err != nil {")
-				g.indentInc()
-				g.writeLine(fmt.Sprintf(`return PlutusData{}, fmt.Errorf("field %s: %%w", err)`, fieldName))
-				g.indentDec()
-				g.writeLine("}")
-				g.writeLine(fmt.Sprintf("items[%d] = item%d", index, index))
-			}
-		}
-	case item.IsInteger():
+	case g.isContainer(item):
+		g.writeLine(fmt.Sprintf("item%d, err := %s", index, g.plutusEncodeCall(item, "v."+fieldName)))
+		g.writeLine("if err != nil {")
+		g.indentInc()
+		g.writeLine(fmt.Sprintf(`return PlutusData{}, validationErrorAt(err, "%s")`, fieldName))
+		g.indentDec()
+		g.writeLine("}")
+		g.writeLine(fmt.Sprintf("items[%d] = item%d", index, index))
+	case isInt(item):
+		g.writeNilCheck("v."+fieldName, "*big.Int", strconv.Quote(fieldName))
 		g.writeLine(fmt.Sprintf("items[%d] = NewIntPlutusData(v.%s)", index, fieldName))
-	case item.IsBytes():
+	case isBytes(item):
 		g.writeLine(fmt.Sprintf("items[%d] = NewBytesPlutusData(v.%s)", index, fieldName))
 	default:
+		if g.isEnum(item) {
+			g.writeNilCheck("v."+fieldName, g.goType(item), strconv.Quote(fieldName))
+		}
 		g.writeLine(fmt.Sprintf("item%d, err := v.%s.ToPlutusData()", index, fieldName))
 		g.writeLine("if err != nil {")
 		g.indentInc()
-		g.writeLine(fmt.Sprintf(`return PlutusData{}, fmt.Errorf("field %s: %%w", err)`, fieldName))
+		g.writeLine(fmt.Sprintf(`return PlutusData{}, validationErrorAt(err, "%s")`, fieldName))
 		g.indentDec()
 		g.writeLine("}")
 		g.writeLine(fmt.Sprintf("items[%d] = item%d", index, index))
 	}
 }
 
-func (g *Generator) writeTupleFieldFromPlutusData(fieldName string, item *Schema, index int) {
+func (g *Generator) writeTupleFieldFromPlutusData(fieldName string, item ir.Type, index int) {
 	switch {
-	case item.IsRef():
-		refName := item.RefName()
-		switch refName {
-		case "Int":
-			g.writeLine(fmt.Sprintf("if pd.List[%d].Integer == nil {", index))
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected integer, got %%s", plutusDataTypeString(pd.List[%d]))`, fieldName, index))
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine(fmt.Sprintf("v.%s = pd.List[%d].Integer", fieldName, index))
-		case "ByteArray":
-			g.writeLine(fmt.Sprintf("if pd.List[%d].ByteString == nil {", index))
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected bytes, got %%s", plutusDataTypeString(pd.List[%d]))`, fieldName, index))
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine(fmt.Sprintf("v.%s = pd.List[%d].ByteString", fieldName, index))
-		default:
-			if g.isPrimitiveWrapper(refName, "bytes") {
-				g.writeLine(fmt.Sprintf("if pd.List[%d].ByteString == nil {", index))
-				g.indentInc()
-				g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected bytes, got %%s", plutusDataTypeString(pd.List[%d]))`, fieldName, index))
-				g.indentDec()
-				g.writeLine("}")
-				g.writeLine(fmt.Sprintf("v.%s = pd.List[%d].ByteString", fieldName, index))
-			} else if g.isPrimitiveWrapper(refName, "integer") {
-				g.writeLine(fmt.Sprintf("if pd.List[%d].Integer == nil {", index))
-				g.indentInc()
-				g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected integer, got %%s", plutusDataTypeString(pd.List[%d]))`, fieldName, index))
-				g.indentDec()
-				g.writeLine("}")
-				g.writeLine(fmt.Sprintf("v.%s = pd.List[%d].Integer", fieldName, index))
-			} else {
-				g.writeLine(fmt.Sprintf("if err := v.%s.FromPlutusData(pd.List[%d]); err != nil {", fieldName, index))
-				g.indentInc()
-				g.writeLine("return err")
-				g.indentDec()
-				g.writeLine("}")
-			}
-		}
-	case item.IsInteger():
-		g.writeLine(fmt.Sprintf("if pd.List[%d].Integer == nil {", index))
+	case g.isContainer(item):
+		g.writeLine(fmt.Sprintf("if err := %s; err != nil {", g.plutusDecodeCall(item, fmt.Sprintf("pd.List[%d]", index), "&v."+fieldName)))
+		g.indentInc()
+		g.writeLine(fmt.Sprintf(`return decodeErrorAt(err, "%s")`, fieldName))
+		g.indentDec()
+		g.writeLine("}")
+	case isInt(item):
+		g.writeLine(fmt.Sprintf("if pd.List[%d].Kind() != KindInteger {", index))
 		g.indentInc()
-		g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected integer, got %%s", plutusDataTypeString(pd.List[%d]))`, fieldName, index))
+		g.writeLine(fmt.Sprintf(`return decodeErrorAt(decodeKindError("*big.Int", "integer", pd.List[%d]), "%s")`, index, fieldName))
 		g.indentDec()
 		g.writeLine("}")
 		g.writeLine(fmt.Sprintf("v.%s = pd.List[%d].Integer", fieldName, index))
-	case item.IsBytes():
-		g.writeLine(fmt.Sprintf("if pd.List[%d].ByteString == nil {", index))
+	case isBytes(item):
+		g.writeLine(fmt.Sprintf("if pd.List[%d].Kind() != KindBytes {", index))
 		g.indentInc()
-		g.writeLine(fmt.Sprintf(`return fmt.Errorf("field %s: expected bytes, got %%s", plutusDataTypeString(pd.List[%d]))`, fieldName, index))
+		g.writeLine(fmt.Sprintf(`return decodeErrorAt(decodeKindError("[]byte", "bytes", pd.List[%d]), "%s")`, index, fieldName))
 		g.indentDec()
 		g.writeLine("}")
 		g.writeLine(fmt.Sprintf("v.%s = pd.List[%d].ByteString", fieldName, index))
 	default:
 		g.writeLine(fmt.Sprintf("if err := v.%s.FromPlutusData(pd.List[%d]); err != nil {", fieldName, index))
 		g.indentInc()
-		g.writeLine("return err")
+		g.writeLine(fmt.Sprintf(`return decodeErrorAt(err, "%s")`, fieldName))
 		g.indentDec()
 		g.writeLine("}")
 	}
 }
 
-func (g *Generator) writeWrapperToPlutusData(name string, field *Schema, constrIndex int) {
-	g.writeLine(fmt.Sprintf("func (v %s) ToPlutusData() (PlutusData, error) {", name))
-	g.indentInc()
-
+// writeWrapperToPlutusData writes the statements returning the encoding of
+// a wrapper variant.
+func (g *Generator) writeWrapperToPlutusData(field ir.Type, constrIndex int) {
 	switch {
-	case field.IsRef():
-		refName := field.RefName()
-		switch refName {
-		case "Int":
-			g.writeLine(fmt.Sprintf("return NewConstrPlutusData(%d, NewIntPlutusData(v.Value)), nil", constrIndex))
-		case "ByteArray":
-			g.writeLine(fmt.Sprintf("return NewConstrPlutusData(%d, NewBytesPlutusData(v.Value)), nil", constrIndex))
-		case "Data":
-			// Data type is raw PlutusData - pass through directly
-			g.writeLine(fmt.Sprintf("return NewConstrPlutusData(%d, v.Value), nil", constrIndex))
-		default:
-			if g.isPrimitiveWrapper(refName, "bytes") {
-				g.writeLine(fmt.Sprintf("return NewConstrPlutusData(%d, NewBytesPlutusData(v.Value)), nil", constrIndex))
-			} else if g.isPrimitiveWrapper(refName, "integer") {
-				g.writeLine(fmt.Sprintf("return NewConstrPlutusData(%d, NewIntPlutusData(v.Value)), nil", constrIndex))
-			} else {
-				g.writeLine("inner, err := v.Value.ToPlutusData()")
-				g.writeLine("if err != nil {")
-				g.indentInc()
-				g.writeLine(`return PlutusData{}, fmt.Errorf("Value: %w", err)`)
-				g.indentDec()
-				g.writeLine("}")
-				g.writeLine(fmt.Sprintf("return NewConstrPlutusData(%d, inner), nil", constrIndex))
-			}
-		}
-	case field.IsInteger():
+	case g.isContainer(field):
+		g.writeLine(fmt.Sprintf("inner, err := %s", g.plutusEncodeCall(field, "v.Value")))
+		g.writeLine("if err != nil {")
+		g.indentInc()
+		g.writeLine(`return PlutusData{}, validationErrorAt(err, "Value")`)
+		g.indentDec()
+		g.writeLine("}")
+		g.writeLine(fmt.Sprintf("return NewConstrPlutusData(%d, inner), nil", constrIndex))
+	case isInt(field):
+		g.writeNilCheck("v.Value", "*big.Int", `"Value"`)
 		g.writeLine(fmt.Sprintf("return NewConstrPlutusData(%d, NewIntPlutusData(v.Value)), nil", constrIndex))
-	case field.IsBytes():
+	case isBytes(field):
 		g.writeLine(fmt.Sprintf("return NewConstrPlutusData(%d, NewBytesPlutusData(v.Value)), nil", constrIndex))
+	case isData(field):
+		// Data type is raw PlutusData - pass through directly
+		g.writeLine(fmt.Sprintf("return NewConstrPlutusData(%d, v.Value), nil", constrIndex))
 	default:
+		if g.isEnum(field) {
+			g.writeNilCheck("v.Value", g.goType(field), `"Value"`)
+		}
 		g.writeLine("inner, err := v.Value.ToPlutusData()")
 		g.writeLine("if err != nil {")
 		g.indentInc()
-		g.writeLine(`return PlutusData{}, fmt.Errorf("Value: %w", err)`)
+		g.writeLine(`return PlutusData{}, validationErrorAt(err, "Value")`)
 		g.indentDec()
 		g.writeLine("}")
 		g.writeLine(fmt.Sprintf("return NewConstrPlutusData(%d, inner), nil", constrIndex))
 	}
-
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("")
 }
 
-func (g *Generator) writeWrapperFromPlutusData(name string, field *Schema, constrIndex int) {
-	g.writeLine(fmt.Sprintf("func (v *%s) FromPlutusData(pd PlutusData) error {", name))
-	g.indentInc()
-
-	g.writeLine("if pd.Constr == nil {")
-	g.indentInc()
-	g.writeLine(fmt.Sprintf(`return errors.New("expected constructor for %s")`, name))
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine(fmt.Sprintf("if pd.Constr.Index != %d {", constrIndex))
-	g.indentInc()
-	g.writeLine(fmt.Sprintf(`return fmt.Errorf("wrong constructor index for %s: expected %d, got %%d", pd.Constr.Index)`, name, constrIndex))
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("if len(pd.Constr.Fields) != 1 {")
-	g.indentInc()
-	g.writeLine(fmt.Sprintf(`return fmt.Errorf("wrong number of fields for %s: expected 1, got %%d", len(pd.Constr.Fields))`, name))
-	g.indentDec()
-	g.writeLine("}")
-
+// writeWrapperFromPlutusData writes the statements decoding the value of a
+// wrapper variant from pd.Constr.Fields[0].
+func (g *Generator) writeWrapperFromPlutusData(field ir.Type) {
 	switch {
-	case field.IsRef():
-		refName := field.RefName()
-		switch refName {
-		case "Int":
-			g.writeLine("if pd.Constr.Fields[0].Integer == nil {")
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return fmt.Errorf("%s: expected integer, got %%s", plutusDataTypeString(pd.Constr.Fields[0]))`, name))
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine("v.Value = pd.Constr.Fields[0].Integer")
-		case "ByteArray":
-			g.writeLine("if pd.Constr.Fields[0].ByteString == nil {")
-			g.indentInc()
-			g.writeLine(fmt.Sprintf(`return fmt.Errorf("%s: expected bytes, got %%s", plutusDataTypeString(pd.Constr.Fields[0]))`, name))
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine("v.Value = pd.Constr.Fields[0].ByteString")
-		case "Data":
-			// Data type is raw PlutusData - store directly
-			g.writeLine("v.Value = pd.Constr.Fields[0]")
-		default:
-			if g.isPrimitiveWrapper(refName, "bytes") {
-				g.writeLine("if pd.Constr.Fields[0].ByteString == nil {")
-				g.indentInc()
-				g.writeLine(fmt.Sprintf(`return fmt.Errorf("%s: expected bytes, got %%s", plutusDataTypeString(pd.Constr.Fields[0]))`, name))
-				g.indentDec()
-				g.writeLine("}")
-				g.writeLine("v.Value = pd.Constr.Fields[0].ByteString")
-			} else if g.isPrimitiveWrapper(refName, "integer") {
-				g.writeLine("if pd.Constr.Fields[0].Integer == nil {")
-				g.indentInc()
-				g.writeLine(fmt.Sprintf(`return fmt.Errorf("%s: expected integer, got %%s", plutusDataTypeString(pd.Constr.Fields[0]))`, name))
-				g.indentDec()
-				g.writeLine("}")
-				g.writeLine("v.Value = pd.Constr.Fields[0].Integer")
-			} else if defSchema, ok := g.bp.Definitions[g.unescapeRef(refName)]; ok && defSchema.IsEnum() && !defSchema.IsSingleConstructor() {
-				// Enum type - use factory function
-				typeName := g.normalizeTypeName(refName)
-				g.writeLine(fmt.Sprintf("innerVal, err := %sFromPlutusData(pd.Constr.Fields[0])", typeName))
-				g.writeLine("if err != nil {")
-				g.indentInc()
-				g.writeLine(fmt.Sprintf(`return fmt.Errorf("%s: %%w", err)`, name))
-				g.indentDec()
-				g.writeLine("}")
-				g.writeLine("v.Value = innerVal")
-			} else {
-				g.writeLine("if err := v.Value.FromPlutusData(pd.Constr.Fields[0]); err != nil {")
-				g.indentInc()
-				g.writeLine(fmt.Sprintf(`return fmt.Errorf("%s: %%w", err)`, name))
-				g.indentDec()
-				g.writeLine("}")
-			}
-		}
-	case field.IsInteger():
-		g.writeLine("if pd.Constr.Fields[0].Integer == nil {")
+	case g.isContainer(field):
+		g.writeLine(fmt.Sprintf("if err := %s; err != nil {", g.plutusDecodeCall(field, "pd.Constr.Fields[0]", "&v.Value")))
+		g.indentInc()
+		g.writeLine(`return decodeErrorAt(err, "Value")`)
+		g.indentDec()
+		g.writeLine("}")
+	case isInt(field):
+		g.writeLine("if pd.Constr.Fields[0].Kind() != KindInteger {")
 		g.indentInc()
-		g.writeLine(fmt.Sprintf(`return fmt.Errorf("%s: expected integer, got %%s", plutusDataTypeString(pd.Constr.Fields[0]))`, name))
+		g.writeLine(`return decodeErrorAt(decodeKindError("*big.Int", "integer", pd.Constr.Fields[0]), "Value")`)
 		g.indentDec()
 		g.writeLine("}")
 		g.writeLine("v.Value = pd.Constr.Fields[0].Integer")
-	case field.IsBytes():
-		g.writeLine("if pd.Constr.Fields[0].ByteString == nil {")
+	case isBytes(field):
+		g.writeLine("if pd.Constr.Fields[0].Kind() != KindBytes {")
 		g.indentInc()
-		g.writeLine(fmt.Sprintf(`return fmt.Errorf("%s: expected bytes, got %%s", plutusDataTypeString(pd.Constr.Fields[0]))`, name))
+		g.writeLine(`return decodeErrorAt(decodeKindError("[]byte", "bytes", pd.Constr.Fields[0]), "Value")`)
 		g.indentDec()
 		g.writeLine("}")
 		g.writeLine("v.Value = pd.Constr.Fields[0].ByteString")
+	case isData(field):
+		// Data type is raw PlutusData - store directly
+		g.writeLine("v.Value = pd.Constr.Fields[0]")
+	case g.isEnum(field):
+		// Enum type - use factory function
+		g.writeLine(fmt.Sprintf("innerVal, err := %sFromPlutusData(pd.Constr.Fields[0])", g.goType(field)))
+		g.writeLine("if err != nil {")
+		g.indentInc()
+		g.writeLine(`return decodeErrorAt(err, "Value")`)
+		g.indentDec()
+		g.writeLine("}")
+		g.writeLine("v.Value = innerVal")
 	default:
 		g.writeLine("if err := v.Value.FromPlutusData(pd.Constr.Fields[0]); err != nil {")
 		g.indentInc()
-		g.writeLine(fmt.Sprintf(`return fmt.Errorf("%s: %%w", err)`, name))
+		g.writeLine(`return decodeErrorAt(err, "Value")`)
 		g.indentDec()
 		g.writeLine("}")
 	}
-
-	g.writeLine("return nil")
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("")
 }
 
-func (g *Generator) writeWrapperEquals(name string, field *Schema) {
-	g.writeLine(fmt.Sprintf("func (v %s) Equals(other %s) bool {", name, name))
-	g.indentInc()
-
+// writeWrapperEquals writes the statements returning whether two wrapper
+// variants are equal.
+func (g *Generator) writeWrapperEquals(field ir.Type) {
 	switch {
-	case field.IsRef():
-		refName := field.RefName()
-		switch refName {
-		case "Int":
-			g.writeLine("if v.Value == nil && other.Value == nil {")
-			g.indentInc()
-			g.writeLine("return true")
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine("if v.Value == nil || other.Value == nil {")
-			g.indentInc()
-			g.writeLine("return false")
-			g.indentDec()
-			g.writeLine("}")
-			g.writeLine("return v.Value.Cmp(other.Value) == 0")
-		case "ByteArray":
-			g.writeLine("return bytes.Equal(v.Value, other.Value)")
-		case "Bool":
-			g.writeLine("return v.Value == other.Value")
-		case "Data":
-			g.writeLine("return v.Value.Equals(other.Value)")
-		default:
-			if g.isPrimitiveWrapper(refName, "bytes") {
-				g.writeLine("return bytes.Equal(v.Value, other.Value)")
-			} else if g.isPrimitiveWrapper(refName, "integer") {
-				g.writeLine("if v.Value == nil && other.Value == nil {")
-				g.indentInc()
-				g.writeLine("return true")
-				g.indentDec()
-				g.writeLine("}")
-				g.writeLine("if v.Value == nil || other.Value == nil {")
-				g.indentInc()
-				g.writeLine("return false")
-				g.indentDec()
-				g.writeLine("}")
-				g.writeLine("return v.Value.Cmp(other.Value) == 0")
-			} else if defSchema, ok := g.bp.Definitions[g.unescapeRef(refName)]; ok && defSchema.IsEnum() && !defSchema.IsSingleConstructor() {
-				typeName := g.normalizeTypeName(refName)
-				g.writeLine(fmt.Sprintf("return %sEquals(v.Value, other.Value)", typeName))
-			} else {
-				g.writeLine("return v.Value.Equals(other.Value)")
-			}
-		}
-	case field.IsInteger():
+	case g.isContainer(field):
+		g.writeLine("return " + g.plutusEqualsCall(field, "v.Value", "other.Value"))
+	case isInt(field):
 		g.writeLine("if v.Value == nil && other.Value == nil {")
 		g.indentInc()
 		g.writeLine("return true")
@@ -2805,135 +1276,75 @@ func (g *Generator) writeWrapperEquals(name string, field *Schema) {
 		g.indentDec()
 		g.writeLine("}")
 		g.writeLine("return v.Value.Cmp(other.Value) == 0")
-	case field.IsBytes():
+	case isBytes(field):
 		g.writeLine("return bytes.Equal(v.Value, other.Value)")
+	case field == ir.Bool:
+		g.writeLine("return v.Value == other.Value")
+	case g.isEnum(field):
+		g.writeLine(fmt.Sprintf("return %sEquals(v.Value, other.Value)", g.goType(field)))
 	default:
 		g.writeLine("return v.Value.Equals(other.Value)")
 	}
-
-	g.indentDec()
-	g.writeLine("}")
-	g.writeLine("")
 }
 
-// schemaToGoType converts a schema to a Go type string.
-func (g *Generator) schemaToGoType(schema *Schema) string {
-	switch {
-	case schema.IsRef():
-		return g.refToGoType(schema.RefName())
-	case schema.IsInteger():
-		return "*big.Int"
-	case schema.IsBytes():
-		return "[]byte"
-	case schema.IsList():
-		if len(schema.Items) > 0 {
-			if schema.Items.IsTuple() {
-				return "[]interface{}"
-			}
-			itemType := g.schemaToGoType(schema.Items.Single())
-			return "[]" + itemType
-		}
-		return "[]interface{}"
-	case schema.IsMap():
-		keyType := "string"
-		if schema.Keys != nil {
-			kt := g.schemaToGoType(schema.Keys)
-			// []byte can't be a map key in Go, keep as string
-			if kt != "[]byte" {
-				keyType = kt
-			}
+// goType returns the Go type of values of t.
+func (g *Generator) goType(t ir.Type) string {
+	if g.isGeneric(t) {
+		return g.genericGoType(t)
+	}
+	switch t := t.(type) {
+	case ir.Primitive:
+		switch t {
+		case ir.Int:
+			return "*big.Int"
+		case ir.ByteArray:
+			return "[]byte"
+		case ir.Bool:
+			return "bool"
+		default:
+			return "struct{}"
 		}
-		valueType := "interface{}"
-		if schema.Values != nil {
-			valueType = g.schemaToGoType(schema.Values)
+	case *ir.Alias:
+		// Aliases of primitives are the primitive itself
+		switch ir.Underlying(t) {
+		case ir.Int:
+			return "*big.Int"
+		case ir.ByteArray:
+			return "[]byte"
 		}
-		return fmt.Sprintf("map[%s]%s", keyType, valueType)
-	case schema.IsBoolean():
-		return "bool"
-	case schema.IsUnit():
-		return "struct{}"
-	case schema.IsOption():
-		// Option types use IsSet + Value struct, return the Option type name
-		inner := schema.OptionInnerType()
-		if inner != nil {
-			innerGoType := g.schemaToGoType(inner)
-			// Generate Option type name based on inner type
-			innerTypeName := strings.TrimPrefix(innerGoType, "*")
-			innerTypeName = strings.TrimPrefix(innerTypeName, "[]")
-			// Capitalize first letter
-			if len(innerTypeName) > 0 {
-				innerTypeName = strings.ToUpper(innerTypeName[:1]) + innerTypeName[1:]
-			}
-			return "Option" + innerTypeName
+		return g.normalizeTypeName(t.Name)
+	case *ir.List:
+		if g.isContainer(t) {
+			return "[]" + g.goType(t.Elem)
 		}
-		return "OptionInterface"
-	case schema.IsEnum():
-		return "interface{}"
-	case schema.IsOpaque() || schema.IsEmpty():
-		return "interface{}" // Any Plutus data
-	default:
-		return "interface{}"
-	}
-}
-
-func (g *Generator) refToGoType(refName string) string {
-	switch refName {
-	case "Int":
-		return "*big.Int"
-	case "ByteArray":
-		return "[]byte"
-	case "Bool":
-		return "bool"
-	case "Data":
-		return "PlutusData"
-	case "Void":
-		return "struct{}"
-	default:
-		if strings.HasPrefix(refName, "List$") {
-			// Extract inner type
-			inner := strings.TrimPrefix(refName, "List$")
-			inner = strings.ReplaceAll(inner, "~1", "/")
-			return "[]" + g.refToGoType(inner)
+	case *ir.Pairs:
+		if g.isContainer(t) {
+			return fmt.Sprintf("map[%s]%s", g.mapKeyGoType(t.Key), g.goType(t.Value))
 		}
-		if strings.HasPrefix(refName, "Option$") {
-			// Option types - return the Option type name (with IsSet + Value)
-			// Normalize the name to get the generated type name
-			return g.normalizeTypeName(refName)
+	case *ir.Option:
+		if t.Name == "" {
+			// An inline option is named after its inner type
+			inner := strings.TrimPrefix(g.goType(t.Elem), "*")
+			inner = strings.TrimPrefix(inner, "[]")
+			if len(inner) > 0 {
+				inner = strings.ToUpper(inner[:1]) + inner[1:]
+			}
+			return "Option" + inner
 		}
-		if strings.HasPrefix(refName, "Pairs$") {
-			return g.pairsToGoType(refName)
+	case *ir.Tuple:
+		if t.Name == "" {
+			return "[]PlutusData"
 		}
-
-		// Check if the referenced type is actually a primitive wrapper
-		unescaped := g.unescapeRef(refName)
-		if def, ok := g.bp.Definitions[unescaped]; ok {
-			if def.IsBytes() {
-				return "[]byte"
-			}
-			if def.IsInteger() {
-				return "*big.Int"
-			}
+	case *ir.Opaque:
+		if isData(t) {
+			return "PlutusData"
 		}
-
-		// All other types - normalize the name
-		return g.normalizeTypeName(refName)
 	}
-}
-
-func (g *Generator) pairsToGoType(refName string) string {
-	inner := strings.TrimPrefix(refName, "Pairs$")
-	inner = strings.ReplaceAll(inner, "~1", "/")
-	parts := strings.SplitN(inner, "_", 2)
-	if len(parts) != 2 {
-		return "map[string]interface{}"
+	if name := typeName(t); name != "" {
+		return g.normalizeTypeName(name)
 	}
-	keyType := g.refToGoType(parts[0])
-	// []byte can't be a map key in Go, keep as string
-	if keyType == "[]byte" {
-		keyType = "string"
-	}
-	valueType := g.refToGoType(parts[1])
-	return fmt.Sprintf("map[%s]%s", keyType, valueType)
+	// An anonymous record or enum left inline: any Plutus data
+	return "PlutusData"
 }
 
 // Helper functions
@@ -2977,6 +1388,16 @@ func (g *Generator) normalizeFieldName(name string, index int) string {
 	return g.toGoIdentifier(name)
 }
 
+// variantFieldName returns the Go name of a field of an enum variant, with an
+// underscore appended if it clashes with the Index and VariantName methods
+// every variant has.
+func variantFieldName(fieldName string) string {
+	if fieldName == "Index" || fieldName == "VariantName" {
+		return fieldName + "_"
+	}
+	return fieldName
+}
+
 func (g *Generator) toGoIdentifier(s string) string {
 	if s == "" {
 		return ""
@@ -3011,13 +1432,6 @@ func (g *Generator) snakeToCamel(s string) string {
 	return strings.Join(parts, "")
 }
 
-func (g *Generator) unescapeRef(ref string) string {
-	// Handle JSON Pointer escaping: ~1 = /, ~0 = ~
-	ref = strings.ReplaceAll(ref, "~1", "/")
-	ref = strings.ReplaceAll(ref, "~0", "~")
-	return ref
-}
-
 func (g *Generator) isStandardTypeName(name string) bool {
 	// Only skip truly primitive types that don't have definitions
 	switch name {
@@ -3028,20 +1442,137 @@ func (g *Generator) isStandardTypeName(name string) bool {
 	}
 }
 
-// isPrimitiveWrapper checks if the referenced type is a primitive wrapper (e.g., PolicyId -> bytes)
-func (g *Generator) isPrimitiveWrapper(refName string, primitiveType string) bool {
-	unescaped := g.unescapeRef(refName)
-	if def, ok := g.bp.Definitions[unescaped]; ok {
-		switch primitiveType {
-		case "bytes":
-			return def.IsBytes()
-		case "integer":
-			return def.IsInteger()
-		}
+// writeNilCheck writes the check ToPlutusData makes on expr, a *big.Int
+// that would otherwise encode as 0 or an enum that can't be encoded at all.
+// The path elements of expr are Go expressions, such as a quoted field name
+// or pathItem(i).
+func (g *Generator) writeNilCheck(expr, expected string, path ...string) {
+	g.writeLine(fmt.Sprintf("if %s == nil {", expr))
+	g.indentInc()
+	g.writeLine(fmt.Sprintf(`return PlutusData{}, validationErrorAt(%s)`, strings.Join(append([]string{fmt.Sprintf("nilValueError(%q)", expected)}, path...), ", ")))
+	g.indentDec()
+	g.writeLine("}")
+}
+
+// optionNilIntCheck is the writeNilCheck of the Value of a set option.
+const optionNilIntCheck = "\tif v.Value == nil {\n\t\treturn PlutusData{}, validationErrorAt(nilValueError(\"*big.Int\"), \"Some\")\n\t}\n"
+
+// fieldValidate returns the statements of Validate returning an error, at
+// the path of the field, if the field can't be encoded or is unset.
+func (g *Generator) fieldValidate(fieldName string, t ir.Type) string {
+	call := g.plutusValidateCall(t, "v."+fieldName)
+	if call == "" {
+		return ""
+	}
+	return g.capture(1, func() {
+		g.writeLine(fmt.Sprintf("if err := %s; err != nil {", call))
+		g.indentInc()
+		g.writeLine(fmt.Sprintf(`return validationErrorAt(err, "%s")`, fieldName))
+		g.indentDec()
+		g.writeLine("}")
+	})
+}
+
+// constructorParam returns the NewX parameter of a field: its name starting
+// in lower case, with an underscore appended if that is a keyword or clashes
+// with an identifier NewX uses.
+func constructorParam(fieldName string) string {
+	runes := []rune(fieldName)
+	runes[0] = unicode.ToLower(runes[0])
+	param := string(runes)
+	if token.IsKeyword(param) || param == "v" || param == "nil" {
+		param += "_"
+	}
+	return param
+}
+
+// isInt reports whether values of t are integers, held as *big.Int: Int
+// or an alias of it, e.g. a POSIXTime.
+func isInt(t ir.Type) bool {
+	return ir.Underlying(t) == ir.Int
+}
+
+// isBytes reports whether values of t are byte strings, held as []byte:
+// ByteArray or an alias of it, e.g. a PolicyId.
+func isBytes(t ir.Type) bool {
+	return ir.Underlying(t) == ir.ByteArray
+}
+
+// isData reports whether t is opaque Plutus data, held as PlutusData.
+func isData(t ir.Type) bool {
+	opaque, ok := t.(*ir.Opaque)
+	return ok && (opaque.Name == "" || opaque.Name == "Data")
+}
+
+// typeName returns the definition name of t, or "" for a primitive or an
+// inline type.
+func typeName(t ir.Type) string {
+	switch t := t.(type) {
+	case *ir.Opaque:
+		return t.Name
+	case *ir.Record:
+		return t.Name
+	case *ir.Enum:
+		return t.Name
+	case *ir.List:
+		return t.Name
+	case *ir.Option:
+		return t.Name
+	case *ir.Pairs:
+		return t.Name
+	case *ir.Tuple:
+		return t.Name
+	case *ir.Alias:
+		return t.Name
+	}
+	return ""
+}
+
+// isNamedBoolOrUnit reports whether t is an alias of Bool or Void, which
+// unlike other primitive aliases is generated as a type of its own.
+func isNamedBoolOrUnit(t ir.Type) bool {
+	if _, ok := t.(*ir.Alias); !ok {
+		return false
+	}
+	switch ir.Underlying(t) {
+	case ir.Bool, ir.Void:
+		return true
 	}
 	return false
 }
 
+// isOption reports whether t is an option generated as a type of its own,
+// with IsSet and Value fields.
+func (g *Generator) isOption(t ir.Type) bool {
+	_, ok := t.(*ir.Option)
+	return ok && !g.isGeneric(t)
+}
+
+// isEnum reports whether t is a multi-constructor enum, which is generated
+// as an interface with a XxxFromPlutusData factory.
+func (g *Generator) isEnum(t ir.Type) bool {
+	_, ok := t.(*ir.Enum)
+	return ok && !g.isGeneric(t) && !g.isConstEnum(t)
+}
+
+// isConstEnum reports whether t is an enum generated as constants (GeneratorOptions.ConstEnums), which has the methods of a
+// record.
+func (g *Generator) isConstEnum(t ir.Type) bool {
+	if !g.opts.ConstEnums || g.opts.UnknownVariants || g.isGeneric(t) {
+		return false
+	}
+	enum, ok := t.(*ir.Enum)
+	if !ok {
+		return false
+	}
+	for _, variant := range enum.Variants {
+		if len(variant.Fields) > 0 || variant.Index > math.MaxUint8 {
+			return false
+		}
+	}
+	return true
+}
+
 // Output helpers
 
 func (g *Generator) writeLine(s string) {
@@ -3061,7 +1592,3 @@ func (g *Generator) indentDec() {
 		g.indent--
 	}
 }
-
-func (g *Generator) executeTemplate(name string, data interface{}) error {
-	return templates.ExecuteTemplate(&g.buf, name, data)
-}
@@ -1,6 +1,8 @@
 package blueprint
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -217,7 +219,7 @@ func TestGenerateSimple(t *testing.T) {
 	}
 
 	gen := NewGenerator(bp, GeneratorOptions{PackageName: "contracts"})
-	code, err := gen.Generate()
+	code, _, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("failed to generate code: %v", err)
 	}
@@ -227,7 +229,7 @@ func TestGenerateSimple(t *testing.T) {
 		"package contracts",
 		"import (",
 		`"math/big"`,
-		`"github.com/fxamacker/cbor/v2"`,
+		`"sort"`,
 		"type PlutusData struct",
 	}
 
@@ -238,6 +240,31 @@ func TestGenerateSimple(t *testing.T) {
 	}
 }
 
+func TestGenerateHeaderRecordsSource(t *testing.T) {
+	path := "../../testdata/simple/plutus.json"
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read blueprint: %v", err)
+	}
+	sum := sha256.Sum256(data)
+
+	bp, err := LoadBlueprint(path)
+	if err != nil {
+		t.Fatalf("failed to load blueprint: %v", err)
+	}
+	code, _, err := NewGenerator(bp, GeneratorOptions{PackageName: "contracts"}).Generate()
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+
+	want := "// Code generated by aiken2go. DO NOT EDIT.\n" +
+		"// Source: blueprint/test\n" +
+		"// Blueprint: ../../testdata/simple/plutus.json (sha256 " + hex.EncodeToString(sum[:]) + ")\n"
+	if !strings.HasPrefix(code, want) {
+		t.Errorf("unexpected header:\n%s\nwant\n%s", code[:len(want)], want)
+	}
+}
+
 func TestGenerateComplex(t *testing.T) {
 	bp, err := LoadBlueprint("../../testdata/complex/plutus.json")
 	if err != nil {
@@ -245,7 +272,7 @@ func TestGenerateComplex(t *testing.T) {
 	}
 
 	gen := NewGenerator(bp, GeneratorOptions{PackageName: "treasury"})
-	code, err := gen.Generate()
+	code, _, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("failed to generate code: %v", err)
 	}
@@ -290,7 +317,7 @@ func TestGenerateTuple(t *testing.T) {
 	}
 
 	gen := NewGenerator(bp, GeneratorOptions{PackageName: "tuples"})
-	code, err := gen.Generate()
+	code, _, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("failed to generate code: %v", err)
 	}
@@ -327,7 +354,7 @@ func TestGeneratedCodeCompiles(t *testing.T) {
 	}
 
 	gen := NewGenerator(bp, GeneratorOptions{PackageName: "contracts"})
-	code, err := gen.Generate()
+	code, _, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("failed to generate code: %v", err)
 	}
@@ -338,14 +365,10 @@ func TestGeneratedCodeCompiles(t *testing.T) {
 		t.Fatalf("failed to write generated code: %v", err)
 	}
 
-	// Create go.mod with cbor dependency
+	// Create go.mod
 	goMod := `module testmod
 
 go 1.21
-
-require github.com/fxamacker/cbor/v2 v2.8.0
-
-require github.com/x448/float16 v0.8.4 // indirect
 `
 	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
 		t.Fatalf("failed to write go.mod: %v", err)
@@ -0,0 +1,351 @@
+package blueprint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pgrange/aiken_to_go/pkg/ir"
+)
+
+// genericFamily is a user-defined parametric Aiken type (e.g. Wrapper<a>)
+// whose instantiations are emitted as a single Go generic type.
+//
+// Aiken monomorphises generics, so the blueprint only contains the
+// instantiations (types/Wrapper$Int, types/Wrapper$ByteArray, ...). The type
+// parameters are inferred from the fields that differ between them.
+type genericFamily struct {
+	goName string
+	record *ir.Record // the first instantiation
+	params []string
+	slots  map[int]int // field index -> type parameter index
+}
+
+// genericInstance is one instantiation of a genericFamily.
+type genericInstance struct {
+	family *genericFamily
+	args   []ir.Type // type of each type parameter in this instantiation
+}
+
+// collectGenericFamilies groups user-defined instantiations by their base
+// name. Only record types (single constructor) with at least two
+// structurally compatible instantiations become families; anything else keeps
+// one Go type per instantiation.
+func (g *Generator) collectGenericFamilies() {
+	g.families = make(map[string]*genericFamily)
+	g.instances = make(map[string]*genericInstance)
+
+	groups := make(map[string][]string)
+	for name := range g.bp.Definitions {
+		idx := strings.Index(name, "$")
+		if idx <= 0 {
+			continue
+		}
+		base := name[:idx]
+		switch base {
+		case "Option", "List", "Pairs", "Tuple":
+			continue
+		}
+		groups[base] = append(groups[base], name)
+	}
+
+	for base, names := range groups {
+		if len(names) < 2 {
+			continue
+		}
+		sort.Strings(names)
+		g.inferGenericFamily(base, names)
+	}
+}
+
+func (g *Generator) inferGenericFamily(base string, names []string) {
+	records := make([]*ir.Record, len(names))
+	for i, name := range names {
+		record, ok := g.definitionType(name).(*ir.Record)
+		if !ok {
+			return
+		}
+		records[i] = record
+	}
+
+	first := records[0]
+	for _, r := range records[1:] {
+		if len(r.Fields) != len(first.Fields) || r.Title != first.Title {
+			return
+		}
+		for i := range r.Fields {
+			if r.Fields[i].Title != first.Fields[i].Title {
+				return
+			}
+		}
+	}
+
+	// keys[f][n] is the type of field f in instantiation n
+	keys := make([][]string, len(first.Fields))
+	for f := range first.Fields {
+		keys[f] = make([]string, len(records))
+		for n, r := range records {
+			keys[f][n] = typeKey(r.Fields[f].Type)
+		}
+	}
+
+	fam := &genericFamily{
+		goName: g.normalizeTypeName(base),
+		record: first,
+		slots:  make(map[int]int),
+	}
+	var paramSlots []int // first field of each type parameter
+	for f := range first.Fields {
+		if allEqual(keys[f]) {
+			continue
+		}
+		param := -1
+		for p, slot := range paramSlots {
+			if equalStrings(keys[slot], keys[f]) {
+				param = p
+				break
+			}
+		}
+		if param < 0 {
+			param = len(paramSlots)
+			paramSlots = append(paramSlots, f)
+			fam.params = append(fam.params, genericParamName(param))
+		}
+		fam.slots[f] = param
+	}
+	if len(fam.params) == 0 {
+		return
+	}
+
+	g.families[base] = fam
+	for n, name := range names {
+		inst := &genericInstance{family: fam}
+		for _, slot := range paramSlots {
+			inst.args = append(inst.args, records[n].Fields[slot].Type)
+		}
+		g.instances[name] = inst
+	}
+}
+
+// typeKey returns a comparable representation of a type: the name of a
+// definition, or the structure of an inline type.
+func typeKey(t ir.Type) string {
+	switch t := t.(type) {
+	case ir.Primitive:
+		return t.String()
+	case *ir.List:
+		if t.Name == "" {
+			return "List<" + typeKey(t.Elem) + ">"
+		}
+	case *ir.Option:
+		if t.Name == "" {
+			return "Option<" + typeKey(t.Elem) + ">"
+		}
+	case *ir.Pairs:
+		if t.Name == "" {
+			return "Pairs<" + typeKey(t.Key) + ", " + typeKey(t.Value) + ">"
+		}
+	case *ir.Tuple:
+		if t.Name == "" {
+			items := make([]string, len(t.Items))
+			for i, item := range t.Items {
+				items[i] = typeKey(item)
+			}
+			return "Tuple<" + strings.Join(items, ", ") + ">"
+		}
+	}
+	if name := typeName(t); name != "" {
+		return name
+	}
+	return "Data"
+}
+
+func allEqual(values []string) bool {
+	for _, v := range values[1:] {
+		if v != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func genericParamName(i int) string {
+	if i < 26 {
+		return string(rune('A' + i))
+	}
+	return fmt.Sprintf("T%d", i)
+}
+
+// isGenericRef reports whether the definition name is emitted as an
+// instantiation of a Go generic type.
+func (g *Generator) isGenericRef(name string) bool {
+	if !g.opts.Generics {
+		return false
+	}
+	name = unescapeRef(name)
+	if strings.HasPrefix(name, "Option$") || strings.HasPrefix(name, "List$") || strings.HasPrefix(name, "Pairs$") {
+		return true
+	}
+	_, ok := g.instances[name]
+	return ok
+}
+
+// isGeneric reports whether t is emitted as an instantiation of a Go
+// generic type.
+func (g *Generator) isGeneric(t ir.Type) bool {
+	return g.isGenericRef(typeName(t))
+}
+
+// genericGoType returns the Go generic instantiation of t, e.g. Option[Int]
+// or Pairs[ByteArray, Int].
+func (g *Generator) genericGoType(t ir.Type) string {
+	switch t := t.(type) {
+	case *ir.Option:
+		return fmt.Sprintf("Option[%s]", g.genericArgType(t.Elem))
+	case *ir.List:
+		return fmt.Sprintf("List[%s]", g.genericArgType(t.Elem))
+	case *ir.Pairs:
+		return fmt.Sprintf("Pairs[%s, %s]", g.genericArgType(t.Key), g.genericArgType(t.Value))
+	}
+
+	inst := g.instances[typeName(t)]
+	args := make([]string, len(inst.args))
+	for i, arg := range inst.args {
+		args[i] = g.genericArgType(arg)
+	}
+	return fmt.Sprintf("%s[%s]", inst.family.goName, strings.Join(args, ", "))
+}
+
+// genericArgType returns the Go type used for t when it appears as a type
+// argument. Primitives map to the Int, ByteArray and Bool codec types;
+// anything without a dedicated codec is kept as raw PlutusData.
+func (g *Generator) genericArgType(t ir.Type) string {
+	switch {
+	case isInt(t):
+		return "Int"
+	case isBytes(t):
+		return "ByteArray"
+	case t == ir.Bool:
+		return "Bool"
+	}
+	switch t := t.(type) {
+	case *ir.List:
+		if t.Name == "" {
+			return fmt.Sprintf("List[%s]", g.genericArgType(t.Elem))
+		}
+	case *ir.Pairs:
+		if t.Name == "" {
+			return fmt.Sprintf("Pairs[%s, %s]", g.genericArgType(t.Key), g.genericArgType(t.Value))
+		}
+	}
+	if typeName(t) == "" {
+		return "PlutusData"
+	}
+	return g.goType(t)
+}
+
+// writeGenericFamily emits the generic record type shared by all
+// instantiations of a family.
+func (g *Generator) writeGenericFamily(fam *genericFamily) error {
+	if g.generated[fam.goName] {
+		return nil
+	}
+	g.generated[fam.goName] = true
+
+	record := fam.record
+	typeParams := make([]string, len(fam.params))
+	for i, p := range fam.params {
+		typeParams[i] = p + " PlutusCodec"
+	}
+	recv := fmt.Sprintf("%s[%s]", fam.goName, strings.Join(fam.params, ", "))
+
+	data := &StructData{
+		TypeData:    TypeData{Name: fam.goName, Receiver: recv, Kind: "generic"},
+		TypeParams:  strings.Join(typeParams, ", "),
+		Title:       record.Title,
+		ConstrIndex: record.Index,
+		Streamable:  true,
+		Appendable:  true,
+	}
+	for i, field := range record.Fields {
+		fieldName := g.normalizeFieldName(field.Title, i)
+		p, isSlot := fam.slots[i]
+		if !isSlot {
+			dec, ok := g.cborDecoder(field.Type)
+			data.Streamable = data.Streamable && ok
+			enc, ok := g.cborEncoder(field.Type)
+			data.Appendable = data.Appendable && ok
+			data.Fields = append(data.Fields, &FieldData{
+				Name:           fieldName,
+				Title:          field.Title,
+				GoType:         g.goType(field.Type),
+				Index:          i,
+				ToPlutusData:   g.capture(1, func() { g.writeFieldToPlutusData(fieldName, field.Type, i) }),
+				FromPlutusData: g.capture(1, func() { g.writeFieldFromPlutusData(fieldName, field.Type, i) }),
+				Equals:         g.capture(1, func() { g.writeFieldEquals(fieldName, field.Type) }),
+				Validate:       g.fieldValidate(fieldName, field.Type),
+				Param:          constructorParam(fieldName),
+				CBORDecoder:    dec,
+				CBOREncoder:    enc,
+			})
+			continue
+		}
+		// Fields of a type parameter go through the PlutusCodec interface
+		data.Fields = append(data.Fields, &FieldData{
+			Name:   fieldName,
+			Title:  field.Title,
+			GoType: fam.params[p],
+			Index:  i,
+			ToPlutusData: g.capture(1, func() {
+				g.writeLine(fmt.Sprintf("field%d, err := encodePlutusCodec(v.%s)", i, fieldName))
+				g.writeLine("if err != nil {")
+				g.indentInc()
+				g.writeLine(fmt.Sprintf(`return PlutusData{}, validationErrorAt(err, "%s")`, fieldName))
+				g.indentDec()
+				g.writeLine("}")
+				g.writeLine(fmt.Sprintf("fields[%d] = field%d", i, i))
+			}),
+			FromPlutusData: g.capture(1, func() {
+				g.writeLine(fmt.Sprintf("if err := decodePlutusInto(&v.%s, pd.Constr.Fields[%d]); err != nil {", fieldName, i))
+				g.indentInc()
+				g.writeLine(fmt.Sprintf(`return decodeErrorAt(err, "%s")`, fieldName))
+				g.indentDec()
+				g.writeLine("}")
+			}),
+			Equals: g.capture(1, func() {
+				g.writeLine(fmt.Sprintf("if !plutusCodecEquals(v.%s, other.%s) {", fieldName, fieldName))
+				g.indentInc()
+				g.writeLine("return false")
+				g.indentDec()
+				g.writeLine("}")
+			}),
+			Validate: g.capture(1, func() {
+				g.writeLine(fmt.Sprintf("if err := validatePlutusCodec(v.%s); err != nil {", fieldName))
+				g.indentInc()
+				g.writeLine(fmt.Sprintf(`return validationErrorAt(err, "%s")`, fieldName))
+				g.indentDec()
+				g.writeLine("}")
+			}),
+			Param:       constructorParam(fieldName),
+			CBORDecoder: fmt.Sprintf("readCBORAny[%s]", fam.params[p]),
+			CBOREncoder: fmt.Sprintf("appendCBORCodec[%s]", fam.params[p]),
+		})
+	}
+
+	if err := g.writeTemplate("generic_type.go.tmpl", data); err != nil {
+		return err
+	}
+	return g.writeExtras(data.TypeData)
+}
@@ -0,0 +1,239 @@
+package blueprint
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerics tests the opt-in generic code generation mode:
+// - Option$X, List$X and Pairs$K_V become Option[X], List[X] and Pairs[K, V]
+// - User-defined types instantiated several times become one generic type
+func TestGenerics(t *testing.T) {
+	bp, err := LoadBlueprint("../../testdata/generics/plutus.json")
+	if err != nil {
+		t.Fatalf("failed to load blueprint: %v", err)
+	}
+
+	gen := NewGenerator(bp, GeneratorOptions{PackageName: "generics", Generics: true})
+	code, _, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+
+	checks := []string{
+		"type PlutusCodec interface",
+		"type Option[T PlutusCodec] struct",
+		"type List[T PlutusCodec] []T",
+		"type Pairs[K, V PlutusCodec] []Pair[K, V]",
+		"Owner Option[ByteArray]",
+		"Limit Option[Int]",
+		"NextAction Option[TypesAction]",
+		"History List[TypesAction]",
+		"Limits List[Option[Int]]",
+		"Value Pairs[ByteArray, Pairs[ByteArray, Int]]",
+		"type TypesWrapper[A PlutusCodec] struct",
+		"Counter TypesWrapper[Int]",
+		"LastAction TypesWrapper[TypesAction]",
+		"RegisterEnum(TypesActionFromPlutusData)",
+	}
+	for _, check := range checks {
+		if !strings.Contains(code, check) {
+			t.Errorf("generated code missing expected element: %q", check)
+		}
+	}
+
+	// One generic implementation replaces the per-instantiation types
+	notExpected := []string{
+		"type OptionInt struct",
+		"type OptionTypesAction struct",
+		"type TypesWrapperInt struct",
+		"type TypesWrapperByteArray struct",
+	}
+	for _, check := range notExpected {
+		if strings.Contains(code, check) {
+			t.Errorf("generated code should not contain %q", check)
+		}
+	}
+}
+
+func TestGenericsDisabledByDefault(t *testing.T) {
+	bp, err := LoadBlueprint("../../testdata/generics/plutus.json")
+	if err != nil {
+		t.Fatalf("failed to load blueprint: %v", err)
+	}
+
+	gen := NewGenerator(bp, GeneratorOptions{PackageName: "generics"})
+	code, _, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+
+	if strings.Contains(code, "PlutusCodec") {
+		t.Error("generic runtime should only be emitted with Generics enabled")
+	}
+	if !strings.Contains(code, "type TypesWrapperInt struct") {
+		t.Error("expected one type per instantiation without Generics")
+	}
+}
+
+// TestGenericsRoundTrip tests serialization and deserialization through the
+// generic container types.
+func TestGenericsRoundTrip(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go compiler not found, skipping round-trip test")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "generics_roundtrip")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	typesDir := filepath.Join(tmpDir, "types")
+	if err := os.MkdirAll(typesDir, 0755); err != nil {
+		t.Fatalf("failed to create types dir: %v", err)
+	}
+
+	bp, err := LoadBlueprint("../../testdata/generics/plutus.json")
+	if err != nil {
+		t.Fatalf("failed to load blueprint: %v", err)
+	}
+
+	gen := NewGenerator(bp, GeneratorOptions{PackageName: "types", Generics: true})
+	code, _, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(typesDir, "types.go"), []byte(code), 0644); err != nil {
+		t.Fatalf("failed to write types file: %v", err)
+	}
+
+	testProgram := `package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"os"
+
+	"testpkg/types"
+)
+
+func main() {
+	datum := types.TypesDatum{
+		Owner:      types.Some(types.ByteArray("owner")),
+		Limit:      types.None[types.Int](),
+		NextAction: types.Some[types.TypesAction](types.TypesActionMint{Amount: big.NewInt(7)}),
+		Signers:    types.List[types.ByteArray]{types.ByteArray("a"), types.ByteArray("b")},
+		History:    types.List[types.TypesAction]{types.TypesActionBurn{}, types.TypesActionMint{Amount: big.NewInt(1)}},
+		Limits:     types.List[types.Option[types.Int]]{types.Some(types.Int{Int: big.NewInt(3)}), types.None[types.Int]()},
+		Value: types.Pairs[types.ByteArray, types.Pairs[types.ByteArray, types.Int]]{
+			{Key: types.ByteArray{0xab}, Value: types.Pairs[types.ByteArray, types.Int]{
+				{Key: types.ByteArray("token"), Value: types.Int{Int: big.NewInt(100)}},
+			}},
+		},
+		Counter:    types.TypesWrapper[types.Int]{Inner: types.Int{Int: big.NewInt(42)}, Version: big.NewInt(1)},
+		Label:      types.TypesWrapper[types.ByteArray]{Inner: types.ByteArray("label"), Version: big.NewInt(2)},
+		LastAction: types.TypesWrapper[types.TypesAction]{Inner: types.TypesActionBurn{}, Version: big.NewInt(3)},
+	}
+
+	pd, err := datum.ToPlutusData()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ToPlutusData:", err)
+		os.Exit(1)
+	}
+	cborBytes, err := pd.MarshalCBOR()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "MarshalCBOR:", err)
+		os.Exit(1)
+	}
+
+	var decodedPd types.PlutusData
+	if err := decodedPd.UnmarshalCBOR(cborBytes); err != nil {
+		fmt.Fprintln(os.Stderr, "UnmarshalCBOR:", err)
+		os.Exit(1)
+	}
+	var decoded types.TypesDatum
+	if err := decoded.FromPlutusData(decodedPd); err != nil {
+		fmt.Fprintln(os.Stderr, "FromPlutusData:", err)
+		os.Exit(1)
+	}
+	if !decoded.Equals(datum) {
+		fmt.Fprintln(os.Stderr, "decoded datum differs from original")
+		os.Exit(1)
+	}
+	if _, ok := decoded.NextAction.Value.(types.TypesActionMint); !ok {
+		fmt.Fprintf(os.Stderr, "expected TypesActionMint, got %T\n", decoded.NextAction.Value)
+		os.Exit(1)
+	}
+
+	// A Wrapper<Int> must keep the wire format of a plain record
+	counterPd, _ := datum.Counter.ToPlutusData()
+	counterHex, _ := counterPd.ToHex()
+	if counterHex != "d8799f182a01ff" {
+		fmt.Fprintf(os.Stderr, "unexpected Wrapper<Int> encoding: %s\n", counterHex)
+		os.Exit(1)
+	}
+
+	// The direct encoders and streaming decoders agree with PlutusData
+	direct, err := datum.MarshalCBOR()
+	if err != nil || !bytes.Equal(direct, cborBytes) {
+		fmt.Fprintf(os.Stderr, "MarshalCBOR: got %x (%v), want %x\n", direct, err, cborBytes)
+		os.Exit(1)
+	}
+	var streamed types.TypesDatum
+	if err := streamed.UnmarshalCBOR(direct); err != nil || !streamed.Equals(datum) {
+		fmt.Fprintf(os.Stderr, "UnmarshalCBOR: %+v %v\n", streamed, err)
+		os.Exit(1)
+	}
+	counterCBOR, err := datum.Counter.MarshalCBOR()
+	if err != nil || fmt.Sprintf("%x", counterCBOR) != counterHex {
+		fmt.Fprintf(os.Stderr, "Wrapper<Int> MarshalCBOR: got %x (%v)\n", counterCBOR, err)
+		os.Exit(1)
+	}
+	if _, err := datum.Value.MarshalCBOR(); err != nil {
+		fmt.Fprintln(os.Stderr, "Pairs MarshalCBOR:", err)
+		os.Exit(1)
+	}
+	datum.Limits[0] = types.Some(types.Int{})
+	if _, err := datum.MarshalCBOR(); err == nil || err.Error() != "Limits[0].Some: value is nil (expected *big.Int)" {
+		fmt.Fprintf(os.Stderr, "MarshalCBOR: got error %v\n", err)
+		os.Exit(1)
+	}
+
+	hex, _ := pd.ToHex()
+	fmt.Printf("✓ Datum: %s\n", hex)
+}
+`
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(testProgram), 0644); err != nil {
+		t.Fatalf("failed to write main file: %v", err)
+	}
+
+	goModContent := `module testpkg
+
+go 1.21
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	cmd := exec.Command("go", "mod", "tidy")
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go mod tidy failed: %v\n%s", err, output)
+	}
+
+	cmd = exec.Command("go", "run", "main.go")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("test program failed: %v\n%s\n\nGenerated code:\n%s", err, output, code)
+	}
+
+	t.Logf("Test output:\n%s", output)
+}
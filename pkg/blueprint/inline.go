@@ -0,0 +1,261 @@
+package blueprint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pgrange/aiken_to_go/pkg/ir"
+)
+
+// hoistInlineSchemas returns a copy of bp in which the anonymous schemas
+// nested in definitions are definitions of their own, so that every field
+// has a named Go type:
+//
+//   - inline tuples become a tuple definition named after their position,
+//     e.g. the unnamed third field of Payout becomes Payout/Field2/Tuple
+//     (PayoutField2Tuple);
+//   - inline anyOf and constructors become an enum or struct definition;
+//   - inline options become the matching Option$ definition;
+//   - inline maps nested in a list, a map or an option become a map
+//     definition (maps directly in a field stay Go maps);
+//   - inline Bool and opaque Data schemas become references to Bool and Data.
+//
+// bp itself is left unchanged.
+func hoistInlineSchemas(bp *Blueprint) *Blueprint {
+	h := &inlineHoister{
+		defs:    make(map[string]*Schema, len(bp.Definitions)),
+		goNames: make(map[string]bool, len(bp.Definitions)),
+	}
+	names := make([]string, 0, len(bp.Definitions))
+	for name, schema := range bp.Definitions {
+		h.defs[name] = cloneSchema(schema)
+		h.goNames[(&Generator{}).normalizeTypeName(name)] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		h.walkDef([]string{name}, h.defs[name])
+	}
+
+	out := *bp
+	out.Definitions = h.defs
+	return &out
+}
+
+type inlineHoister struct {
+	defs    map[string]*Schema
+	goNames map[string]bool // normalized names of defs, to avoid collisions
+}
+
+// walkDef hoists the inline schemas nested in a definition. ctx is the
+// path naming the definition, extended for each nested position.
+func (h *inlineHoister) walkDef(ctx []string, schema *Schema) {
+	g := &Generator{}
+	switch {
+	case len(schema.AnyOf) > 0:
+		for i := range schema.AnyOf {
+			variant := &schema.AnyOf[i]
+			variantCtx := ctx
+			if len(schema.AnyOf) > 1 {
+				variantCtx = extendCtx(ctx, g.toGoIdentifier(variant.Title))
+			}
+			h.walkFields(variantCtx, variant.Fields)
+		}
+	case schema.IsConstructor():
+		h.walkFields(ctx, schema.Fields)
+	case schema.IsList() && len(schema.Items) > 1:
+		for i, item := range schema.Items {
+			schema.Items[i] = h.hoist(extendCtx(ctx, g.normalizeFieldName("", i)), item, true)
+		}
+	case schema.IsList() && len(schema.Items) == 1:
+		schema.Items[0] = h.hoist(extendCtx(ctx, "Item"), schema.Items[0], true)
+	case schema.IsMap():
+		h.walkMap(ctx, schema)
+	}
+}
+
+func (h *inlineHoister) walkFields(ctx []string, fields []Schema) {
+	g := &Generator{}
+	for j := range fields {
+		field := fields[j]
+		fields[j] = *h.hoist(extendCtx(ctx, g.normalizeFieldName(field.Title, j)), &field, false)
+	}
+}
+
+func (h *inlineHoister) walkMap(ctx []string, schema *Schema) {
+	if schema.Keys != nil {
+		schema.Keys = h.hoist(extendCtx(ctx, "Key"), schema.Keys, true)
+	}
+	if schema.Values == nil {
+		schema.Values = &Schema{Ref: "#/definitions/Data"}
+	} else {
+		schema.Values = h.hoist(extendCtx(ctx, "Value"), schema.Values, true)
+	}
+}
+
+// hoist returns the schema to use at position ctx in place of schema: a
+// reference to a new definition if schema is anonymous. nested is false
+// for the fields of a constructor, where an inline map or list is kept.
+func (h *inlineHoister) hoist(ctx []string, schema *Schema, nested bool) *Schema {
+	switch {
+	case schema.IsRef(), schema.IsInteger(), schema.IsBytes():
+		return schema
+	case schema.IsBoolean():
+		return h.reference("Bool", schema)
+	case schema.IsOpaque(), schema.IsEmpty():
+		return h.reference("Data", schema)
+	case schema.IsOption():
+		return h.hoistOption(ctx, schema)
+	case schema.IsList() && len(schema.Items) > 1:
+		return h.define(extendCtx(ctx, "Tuple"), schema)
+	case schema.IsList():
+		if len(schema.Items) == 0 {
+			schema.Items = SchemaItems{{Ref: "#/definitions/Data"}}
+		} else {
+			schema.Items[0] = h.hoist(extendCtx(ctx, "Item"), schema.Items[0], true)
+		}
+		return schema
+	case schema.IsMap():
+		if nested {
+			return h.define(extendCtx(ctx, "Map"), schema)
+		}
+		h.walkMap(ctx, schema)
+		return schema
+	case len(schema.AnyOf) > 0, schema.IsConstructor():
+		return h.define(ctx, schema)
+	default:
+		return h.reference("Data", schema)
+	}
+}
+
+// hoistOption replaces an inline option with a reference to Option$X, X
+// being the (hoisted) inner type, adding the definition if needed.
+func (h *inlineHoister) hoistOption(ctx []string, schema *Schema) *Schema {
+	some := &schema.AnyOf[0]
+	inner := &Schema{Ref: "#/definitions/Data"}
+	if len(some.Fields) > 0 {
+		field := some.Fields[0]
+		inner = h.hoist(extendCtx(ctx, "Some"), &field, true)
+		some.Fields[0] = *inner
+	}
+
+	var innerName string
+	switch {
+	case inner.IsRef():
+		innerName = inner.RefName()
+	case inner.IsInteger():
+		innerName = "Int"
+	case inner.IsBytes():
+		innerName = "ByteArray"
+	default:
+		// An inline list; name the option after its position instead
+		return h.define(extendCtx(ctx, "Option"), schema)
+	}
+
+	name := "Option$" + innerName
+	if existing, ok := h.defs[name]; ok {
+		if sameSchema(existing, schema) {
+			return h.reference(name, schema)
+		}
+		return h.define(extendCtx(ctx, "Option"), schema)
+	}
+	h.defs[name] = schema
+	h.goNames[(&Generator{}).normalizeTypeName(name)] = true
+	return h.reference(name, schema)
+}
+
+// define adds schema as a definition named after ctx, hoisting what it
+// nests in turn, and returns a reference to it.
+func (h *inlineHoister) define(ctx []string, schema *Schema) *Schema {
+	g := &Generator{}
+	name := strings.Join(ctx, "/")
+	for i := 2; h.goNames[g.normalizeTypeName(name)]; i++ {
+		name = fmt.Sprintf("%s%d", strings.Join(ctx, "/"), i)
+	}
+	h.defs[name] = schema
+	h.goNames[g.normalizeTypeName(name)] = true
+	h.walkDef([]string{name}, schema)
+	return h.reference(name, schema)
+}
+
+// reference returns a reference to the definition name, keeping the title
+// and description of the schema it replaces.
+func (h *inlineHoister) reference(name string, schema *Schema) *Schema {
+	ref := strings.ReplaceAll(name, "~", "~0")
+	ref = strings.ReplaceAll(ref, "/", "~1")
+	return &Schema{
+		Ref:         "#/definitions/" + ref,
+		Title:       schema.Title,
+		Description: schema.Description,
+	}
+}
+
+func extendCtx(ctx []string, part string) []string {
+	return append(ctx[:len(ctx):len(ctx)], part)
+}
+
+// cloneSchema returns a deep copy of schema.
+func cloneSchema(schema *Schema) *Schema {
+	if schema == nil {
+		return nil
+	}
+	c := *schema
+	if schema.Items != nil {
+		c.Items = make(SchemaItems, len(schema.Items))
+		for i, item := range schema.Items {
+			c.Items[i] = cloneSchema(item)
+		}
+	}
+	c.Keys = cloneSchema(schema.Keys)
+	c.Values = cloneSchema(schema.Values)
+	if schema.Fields != nil {
+		c.Fields = make([]Schema, len(schema.Fields))
+		for i := range schema.Fields {
+			c.Fields[i] = *cloneSchema(&schema.Fields[i])
+		}
+	}
+	if schema.AnyOf != nil {
+		c.AnyOf = make([]Schema, len(schema.AnyOf))
+		for i := range schema.AnyOf {
+			c.AnyOf[i] = *cloneSchema(&schema.AnyOf[i])
+		}
+	}
+	return &c
+}
+
+// writeMapType writes a named map type, for a map definition.
+func (g *Generator) writeMapType(name string, pairs *ir.Pairs) error {
+	keyType, valueType := g.mapKeyGoType(pairs.Key), g.goType(pairs.Value)
+	goType := fmt.Sprintf("map[%s]%s", keyType, valueType)
+
+	data := &MapData{
+		TypeData:       TypeData{Name: name, Receiver: name, Kind: "map"},
+		KeyType:        keyType,
+		ValueType:      valueType,
+		ToPlutusData:   g.plutusEncodeCall(pairs, fmt.Sprintf("%s(v)", goType)),
+		FromPlutusData: g.plutusDecodeCall(pairs, "pd", fmt.Sprintf("(*%s)(v)", goType)),
+		Validate:       g.containerValidateCall(pairs, fmt.Sprintf("%s(v)", goType)),
+	}
+
+	// Streaming CBOR decoder and direct encoder
+	_, keyDecoder, keyOK := g.cborMapKeyDecoder(g.goType(pairs.Key), func() (string, bool) {
+		return g.cborDecoder(pairs.Key)
+	})
+	valueDecoder, valueOK := g.cborDecoder(pairs.Value)
+	if keyOK && valueOK {
+		data.KeyCBORDecoder, data.ValueCBORDecoder = keyDecoder, valueDecoder
+	}
+	_, keyEncoder, keyOK := g.cborMapKeyEncoder(g.goType(pairs.Key), func() (string, bool) {
+		return g.cborEncoder(pairs.Key)
+	})
+	valueEncoder, valueOK := g.cborEncoder(pairs.Value)
+	if keyOK && valueOK {
+		data.KeyCBOREncoder, data.ValueCBOREncoder = keyEncoder, valueEncoder
+	}
+
+	if err := g.writeTemplate("map_type.go.tmpl", data); err != nil {
+		return err
+	}
+	return g.writeExtras(data.TypeData)
+}
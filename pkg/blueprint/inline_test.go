@@ -0,0 +1,160 @@
+package blueprint
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestInlineSchemas checks that anonymous schemas nested in a definition
+// get a named type, so that no generated field is interface{}.
+func TestInlineSchemas(t *testing.T) {
+	bp, err := LoadBlueprint("../../testdata/inline/plutus.json")
+	if err != nil {
+		t.Fatalf("failed to load blueprint: %v", err)
+	}
+	code, _, err := NewGenerator(bp, GeneratorOptions{PackageName: "inline"}).Generate()
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+
+	for _, want := range []string{
+		"Field2 PayoutField2Tuple",
+		"Kind PayoutKind",
+		"Weights map[string]PayoutWeightsValue",
+		"Routes []PayoutRoutesItemMap",
+		"Splits []PayoutSplitsItemTuple",
+		"Memo OptionByteArray",
+		"Active bool",
+		"Extra PlutusData",
+		"type PayoutField2Tuple struct",
+		"type PayoutKind interface",
+		"type PayoutRoutesItemMap map[*big.Int][]byte",
+		"type OptionByteArray struct",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("expected %q in generated code", want)
+		}
+	}
+
+	runtime := strings.Replace(plutusDataSource, "package blueprint", "package inline", 1)
+	if strings.Contains(strings.Replace(code, runtime, "", 1), "interface{}") {
+		t.Error("generated types should not use interface{}")
+	}
+
+	// The loaded blueprint is left as it was
+	if _, ok := bp.Definitions["Payout/Field2/Tuple"]; ok {
+		t.Error("Generate should not add definitions to the blueprint")
+	}
+	if bp.Definitions["Payout"].AnyOf[0].Fields[2].IsRef() {
+		t.Error("Generate should not replace inline schemas in the blueprint")
+	}
+}
+
+// TestGeneratedCode_InlineSchemas round-trips a value whose fields are
+// synthesized types through both decode paths.
+func TestGeneratedCode_InlineSchemas(t *testing.T) {
+	testProgram := `package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"os"
+
+	"testpkg/types"
+)
+
+func main() {
+	payout := types.Payout{
+		Owner:  []byte("owner"),
+		Amount: big.NewInt(1000),
+		Field2: types.PayoutField2Tuple{Int: big.NewInt(7), ByteArray: []byte{0xca, 0xfe}},
+		Kind:   types.PayoutKindPercent{Bps: big.NewInt(250)},
+		Weights: map[string]types.PayoutWeightsValue{
+			"a": types.PayoutWeightsValueLow{},
+			"b": types.PayoutWeightsValueHigh{},
+		},
+		Routes: []types.PayoutRoutesItemMap{
+			{big.NewInt(1): []byte("one")},
+			{},
+		},
+		Splits: []types.PayoutSplitsItemTuple{
+			{Field0: []byte("x"), Field1: big.NewInt(60)},
+			{Field0: []byte("y"), Field1: big.NewInt(40)},
+		},
+		Memo:   types.OptionByteArray{IsSet: true, Value: []byte("memo")},
+		Active: true,
+		Extra:  types.NewListPlutusData(types.NewIntPlutusData(big.NewInt(42))),
+	}
+
+	pd, err := payout.ToPlutusData()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ToPlutusData: %v\n", err)
+		os.Exit(1)
+	}
+	data, err := pd.MarshalCBOR()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "MarshalCBOR: %v\n", err)
+		os.Exit(1)
+	}
+	direct, err := payout.MarshalCBOR()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Payout.MarshalCBOR: %v\n", err)
+		os.Exit(1)
+	}
+	if !bytes.Equal(direct, data) {
+		fmt.Fprintf(os.Stderr, "MarshalCBOR: got %x, want %x\n", direct, data)
+		os.Exit(1)
+	}
+
+	var viaPlutusData, streamed types.Payout
+	if err := viaPlutusData.FromPlutusData(pd); err != nil {
+		fmt.Fprintf(os.Stderr, "FromPlutusData: %v\n", err)
+		os.Exit(1)
+	}
+	if err := streamed.UnmarshalCBOR(data); err != nil {
+		fmt.Fprintf(os.Stderr, "UnmarshalCBOR: %v\n", err)
+		os.Exit(1)
+	}
+	for _, decoded := range []types.Payout{viaPlutusData, streamed} {
+		again, err := decoded.MarshalCBOR()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "MarshalCBOR decoded: %v\n", err)
+			os.Exit(1)
+		}
+		if !bytes.Equal(again, data) {
+			fmt.Fprintf(os.Stderr, "decoded re-encodes as %x, want %x\n", again, data)
+			os.Exit(1)
+		}
+		if !decoded.Equals(payout) || !decoded.Routes[0].Equals(payout.Routes[0]) {
+			fmt.Fprintln(os.Stderr, "decoded payout differs")
+			os.Exit(1)
+		}
+	}
+	if payout.Routes[0].Equals(payout.Routes[1]) {
+		fmt.Fprintln(os.Stderr, "different maps compare equal")
+		os.Exit(1)
+	}
+
+	var invalid types.Payout
+	pd.Constr.Fields[5].List[0] = types.NewBytesPlutusData(nil)
+	if err := invalid.FromPlutusData(pd); err == nil || !bytes.Contains([]byte(err.Error()), []byte("Routes[0]")) {
+		fmt.Fprintf(os.Stderr, "expected an error at Routes[0], got %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("OK")
+}
+`
+	tmpDir := setupTypesModule(t, "../../testdata/inline/plutus.json", GeneratorOptions{PackageName: "types"}, map[string]string{"main.go": testProgram})
+
+	cmd := exec.Command("go", "run", ".")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("test program failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "OK") {
+		t.Errorf("unexpected output: %s", output)
+	}
+}
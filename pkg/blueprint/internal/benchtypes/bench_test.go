@@ -0,0 +1,319 @@
+package benchtypes
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// baselineUnmarshal decodes data the way PlutusData.UnmarshalCBOR did before
+// the streaming decoder: into interface{} with fxamacker/cbor, then into
+// PlutusData.
+func baselineUnmarshal(data []byte) (PlutusData, error) {
+	dm, err := cbor.DecOptions{BigIntDec: cbor.BigIntDecodePointer}.DecMode()
+	if err != nil {
+		return PlutusData{}, err
+	}
+	var raw interface{}
+	if err := dm.Unmarshal(data, &raw); err != nil {
+		return PlutusData{}, err
+	}
+	return baselineFromCBORValue(raw)
+}
+
+func baselineFromCBORValue(v interface{}) (PlutusData, error) {
+	switch val := v.(type) {
+	case cbor.Tag:
+		var index uint64
+		switch {
+		case val.Number >= cborTagConstr0 && val.Number <= cborTagConstr6:
+			index = val.Number - cborTagConstr0
+		case val.Number >= cborTagConstrBase:
+			index = val.Number - cborTagConstrBase + 7
+		default:
+			return PlutusData{}, fmt.Errorf("unsupported CBOR tag: %d", val.Number)
+		}
+		content, ok := val.Content.([]interface{})
+		if !ok {
+			return PlutusData{}, errors.New("constructor content is not an array")
+		}
+		fields := make([]PlutusData, len(content))
+		for i, item := range content {
+			pd, err := baselineFromCBORValue(item)
+			if err != nil {
+				return PlutusData{}, err
+			}
+			fields[i] = pd
+		}
+		return NewConstrPlutusData(index, fields...), nil
+	case *big.Int:
+		return NewIntPlutusData(val), nil
+	case int64:
+		return NewIntPlutusData(big.NewInt(val)), nil
+	case uint64:
+		return NewIntPlutusData(new(big.Int).SetUint64(val)), nil
+	case []byte:
+		return NewBytesPlutusData(val), nil
+	case cbor.ByteString:
+		return NewBytesPlutusData([]byte(val)), nil
+	case []interface{}:
+		items := make([]PlutusData, len(val))
+		for i, item := range val {
+			pd, err := baselineFromCBORValue(item)
+			if err != nil {
+				return PlutusData{}, err
+			}
+			items[i] = pd
+		}
+		return NewListPlutusData(items...), nil
+	case map[interface{}]interface{}:
+		entries := make([]PlutusDataMapEntry, 0, len(val))
+		for k, v := range val {
+			key, err := baselineFromCBORValue(k)
+			if err != nil {
+				return PlutusData{}, err
+			}
+			value, err := baselineFromCBORValue(v)
+			if err != nil {
+				return PlutusData{}, err
+			}
+			entries = append(entries, PlutusDataMapEntry{Key: key, Value: value})
+		}
+		return NewMapPlutusData(entries...), nil
+	default:
+		return PlutusData{}, fmt.Errorf("unsupported CBOR type: %T", v)
+	}
+}
+
+// baselineMarshal encodes p the way PlutusData.MarshalCBOR did before the
+// direct encoder: one bytes.Buffer and fxamacker/cbor EncMode per node.
+func baselineMarshal(p PlutusData) ([]byte, error) {
+	em, err := cbor.EncOptions{BigIntConvert: cbor.BigIntConvertShortest}.EncMode()
+	if err != nil {
+		return nil, err
+	}
+	switch p.Kind() {
+	case KindConstr:
+		var buf bytes.Buffer
+		var tag uint64
+		if p.Constr.Index <= 6 {
+			tag = cborTagConstr0 + p.Constr.Index
+		} else {
+			tag = cborTagConstrBase + p.Constr.Index - 7
+		}
+		if tag < 256 {
+			buf.WriteByte(0xd8)
+			buf.WriteByte(byte(tag))
+		} else {
+			buf.WriteByte(0xd9)
+			buf.WriteByte(byte(tag >> 8))
+			buf.WriteByte(byte(tag))
+		}
+		if len(p.Constr.Fields) == 0 {
+			buf.WriteByte(0x80)
+		} else {
+			buf.WriteByte(0x9f)
+			for _, f := range p.Constr.Fields {
+				fieldBytes, err := baselineMarshal(f)
+				if err != nil {
+					return nil, err
+				}
+				buf.Write(fieldBytes)
+			}
+			buf.WriteByte(0xff)
+		}
+		return buf.Bytes(), nil
+	case KindInteger:
+		return em.Marshal(p.Integer)
+	case KindBytes:
+		return em.Marshal(p.ByteString)
+	case KindList:
+		var buf bytes.Buffer
+		buf.WriteByte(0x9f)
+		for _, item := range p.List {
+			itemBytes, err := baselineMarshal(item)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(itemBytes)
+		}
+		buf.WriteByte(0xff)
+		return buf.Bytes(), nil
+	case KindMap:
+		var buf bytes.Buffer
+		if len(p.Map) == 0 {
+			buf.WriteByte(0xa0)
+		} else {
+			buf.WriteByte(0xbf)
+			for _, entry := range p.Map {
+				keyBytes, err := baselineMarshal(entry.Key)
+				if err != nil {
+					return nil, err
+				}
+				buf.Write(keyBytes)
+				valBytes, err := baselineMarshal(entry.Value)
+				if err != nil {
+					return nil, err
+				}
+				buf.Write(valBytes)
+			}
+			buf.WriteByte(0xff)
+		}
+		return buf.Bytes(), nil
+	default:
+		return []byte{0xd8, 0x79, 0x80}, nil
+	}
+}
+
+// TestBaseline tests that the baselines encode and decode the same values as
+// the generated code, so the benchmarks compare like with like.
+func TestBaseline(t *testing.T) {
+	datum := VendorDatum(10)
+	pd, err := datum.ToPlutusData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := datum.MarshalCBOR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := baselineMarshal(pd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("baselineMarshal = %x, want %x", got, want)
+	}
+
+	decoded, err := baselineUnmarshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var roundTrip TypesVendorDatum
+	if err := roundTrip.FromPlutusData(decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !roundTrip.Equals(datum) {
+		t.Fatalf("baselineUnmarshal = %+v, want %+v", roundTrip, datum)
+	}
+}
+
+func encodedDatum(b *testing.B) []byte {
+	data, err := EncodeCBOR(VendorDatum(1000))
+	if err != nil {
+		b.Fatal(err)
+	}
+	return data
+}
+
+// BenchmarkDecodeBaseline decodes with fxamacker/cbor into interface{}, then
+// FromPlutusData.
+func BenchmarkDecodeBaseline(b *testing.B) {
+	data := encodedDatum(b)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pd, err := baselineUnmarshal(data)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var datum TypesVendorDatum
+		if err := datum.FromPlutusData(pd); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDecodeViaPlutusData decodes with PlutusData.UnmarshalCBOR, then
+// FromPlutusData.
+func BenchmarkDecodeViaPlutusData(b *testing.B) {
+	data := encodedDatum(b)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var pd PlutusData
+		if err := pd.UnmarshalCBOR(data); err != nil {
+			b.Fatal(err)
+		}
+		var datum TypesVendorDatum
+		if err := datum.FromPlutusData(pd); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDecodeStreaming decodes with the generated UnmarshalCBOR.
+func BenchmarkDecodeStreaming(b *testing.B) {
+	data := encodedDatum(b)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var datum TypesVendorDatum
+		if err := datum.UnmarshalCBOR(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkEncodeBaseline encodes with ToPlutusData, then fxamacker/cbor.
+func BenchmarkEncodeBaseline(b *testing.B) {
+	datum := VendorDatum(1000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		pd, err := datum.ToPlutusData()
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := baselineMarshal(pd); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkEncodeViaPlutusData encodes with ToPlutusData, then
+// PlutusData.MarshalCBOR.
+func BenchmarkEncodeViaPlutusData(b *testing.B) {
+	datum := VendorDatum(1000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		pd, err := datum.ToPlutusData()
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := pd.MarshalCBOR(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkEncodeDirect encodes with the generated MarshalCBOR.
+func BenchmarkEncodeDirect(b *testing.B) {
+	datum := VendorDatum(1000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := datum.MarshalCBOR(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkEncodeDirectReuseBuffer encodes with the generated AppendCBOR into
+// a reused buffer.
+func BenchmarkEncodeDirectReuseBuffer(b *testing.B) {
+	datum := VendorDatum(1000)
+	var buf []byte
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var err error
+		if buf, err = datum.AppendCBOR(buf[:0]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
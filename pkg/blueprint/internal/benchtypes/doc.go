@@ -0,0 +1,7 @@
+// Package benchtypes holds the code generated from testdata/complex, checked
+// in so the generated codecs can be benchmarked in process:
+//
+//	go test -run '^$' -bench . -benchmem ./pkg/blueprint/internal/benchtypes
+package benchtypes
+
+//go:generate go run ../../../../cmd/aiken2go -quiet -o types.go -p benchtypes ../../../../testdata/complex/plutus.json
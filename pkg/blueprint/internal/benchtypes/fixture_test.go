@@ -0,0 +1,38 @@
+package benchtypes
+
+import (
+	"bytes"
+	"math/big"
+)
+
+// VendorDatum returns a datum with payouts entries in its Payouts list.
+//
+// The blueprint package tests copy this file into the packages they generate
+// from testdata/complex, so it must only use the generated types.
+func VendorDatum(payouts int) TypesVendorDatum {
+	datum := TypesVendorDatum{
+		Vendor: MultisigMultisigScriptAtLeast{
+			Required: big.NewInt(1),
+			Scripts: []MultisigMultisigScript{
+				MultisigMultisigScriptSignature{KeyHash: bytes.Repeat([]byte{0xab}, 28)},
+				MultisigMultisigScriptAllOf{Scripts: []MultisigMultisigScript{
+					MultisigMultisigScriptBefore{Time: big.NewInt(1700000000000)},
+				}},
+			},
+		},
+	}
+	for i := 0; i < payouts; i++ {
+		datum.Payouts = append(datum.Payouts, TypesPayout{
+			Maturation: big.NewInt(int64(1700000000000 + i)),
+			Value: map[string]map[string]*big.Int{
+				"": {"": big.NewInt(int64(i) * 1000000)},
+				string(bytes.Repeat([]byte{0xcd}, 28)): {
+					"token": big.NewInt(int64(i)),
+					"other": new(big.Int).Lsh(big.NewInt(1), 70),
+				},
+			},
+			Status: TypesPayoutStatusActive{},
+		})
+	}
+	return datum
+}
@@ -0,0 +1,28 @@
+package benchtypes
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pgrange/aiken_to_go/pkg/blueprint"
+)
+
+// TestGenerated tests that types.go is what the generator currently
+// writes, so the benchmarks measure the current generated code.
+func TestGenerated(t *testing.T) {
+	bp, err := blueprint.LoadBlueprint("../../../../testdata/complex/plutus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, _, err := blueprint.NewGenerator(bp, blueprint.GeneratorOptions{PackageName: "benchtypes"}).Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkedIn, err := os.ReadFile("types.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(checkedIn) != code {
+		t.Fatal("types.go is out of date; run go generate ./pkg/blueprint/internal/benchtypes")
+	}
+}
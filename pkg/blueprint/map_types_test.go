@@ -16,7 +16,7 @@ func TestMapTypes(t *testing.T) {
 	}
 
 	gen := NewGenerator(bp, GeneratorOptions{PackageName: "maptypes"})
-	code, err := gen.Generate()
+	code, _, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("failed to generate code: %v", err)
 	}
@@ -49,8 +49,8 @@ func TestMapTypes(t *testing.T) {
 
 	// Test 3: Map deserialization should check for Map field
 	t.Run("MapDeserialization", func(t *testing.T) {
-		if !strings.Contains(code, ".Map == nil") {
-			t.Error("Expected map deserialization to check for nil Map")
+		if !strings.Contains(code, ".Kind() != KindMap") {
+			t.Error("Expected map deserialization to check for the map kind")
 		}
 		if !strings.Contains(code, "entry.Key") {
 			t.Error("Expected map deserialization to access entry.Key")
@@ -79,7 +79,7 @@ func TestMapTypesCompiles(t *testing.T) {
 	}
 
 	gen := NewGenerator(bp, GeneratorOptions{PackageName: "maptypes"})
-	code, err := gen.Generate()
+	code, _, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("failed to generate code: %v", err)
 	}
@@ -92,10 +92,6 @@ func TestMapTypesCompiles(t *testing.T) {
 	goMod := `module testmod
 
 go 1.21
-
-require github.com/fxamacker/cbor/v2 v2.8.0
-
-require github.com/x448/float16 v0.8.4 // indirect
 `
 	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
 		t.Fatalf("failed to write go.mod: %v", err)
@@ -134,7 +130,7 @@ func TestMapTypesRoundTrip(t *testing.T) {
 	}
 
 	gen := NewGenerator(bp, GeneratorOptions{PackageName: "types"})
-	code, err := gen.Generate()
+	code, _, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("failed to generate code: %v", err)
 	}
@@ -147,6 +143,7 @@ func TestMapTypesRoundTrip(t *testing.T) {
 	testProgram := `package main
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 	"os"
@@ -155,7 +152,8 @@ import (
 )
 
 func testRoundTrip[T interface {
-	ToPlutusData() (types.PlutusData, error)
+	types.PlutusMarshaler
+	types.CBORAppender
 }](name string, original T, decode func(types.PlutusData) (T, error)) error {
 	pd, err := original.ToPlutusData()
 	if err != nil {
@@ -167,6 +165,15 @@ func testRoundTrip[T interface {
 		return fmt.Errorf("%s MarshalCBOR: %v", name, err)
 	}
 
+	// The direct encoder must write the same bytes
+	direct, err := original.AppendCBOR(nil)
+	if err != nil {
+		return fmt.Errorf("%s AppendCBOR: %v", name, err)
+	}
+	if !bytes.Equal(direct, cborBytes) {
+		return fmt.Errorf("%s AppendCBOR: got %x, want %x", name, direct, cborBytes)
+	}
+
 	var decodedPd types.PlutusData
 	if err := decodedPd.UnmarshalCBOR(cborBytes); err != nil {
 		return fmt.Errorf("%s UnmarshalCBOR: %v", name, err)
@@ -263,6 +270,24 @@ func main() {
 		failed = true
 	}
 
+	// Test empty bytes and maps, which must keep their kind rather than
+	// encode as the unit constructor
+	var emptyFields types.MapWithMultipleMaps
+	if pd, err := emptyFields.ToPlutusData(); err != nil {
+		fmt.Fprintf(os.Stderr, "EmptyFields ToPlutusData: %v\n", err)
+		failed = true
+	} else if hex, _ := pd.ToHex(); hex != "d8799f40a0a0ff" {
+		fmt.Fprintf(os.Stderr, "EmptyFields: got %s, want d8799f40a0a0ff\n", hex)
+		failed = true
+	}
+	if err := testRoundTrip("EmptyFields", emptyFields, func(pd types.PlutusData) (types.MapWithMultipleMaps, error) {
+		var v types.MapWithMultipleMaps
+		return v, v.FromPlutusData(pd)
+	}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		failed = true
+	}
+
 	if failed {
 		os.Exit(1)
 	}
@@ -278,10 +303,6 @@ func main() {
 	goModContent := `module testpkg
 
 go 1.21
-
-require github.com/fxamacker/cbor/v2 v2.8.0
-
-require github.com/x448/float16 v0.8.4 // indirect
 `
 	goModFile := filepath.Join(tmpDir, "go.mod")
 	if err := os.WriteFile(goModFile, []byte(goModContent), 0644); err != nil {
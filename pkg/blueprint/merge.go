@@ -0,0 +1,172 @@
+package blueprint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MergeConflict is a definition or validator that two merged blueprints
+// declare differently.
+type MergeConflict struct {
+	// Name is the definition name, or "validator <title>".
+	Name string
+	// First and Second are the sources declaring it, in merge order.
+	First, Second string
+}
+
+func (c MergeConflict) String() string {
+	return fmt.Sprintf("%s differs between %s and %s", c.Name, c.First, c.Second)
+}
+
+// MergeError reports the conflicts that prevented merging blueprints.
+type MergeError struct {
+	Conflicts []MergeConflict
+}
+
+func (e *MergeError) Error() string {
+	lines := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		lines[i] = c.String()
+	}
+	return fmt.Sprintf("%d conflicts merging blueprints: %s", len(e.Conflicts), strings.Join(lines, "; "))
+}
+
+// LoadBlueprints reads several plutus.json files and merges them with
+// MergeBlueprints, naming each by its path.
+func LoadBlueprints(paths ...string) (*Blueprint, error) {
+	bps := make([]*Blueprint, len(paths))
+	for i, path := range paths {
+		bp, err := LoadBlueprint(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		bps[i] = bp
+	}
+	return MergeBlueprints(paths, bps)
+}
+
+// MergeBlueprints merges the definitions and validators of several
+// blueprints, so that projects sharing types (such as the stdlib's
+// cardano/transaction/OutputReference) generate into one package.
+//
+// Definitions with the same name must be structurally identical, and
+// validators with the same title must have the same hash; descriptions are
+// ignored. Otherwise MergeBlueprints returns a *MergeError listing every
+// conflict, naming blueprints by the matching entry of sources.
+//
+// The merged preamble joins the titles and keeps the other fields the
+// blueprints agree on.
+func MergeBlueprints(sources []string, bps []*Blueprint) (*Blueprint, error) {
+	if len(sources) != len(bps) {
+		return nil, fmt.Errorf("merging %d blueprints with %d sources", len(bps), len(sources))
+	}
+	if len(bps) == 1 {
+		return bps[0], nil
+	}
+
+	merged := &Blueprint{Definitions: make(map[string]*Schema)}
+	definedIn := make(map[string]string)
+	validatorIn := make(map[string]int)
+	var conflicts []MergeConflict
+
+	for i, bp := range bps {
+		for name, schema := range bp.Definitions {
+			existing, ok := merged.Definitions[name]
+			if !ok {
+				merged.Definitions[name] = schema
+				definedIn[name] = sources[i]
+				continue
+			}
+			if !sameSchema(existing, schema) {
+				conflicts = append(conflicts, MergeConflict{Name: name, First: definedIn[name], Second: sources[i]})
+			}
+		}
+
+		for _, v := range bp.Validators {
+			j, ok := validatorIn[v.Title]
+			if !ok {
+				validatorIn[v.Title] = len(merged.Validators)
+				merged.Validators = append(merged.Validators, v)
+				definedIn["validator "+v.Title] = sources[i]
+				continue
+			}
+			if merged.Validators[j].Hash != v.Hash {
+				name := "validator " + v.Title
+				conflicts = append(conflicts, MergeConflict{Name: name, First: definedIn[name], Second: sources[i]})
+			}
+		}
+	}
+
+	if len(conflicts) > 0 {
+		sort.SliceStable(conflicts, func(i, j int) bool { return conflicts[i].Name < conflicts[j].Name })
+		return nil, &MergeError{Conflicts: conflicts}
+	}
+
+	merged.Preamble = mergePreambles(bps)
+	for _, bp := range bps {
+		merged.Sources = append(merged.Sources, bp.Sources...)
+	}
+	return merged, nil
+}
+
+// mergePreambles joins the titles of the blueprints and keeps the other
+// fields when all blueprints agree on them.
+func mergePreambles(bps []*Blueprint) Preamble {
+	merged := bps[0].Preamble
+	titles := make([]string, len(bps))
+	for i, bp := range bps {
+		p := bp.Preamble
+		titles[i] = p.Title
+		if p.Description != merged.Description {
+			merged.Description = ""
+		}
+		if p.Version != merged.Version {
+			merged.Version = ""
+		}
+		if p.PlutusVersion != merged.PlutusVersion {
+			merged.PlutusVersion = ""
+		}
+		if p.Compiler != merged.Compiler {
+			merged.Compiler = Compiler{}
+		}
+		if p.License != merged.License {
+			merged.License = ""
+		}
+	}
+	merged.Title = strings.Join(titles, ", ")
+	return merged
+}
+
+// sameSchema reports whether two schemas describe the same type, ignoring
+// descriptions.
+func sameSchema(a, b *Schema) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Ref != b.Ref || a.Title != b.Title || a.DataType != b.DataType {
+		return false
+	}
+	if (a.Index == nil) != (b.Index == nil) || (a.Index != nil && *a.Index != *b.Index) {
+		return false
+	}
+	if len(a.Items) != len(b.Items) || len(a.Fields) != len(b.Fields) || len(a.AnyOf) != len(b.AnyOf) {
+		return false
+	}
+	for i := range a.Items {
+		if !sameSchema(a.Items[i], b.Items[i]) {
+			return false
+		}
+	}
+	for i := range a.Fields {
+		if !sameSchema(&a.Fields[i], &b.Fields[i]) {
+			return false
+		}
+	}
+	for i := range a.AnyOf {
+		if !sameSchema(&a.AnyOf[i], &b.AnyOf[i]) {
+			return false
+		}
+	}
+	return sameSchema(a.Keys, b.Keys) && sameSchema(a.Values, b.Values)
+}
@@ -0,0 +1,91 @@
+package blueprint
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeBlueprints(t *testing.T) {
+	complexBP, err := LoadBlueprint("../../testdata/complex/plutus.json")
+	if err != nil {
+		t.Fatalf("failed to load blueprint: %v", err)
+	}
+	simpleBP, err := LoadBlueprint("../../testdata/simple/plutus.json")
+	if err != nil {
+		t.Fatalf("failed to load blueprint: %v", err)
+	}
+
+	merged, err := MergeBlueprints([]string{"complex.json", "simple.json"}, []*Blueprint{complexBP, simpleBP})
+	if err != nil {
+		t.Fatalf("MergeBlueprints: %v", err)
+	}
+
+	for name := range complexBP.Definitions {
+		if merged.Definitions[name] == nil {
+			t.Errorf("merged blueprint lacks %s from complex.json", name)
+		}
+	}
+	for name := range simpleBP.Definitions {
+		if merged.Definitions[name] == nil {
+			t.Errorf("merged blueprint lacks %s from simple.json", name)
+		}
+	}
+	if got, want := len(merged.Validators), len(complexBP.Validators)+len(simpleBP.Validators); got != want {
+		t.Errorf("expected %d validators, got %d", want, got)
+	}
+	if want := append(complexBP.Sources[:1:1], simpleBP.Sources...); !reflect.DeepEqual(merged.Sources, want) {
+		t.Errorf("expected sources %v, got %v", want, merged.Sources)
+	}
+	if want := complexBP.Preamble.Title + ", " + simpleBP.Preamble.Title; merged.Preamble.Title != want {
+		t.Errorf("expected title %q, got %q", want, merged.Preamble.Title)
+	}
+
+	// Merging a blueprint with itself deduplicates everything
+	self, err := MergeBlueprints([]string{"a.json", "b.json"}, []*Blueprint{complexBP, complexBP})
+	if err != nil {
+		t.Fatalf("MergeBlueprints with itself: %v", err)
+	}
+	if !reflect.DeepEqual(self.Definitions, complexBP.Definitions) || !reflect.DeepEqual(self.Validators, complexBP.Validators) {
+		t.Error("merging a blueprint with itself changed it")
+	}
+}
+
+func TestMergeBlueprintsConflicts(t *testing.T) {
+	load := func() *Blueprint {
+		bp, err := LoadBlueprint("../../testdata/complex/plutus.json")
+		if err != nil {
+			t.Fatalf("failed to load blueprint: %v", err)
+		}
+		return bp
+	}
+	a, b, c := load(), load(), load()
+
+	// Descriptions don't make definitions differ
+	b.Definitions["types/PayoutStatus"].Description = "Whether a payout can be claimed."
+	if _, err := MergeBlueprints([]string{"a.json", "b.json"}, []*Blueprint{a, b}); err != nil {
+		t.Fatalf("descriptions caused a conflict: %v", err)
+	}
+
+	b.Definitions["types/PayoutStatus"].AnyOf[1].Title = "Frozen"
+	c.Definitions["cardano/transaction/OutputReference"].AnyOf[0].Fields = c.Definitions["cardano/transaction/OutputReference"].AnyOf[0].Fields[:1]
+	c.Validators[0].Hash = "00"
+
+	_, err := MergeBlueprints([]string{"a.json", "b.json", "c.json"}, []*Blueprint{a, b, c})
+	var mergeErr *MergeError
+	if !errors.As(err, &mergeErr) {
+		t.Fatalf("expected *MergeError, got %v", err)
+	}
+	want := []MergeConflict{
+		{Name: "cardano/transaction/OutputReference", First: "a.json", Second: "c.json"},
+		{Name: "types/PayoutStatus", First: "a.json", Second: "b.json"},
+		{Name: "validator " + a.Validators[0].Title, First: "a.json", Second: "c.json"},
+	}
+	if !reflect.DeepEqual(mergeErr.Conflicts, want) {
+		t.Errorf("unexpected conflicts:\n%v\nwant\n%v", mergeErr.Conflicts, want)
+	}
+	if !strings.Contains(err.Error(), "types/PayoutStatus differs between a.json and b.json") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+}
@@ -0,0 +1,352 @@
+package blueprint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pgrange/aiken_to_go/pkg/ir"
+)
+
+// Migrations convert values between versions of a type that a blueprint
+// keeps side by side, such as v0_1/types/Settings and v0_3/types/Settings.
+// For every older version of a record, the newer one gets a method
+//
+//	func (v *V03TypesSettings) FromV01TypesSettings(old V01TypesSettings) error
+//
+// and for every older version of an enum, a function
+//
+//	func V03TypesStatusFromV01TypesStatus(old V01TypesStatus) (V03TypesStatus, error)
+//
+// which enums generated as constants (GeneratorOptions.ConstEnums) get too,
+// from older versions also generated as constants.
+//
+// Fields and variants are matched by name, not position. Fields with the
+// same Go type are copied; versioned types, and lists and options of them,
+// are migrated in turn. A new field without an old counterpart, or with a
+// type that can't be converted, makes the migration always return an error.
+
+// versionPattern matches the version segment of a definition name.
+var versionPattern = regexp.MustCompile(`^v\d+(_\d+)*$`)
+
+// versionedDef is a record or enum definition under a version segment.
+type versionedDef struct {
+	name    string // definition name, e.g. "v0_3/types/Settings"
+	rest    string // name without the version, e.g. "types/Settings"
+	version []int
+	kind    string // "struct", "enum" or "constants"
+}
+
+// splitVersion splits a definition name such as "v0_3/types/Settings" into
+// its version numbers and the rest of the name.
+func splitVersion(name string) ([]int, string, bool) {
+	segment, rest, ok := strings.Cut(name, "/")
+	if !ok || !versionPattern.MatchString(segment) {
+		return nil, "", false
+	}
+	parts := strings.Split(segment[1:], "_")
+	version := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, "", false
+		}
+		version[i] = n
+	}
+	return version, rest, true
+}
+
+// olderVersion reports whether version a precedes version b.
+func olderVersion(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// collectVersionedDefs indexes the versioned record and enum definitions by
+// name.
+func (g *Generator) collectVersionedDefs() {
+	g.versioned = make(map[string]*versionedDef)
+	for _, def := range g.module.Definitions {
+		if _, ok := g.instances[def.Name]; ok {
+			continue
+		}
+		version, rest, ok := splitVersion(def.Name)
+		if !ok {
+			continue
+		}
+		var kind string
+		switch def.Type.(type) {
+		case *ir.Record:
+			kind = "struct"
+		case *ir.Enum:
+			kind = "enum"
+			if g.isConstEnum(def.Type) {
+				kind = "constants"
+			}
+		default:
+			continue
+		}
+		g.versioned[def.Name] = &versionedDef{name: def.Name, rest: rest, version: version, kind: kind}
+	}
+}
+
+// hasMigration reports whether a migration from definition oldName to
+// newName is generated.
+func (g *Generator) hasMigration(oldName, newName string) bool {
+	oldDef, newDef := g.versioned[oldName], g.versioned[newName]
+	return oldDef != nil && newDef != nil && oldDef.rest == newDef.rest &&
+		oldDef.kind == newDef.kind && olderVersion(oldDef.version, newDef.version)
+}
+
+// writeMigrations writes a migration from every version of a type to every
+// newer one.
+func (g *Generator) writeMigrations() error {
+	g.collectVersionedDefs()
+
+	groups := make(map[string][]*versionedDef)
+	for _, def := range g.versioned {
+		groups[def.rest] = append(groups[def.rest], def)
+	}
+	rests := make([]string, 0, len(groups))
+	for rest := range groups {
+		rests = append(rests, rest)
+	}
+	sort.Strings(rests)
+
+	for _, rest := range rests {
+		defs := groups[rest]
+		sort.Slice(defs, func(i, j int) bool { return olderVersion(defs[i].version, defs[j].version) })
+		for i, oldDef := range defs {
+			for _, newDef := range defs[i+1:] {
+				if !g.hasMigration(oldDef.name, newDef.name) {
+					continue
+				}
+				oldType, newType := g.definitionType(oldDef.name), g.definitionType(newDef.name)
+				oldName, newName := g.normalizeTypeName(oldDef.name), g.normalizeTypeName(newDef.name)
+				var err error
+				switch oldDef.kind {
+				case "enum":
+					err = g.writeEnumMigration(newName, oldName, newType.(*ir.Enum), oldType.(*ir.Enum))
+				case "constants":
+					err = g.writeTemplate("const_enum_migration.go.tmpl", g.enumMigrationData(newName, oldName, newType.(*ir.Enum), oldType.(*ir.Enum)))
+				default:
+					err = g.writeStructMigration(newName, oldName, newType.(*ir.Record).Fields, oldType.(*ir.Record).Fields, false)
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// migrationField is a field of a generated struct and its type.
+type migrationField struct {
+	name string
+	typ  ir.Type
+}
+
+// migrationFields returns the fields of the struct generated for a
+// constructor. Enum variants with a single unnamed field wrap it as Value.
+func (g *Generator) migrationFields(constrFields []*ir.Field, variant bool) []migrationField {
+	if variant && len(constrFields) == 1 && constrFields[0].Title == "" {
+		return []migrationField{{name: "Value", typ: constrFields[0].Type}}
+	}
+	fields := make([]migrationField, len(constrFields))
+	for i, field := range constrFields {
+		name := g.normalizeFieldName(field.Title, i)
+		if variant {
+			name = variantFieldName(name)
+		}
+		fields[i] = migrationField{name: name, typ: field.Type}
+	}
+	return fields
+}
+
+// writeStructMigration writes the FromOld method of a record or enum
+// variant.
+func (g *Generator) writeStructMigration(newName, oldName string, newFields, oldFields []*ir.Field, variant bool) error {
+	oldTypes := make(map[string]ir.Type)
+	for _, field := range g.migrationFields(oldFields, variant) {
+		oldTypes[field.name] = field.typ
+	}
+
+	var problems []string
+	needsErr := false
+	for _, field := range g.migrationFields(newFields, variant) {
+		oldType, ok := oldTypes[field.name]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("no field %s in %s", field.name, oldName))
+		case !g.canMigrate(oldType, field.typ):
+			problems = append(problems, fmt.Sprintf("field %s can't be converted from %s to %s",
+				field.name, g.goType(oldType), g.goType(field.typ)))
+		default:
+			needsErr = needsErr || g.migrationNeedsErr(oldType, field.typ)
+		}
+	}
+
+	data := &MigrationData{Name: newName, OldName: oldName, Problems: strings.Join(problems, ", ")}
+	if len(problems) == 0 {
+		data.NeedsErr = needsErr
+		for _, field := range g.migrationFields(newFields, variant) {
+			data.Fields = append(data.Fields, g.capture(1, func() {
+				g.writeFieldMigration("v."+field.name, "old."+field.name, oldTypes[field.name], field.typ, field.name, nil)
+			}))
+		}
+	}
+	return g.writeTemplate("record_migration.go.tmpl", data)
+}
+
+// writeEnumMigration writes the NewFromOld function of an enum and the
+// migrations of its variants.
+func (g *Generator) writeEnumMigration(newName, oldName string, newEnum, oldEnum *ir.Enum) error {
+	if err := g.writeTemplate("enum_migration.go.tmpl", g.enumMigrationData(newName, oldName, newEnum, oldEnum)); err != nil {
+		return err
+	}
+
+	newVariants := make(map[string]*ir.Variant)
+	for _, variant := range newEnum.Variants {
+		newVariants[variant.Title] = variant
+	}
+	for _, oldVariant := range oldEnum.Variants {
+		newVariant, ok := newVariants[oldVariant.Title]
+		if !ok {
+			continue
+		}
+		title := g.toGoIdentifier(oldVariant.Title)
+		if err := g.writeStructMigration(newName+title, oldName+title, newVariant.Fields, oldVariant.Fields, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enumMigrationData matches the variants of two versions of an enum by
+// title.
+func (g *Generator) enumMigrationData(newName, oldName string, newEnum, oldEnum *ir.Enum) *EnumMigrationData {
+	newTitles := make(map[string]bool)
+	for _, variant := range newEnum.Variants {
+		newTitles[variant.Title] = true
+	}
+
+	data := &EnumMigrationData{Name: newName, OldName: oldName}
+	for _, oldVariant := range oldEnum.Variants {
+		title := g.toGoIdentifier(oldVariant.Title)
+		variant := &VariantMigrationData{Name: title, Old: oldName + title}
+		if newTitles[oldVariant.Title] {
+			variant.New = newName + title
+		}
+		data.Variants = append(data.Variants, variant)
+	}
+	return data
+}
+
+// migrationListItem returns the item type of a list, or nil.
+func migrationListItem(t ir.Type) ir.Type {
+	if list, ok := t.(*ir.List); ok {
+		return list.Elem
+	}
+	return nil
+}
+
+// migrationOptionInner returns the inner type of an option, or nil.
+func migrationOptionInner(t ir.Type) ir.Type {
+	if option, ok := t.(*ir.Option); ok {
+		return option.Elem
+	}
+	return nil
+}
+
+// canMigrate reports whether a value of type from can be converted to type
+// to.
+func (g *Generator) canMigrate(from, to ir.Type) bool {
+	if g.goType(from) == g.goType(to) {
+		return true
+	}
+	fromName, toName := typeName(from), typeName(to)
+	if g.hasMigration(fromName, toName) {
+		return true
+	}
+	if fromItem, toItem := migrationListItem(from), migrationListItem(to); fromItem != nil && toItem != nil {
+		return g.canMigrate(fromItem, toItem)
+	}
+	if fromInner, toInner := migrationOptionInner(from), migrationOptionInner(to); fromInner != nil && toInner != nil {
+		return g.canMigrate(fromInner, toInner)
+	}
+	return false
+}
+
+// migrationNeedsErr reports whether converting from to to assigns an enum
+// migration result, which writeFieldMigration does through a shared err.
+func (g *Generator) migrationNeedsErr(from, to ir.Type) bool {
+	if g.goType(from) == g.goType(to) {
+		return false
+	}
+	fromName, toName := typeName(from), typeName(to)
+	if g.hasMigration(fromName, toName) {
+		return g.versioned[toName].kind != "struct"
+	}
+	if fromItem, toItem := migrationListItem(from), migrationListItem(to); fromItem != nil && toItem != nil {
+		return g.migrationNeedsErr(fromItem, toItem)
+	}
+	if fromInner, toInner := migrationOptionInner(from), migrationOptionInner(to); fromInner != nil && toInner != nil {
+		return g.migrationNeedsErr(fromInner, toInner)
+	}
+	return false
+}
+
+// migrationIndexVars names the index variables of nested list migrations.
+var migrationIndexVars = []string{"i", "j", "k", "l", "m", "n"}
+
+// writeFieldMigration writes the conversion of src into dst, which canMigrate
+// accepted. Errors are wrapped with path, a format string whose %d verbs
+// take the list indexes in scope.
+func (g *Generator) writeFieldMigration(dst, src string, from, to ir.Type, path string, indexes []string) {
+	if g.goType(from) == g.goType(to) {
+		g.writeLine(fmt.Sprintf("%s = %s", dst, src))
+		return
+	}
+
+	fromName, toName := typeName(from), typeName(to)
+	if g.hasMigration(fromName, toName) {
+		fromGo, toGo := g.normalizeTypeName(fromName), g.normalizeTypeName(toName)
+		if g.versioned[toName].kind != "struct" {
+			g.writeLine(fmt.Sprintf("if %s, err = %sFrom%s(%s); err != nil {", dst, toGo, fromGo, src))
+		} else {
+			g.writeLine(fmt.Sprintf("if err := %s.From%s(%s); err != nil {", dst, fromGo, src))
+		}
+		g.indentInc()
+		args := append([]string{strconv.Quote(path + ": %w")}, indexes...)
+		g.writeLine(fmt.Sprintf("return fmt.Errorf(%s, err)", strings.Join(args, ", ")))
+		g.indentDec()
+		g.writeLine("}")
+		return
+	}
+
+	if fromItem, toItem := migrationListItem(from), migrationListItem(to); fromItem != nil && toItem != nil {
+		index := migrationIndexVars[len(indexes)%len(migrationIndexVars)]
+		g.writeLine(fmt.Sprintf("%s = make(%s, len(%s))", dst, g.goType(to), src))
+		g.writeLine(fmt.Sprintf("for %s := range %s {", index, src))
+		g.indentInc()
+		g.writeFieldMigration(dst+"["+index+"]", src+"["+index+"]", fromItem, toItem, path+"[%d]", append(indexes[:len(indexes):len(indexes)], index))
+		g.indentDec()
+		g.writeLine("}")
+		return
+	}
+
+	fromInner, toInner := migrationOptionInner(from), migrationOptionInner(to)
+	g.writeLine(fmt.Sprintf("%s.IsSet = %s.IsSet", dst, src))
+	g.writeLine(fmt.Sprintf("if %s.IsSet {", src))
+	g.indentInc()
+	g.writeFieldMigration(dst+".Value", src+".Value", fromInner, toInner, path, indexes)
+	g.indentDec()
+	g.writeLine("}")
+}
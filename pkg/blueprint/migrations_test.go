@@ -0,0 +1,154 @@
+package blueprint
+
+import (
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version []int
+		rest    string
+		ok      bool
+	}{
+		{"v0_3/types/Settings", []int{0, 3}, "types/Settings", true},
+		{"v2/types/Settings", []int{2}, "types/Settings", true},
+		{"types/Settings", nil, "", false},
+		{"version/types/Settings", nil, "", false},
+		{"v0_3", nil, "", false},
+	}
+	for _, tt := range tests {
+		version, rest, ok := splitVersion(tt.name)
+		if !reflect.DeepEqual(version, tt.version) || rest != tt.rest || ok != tt.ok {
+			t.Errorf("splitVersion(%q) = %v, %q, %v, want %v, %q, %v", tt.name, version, rest, ok, tt.version, tt.rest, tt.ok)
+		}
+	}
+
+	if !olderVersion([]int{0, 3}, []int{0, 10}) {
+		t.Error("expected v0_3 to precede v0_10")
+	}
+	if olderVersion([]int{1}, []int{0, 9}) {
+		t.Error("expected v1 not to precede v0_9")
+	}
+}
+
+func TestMigrations(t *testing.T) {
+	bp, err := LoadBlueprint("../../testdata/versioned/plutus.json")
+	if err != nil {
+		t.Fatalf("failed to load blueprint: %v", err)
+	}
+
+	code, _, err := NewGenerator(bp, GeneratorOptions{PackageName: "types"}).Generate()
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+	if strings.Contains(code, "FromV01TypesSettings") {
+		t.Error("migrations generated without Migrations")
+	}
+
+	code, _, err = NewGenerator(bp, GeneratorOptions{PackageName: "types", Migrations: true}).Generate()
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+	for _, want := range []string{
+		"func (v *V03TypesSettings) FromV01TypesSettings(old V01TypesSettings) error {",
+		"func V03TypesStatusFromV01TypesStatus(old V01TypesStatus) (V03TypesStatus, error) {",
+		"func (v *V03TypesStatusPaused) FromV01TypesStatusPaused(old V01TypesStatusPaused) error {",
+		`return errors.New("cannot migrate V01TypesConfig to V03TypesConfig: no field Deadline in V01TypesConfig")`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("expected generated code to contain %q", want)
+		}
+	}
+	// Migrations only go from older to newer versions
+	if strings.Contains(code, "FromV03TypesSettings") {
+		t.Error("unexpected downgrade migration")
+	}
+}
+
+func TestMigrationsRoundTrip(t *testing.T) {
+	testProgram := `package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"testpkg/types"
+)
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
+
+func main() {
+	old := types.V01TypesSettings{
+		Owner:  []byte("owner"),
+		Fee:    big.NewInt(42),
+		Status: types.V01TypesStatusPaused{Value: big.NewInt(7)},
+		Payouts: []types.V01TypesPayout{
+			{Amount: big.NewInt(1), To: []byte("a")},
+			{Amount: big.NewInt(2), To: []byte("b")},
+		},
+		Backup: types.OptionV01TypesPayout{IsSet: true, Value: types.V01TypesPayout{Amount: big.NewInt(3), To: []byte("c")}},
+	}
+
+	var settings types.V03TypesSettings
+	if err := settings.FromV01TypesSettings(old); err != nil {
+		fail("FromV01TypesSettings: %v", err)
+	}
+	want := types.V03TypesSettings{
+		Owner:  []byte("owner"),
+		Status: types.V03TypesStatusPaused{Value: big.NewInt(7)},
+		Fee:    big.NewInt(42),
+		Payouts: []types.V03TypesPayout{
+			{To: []byte("a"), Amount: big.NewInt(1)},
+			{To: []byte("b"), Amount: big.NewInt(2)},
+		},
+		Backup: types.OptionV03TypesPayout{IsSet: true, Value: types.V03TypesPayout{To: []byte("c"), Amount: big.NewInt(3)}},
+	}
+	if !settings.Equals(want) {
+		fail("unexpected migrated settings: %+v", settings)
+	}
+
+	// Paused moved from index 1 to 0: the migrated value encodes with the
+	// new index
+	data, err := types.EncodeCBOR(settings.Status)
+	if err != nil {
+		fail("EncodeCBOR: %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte{0xd8, 0x79}) {
+		fail("unexpected Paused encoding %x", data)
+	}
+
+	old.Status = types.V01TypesStatusFrozen{}
+	err = settings.FromV01TypesSettings(old)
+	if err == nil || !strings.Contains(err.Error(), "Status: cannot migrate V01TypesStatusFrozen: V03TypesStatus has no variant Frozen") {
+		fail("Frozen: unexpected error %v", err)
+	}
+
+	var config types.V03TypesConfig
+	err = config.FromV01TypesConfig(types.V01TypesConfig{Admin: []byte("admin")})
+	if err == nil || !strings.Contains(err.Error(), "no field Deadline") {
+		fail("Config: unexpected error %v", err)
+	}
+
+	fmt.Println("✓ migrated v0_1 to v0_3")
+}
+`
+	tmpDir := setupTypesModule(t, "../../testdata/versioned/plutus.json", GeneratorOptions{PackageName: "types", Migrations: true}, map[string]string{"main.go": testProgram})
+
+	cmd := exec.Command("go", "run", "main.go")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("test program failed: %v\n%s", err, output)
+	}
+	t.Logf("Test output:\n%s", output)
+}
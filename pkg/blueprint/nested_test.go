@@ -0,0 +1,182 @@
+package blueprint
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestNestedContainers checks that lists, options, pairs and tuples nested
+// in one another get a Go type and a codec at any depth.
+func TestNestedContainers(t *testing.T) {
+	bp, err := LoadBlueprint("../../testdata/nested/plutus.json")
+	if err != nil {
+		t.Fatalf("failed to load blueprint: %v", err)
+	}
+
+	for _, opts := range []GeneratorOptions{
+		{PackageName: "nested"},
+		{PackageName: "nested", Generics: true, UnknownVariants: true},
+	} {
+		code, _, err := NewGenerator(bp, opts).Generate()
+		if err != nil {
+			t.Fatalf("failed to generate code: %v", err)
+		}
+		if strings.Contains(code, "TODO") {
+			t.Errorf("generated code (generics=%v) should not contain TODOs", opts.Generics)
+		}
+		if opts.Generics {
+			continue
+		}
+
+		for _, want := range []string{
+			"Value map[string]map[string]*big.Int",
+			"Buckets []OptionPairsBytearrayListInt",
+			"Matrix [][]*big.Int",
+			"Sparse map[*big.Int]OptionInt",
+			"Entries []TupleIntListByteArray",
+			"Maybe OptionListOptionInt",
+			"Grid [][]*big.Int",
+			"Index map[string][]NestedTortureIndexValueItemMap",
+			"ListByteArray [][]byte",
+			"type NestedMatrix [][]*big.Int",
+		} {
+			if !strings.Contains(code, want) {
+				t.Errorf("expected %q in generated code", want)
+			}
+		}
+	}
+}
+
+// TestGeneratedCode_NestedContainers round-trips deeply nested containers
+// through both decode paths.
+func TestGeneratedCode_NestedContainers(t *testing.T) {
+	testProgram := `package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"os"
+
+	"testpkg/types"
+)
+
+func roundTrip(name string, value types.PlutusMarshaler, decoded, streamed interface {
+	types.PlutusUnmarshaler
+	UnmarshalCBOR([]byte) error
+	MarshalCBOR() ([]byte, error)
+}) {
+	pd, err := value.ToPlutusData()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s ToPlutusData: %v\n", name, err)
+		os.Exit(1)
+	}
+	data, err := pd.MarshalCBOR()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s MarshalCBOR: %v\n", name, err)
+		os.Exit(1)
+	}
+	if err := decoded.FromPlutusData(pd); err != nil {
+		fmt.Fprintf(os.Stderr, "%s FromPlutusData: %v\n", name, err)
+		os.Exit(1)
+	}
+	if err := streamed.UnmarshalCBOR(data); err != nil {
+		fmt.Fprintf(os.Stderr, "%s UnmarshalCBOR: %v\n", name, err)
+		os.Exit(1)
+	}
+	for _, v := range []interface{ MarshalCBOR() ([]byte, error) }{decoded, streamed} {
+		again, err := v.MarshalCBOR()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s MarshalCBOR decoded: %v\n", name, err)
+			os.Exit(1)
+		}
+		if !bytes.Equal(again, data) {
+			fmt.Fprintf(os.Stderr, "%s re-encodes as %x, want %x\n", name, again, data)
+			os.Exit(1)
+		}
+	}
+}
+
+func main() {
+	torture := types.NestedTorture{
+		Value: map[string]map[string]*big.Int{
+			"policy": {"gold": big.NewInt(3), "silver": big.NewInt(-7)},
+			"empty":  {},
+		},
+		Buckets: []types.OptionPairsBytearrayListInt{
+			{IsSet: true, Value: map[string][]*big.Int{"a": {big.NewInt(1), big.NewInt(2)}, "b": {}}},
+			{},
+		},
+		Matrix: [][]*big.Int{{big.NewInt(1)}, {}, {big.NewInt(2), big.NewInt(3)}},
+		Sparse: map[*big.Int]types.OptionInt{
+			big.NewInt(1): {IsSet: true, Value: big.NewInt(10)},
+			big.NewInt(2): {},
+		},
+		Entries: []types.TupleIntListByteArray{
+			{Int: big.NewInt(5), ListByteArray: [][]byte{[]byte("x"), []byte("y")}},
+		},
+		Maybe: types.OptionListOptionInt{IsSet: true, Value: []types.OptionInt{{IsSet: true, Value: big.NewInt(4)}, {}}},
+		Rows:  types.NestedMatrix{{big.NewInt(9)}},
+		Grid:  [][]*big.Int{{big.NewInt(8), big.NewInt(7)}},
+		Index: map[string][]types.NestedTortureIndexValueItemMap{
+			"k": {{big.NewInt(1): []byte("one")}, {}},
+		},
+	}
+
+	var decoded, streamed types.NestedTorture
+	roundTrip("Torture", torture, &decoded, &streamed)
+	if !decoded.Equals(torture) || !streamed.Equals(torture) {
+		fmt.Fprintln(os.Stderr, "decoded torture differs")
+		os.Exit(1)
+	}
+	changed := streamed
+	changed.Value = map[string]map[string]*big.Int{"policy": {"gold": big.NewInt(4)}}
+	if changed.Equals(torture) {
+		fmt.Fprintln(os.Stderr, "different values compare equal")
+		os.Exit(1)
+	}
+
+	for _, action := range []types.NestedAction{
+		types.NestedActionMint{Amounts: torture.Value},
+		types.NestedActionBurn{Value: torture.Matrix},
+		types.NestedActionNoop{},
+	} {
+		pd, err := action.ToPlutusData()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "action ToPlutusData: %v\n", err)
+			os.Exit(1)
+		}
+		back, err := types.NestedActionFromPlutusData(pd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "NestedActionFromPlutusData: %v\n", err)
+			os.Exit(1)
+		}
+		if !types.NestedActionEquals(back, action) {
+			fmt.Fprintf(os.Stderr, "action %T differs after round trip\n", action)
+			os.Exit(1)
+		}
+	}
+
+	pd, _ := torture.ToPlutusData()
+	pd.Constr.Fields[2].List[2].List[1] = types.NewBytesPlutusData(nil)
+	var invalid types.NestedTorture
+	if err := invalid.FromPlutusData(pd); err == nil || !bytes.Contains([]byte(err.Error()), []byte("Matrix[2][1]")) {
+		fmt.Fprintf(os.Stderr, "expected an error at Matrix[2][1], got %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("OK")
+}
+`
+	tmpDir := setupTypesModule(t, "../../testdata/nested/plutus.json", GeneratorOptions{PackageName: "types"}, map[string]string{"main.go": testProgram})
+
+	cmd := exec.Command("go", "run", ".")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("test program failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(string(output), "OK") {
+		t.Errorf("unexpected output: %s", output)
+	}
+}
@@ -6,17 +6,55 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
-
-	"github.com/fxamacker/cbor/v2"
+	"sort"
+	"strings"
 )
 
 // PlutusData represents a Plutus Data value that can be serialized to CBOR.
+//
+// The New*PlutusData constructors record which field holds the value, so an
+// empty list, map or bytestring keeps its kind; Kind reports it. A
+// PlutusData built as a struct literal has no recorded kind and takes that
+// of its first non-nil field.
 type PlutusData struct {
 	Constr     *ConstrPlutusData
 	Integer    *big.Int
 	ByteString []byte
 	List       []PlutusData
 	Map        []PlutusDataMapEntry
+
+	kind PlutusDataKind
+}
+
+// PlutusDataKind identifies which field of a PlutusData holds its value.
+type PlutusDataKind uint8
+
+const (
+	// KindNone is the kind of the zero PlutusData, which encodes as the
+	// unit constructor.
+	KindNone PlutusDataKind = iota
+	KindConstr
+	KindInteger
+	KindBytes
+	KindList
+	KindMap
+)
+
+func (k PlutusDataKind) String() string {
+	switch k {
+	case KindConstr:
+		return "constructor"
+	case KindInteger:
+		return "integer"
+	case KindBytes:
+		return "bytes"
+	case KindList:
+		return "list"
+	case KindMap:
+		return "map"
+	default:
+		return "null"
+	}
 }
 
 // ConstrPlutusData represents a constructor with an index and fields.
@@ -31,213 +69,338 @@ type PlutusDataMapEntry struct {
 	Value PlutusData
 }
 
+// Constructors 0-6 use tags 121-127 and 7-127 use tags 1280-1400; any
+// other index uses the general form, tag 102 wrapping [index, fields].
 const (
 	cborTagConstr0    = 121
 	cborTagConstr6    = 127
 	cborTagConstrBase = 1280
+	cborTagConstr127  = 1400
+	cborTagConstrAny  = 102
 )
 
 // NewConstrPlutusData creates a new constructor PlutusData.
 func NewConstrPlutusData(index uint64, fields ...PlutusData) PlutusData {
-	return PlutusData{Constr: &ConstrPlutusData{Index: index, Fields: fields}}
+	return PlutusData{Constr: &ConstrPlutusData{Index: index, Fields: fields}, kind: KindConstr}
 }
 
-// NewIntPlutusData creates a new integer PlutusData.
+// NewIntPlutusData creates a new integer PlutusData. A nil i encodes as 0.
 func NewIntPlutusData(i *big.Int) PlutusData {
-	return PlutusData{Integer: i}
+	return PlutusData{Integer: i, kind: KindInteger}
 }
 
-// NewBytesPlutusData creates a new bytestring PlutusData.
+// NewBytesPlutusData creates a new bytestring PlutusData. A nil b is the
+// empty bytestring.
 func NewBytesPlutusData(b []byte) PlutusData {
-	return PlutusData{ByteString: b}
+	return PlutusData{ByteString: b, kind: KindBytes}
 }
 
-// NewListPlutusData creates a new list PlutusData.
+// NewListPlutusData creates a new list PlutusData, empty without items.
 func NewListPlutusData(items ...PlutusData) PlutusData {
-	return PlutusData{List: items}
+	return PlutusData{List: items, kind: KindList}
 }
 
-// NewMapPlutusData creates a new map PlutusData.
+// NewMapPlutusData creates a new map PlutusData, empty without entries.
 func NewMapPlutusData(entries ...PlutusDataMapEntry) PlutusData {
-	return PlutusData{Map: entries}
+	return PlutusData{Map: entries, kind: KindMap}
 }
 
-// MarshalCBOR serializes PlutusData to CBOR bytes using indefinite-length arrays.
-func (p PlutusData) MarshalCBOR() ([]byte, error) {
-	return p.toCBORBytes()
+// Kind returns the kind of p.
+func (p PlutusData) Kind() PlutusDataKind {
+	switch {
+	case p.kind != KindNone:
+		return p.kind
+	case p.Constr != nil:
+		return KindConstr
+	case p.Integer != nil:
+		return KindInteger
+	case p.ByteString != nil:
+		return KindBytes
+	case p.List != nil:
+		return KindList
+	case p.Map != nil:
+		return KindMap
+	default:
+		return KindNone
+	}
 }
 
-// UnmarshalCBOR deserializes PlutusData from CBOR bytes.
-func (p *PlutusData) UnmarshalCBOR(data []byte) error {
-	dm, err := cbor.DecOptions{BigIntDec: cbor.BigIntDecodePointer}.DecMode()
-	if err != nil {
-		return err
+// AsConstr returns the constructor held by p, and whether p is one.
+func (p PlutusData) AsConstr() (*ConstrPlutusData, bool) {
+	if p.Kind() != KindConstr {
+		return nil, false
 	}
-	var raw interface{}
-	if err := dm.Unmarshal(data, &raw); err != nil {
-		return err
+	return p.Constr, true
+}
+
+// AsInteger returns the integer held by p, and whether p is one.
+func (p PlutusData) AsInteger() (*big.Int, bool) {
+	if p.Kind() != KindInteger {
+		return nil, false
 	}
-	result, err := plutusDataFromCBORValue(raw)
-	if err != nil {
-		return err
+	if p.Integer == nil {
+		return new(big.Int), true
 	}
-	*p = result
-	return nil
+	return p.Integer, true
 }
 
-func (p PlutusData) toCBORBytes() ([]byte, error) {
-	em, err := cbor.EncOptions{BigIntConvert: cbor.BigIntConvertShortest}.EncMode()
-	if err != nil {
-		return nil, err
-	}
-	switch {
-	case p.Constr != nil:
-		var buf bytes.Buffer
-		// Encode constructor tag
-		var tag uint64
-		if p.Constr.Index <= 6 {
-			tag = cborTagConstr0 + p.Constr.Index
-		} else {
-			tag = cborTagConstrBase + p.Constr.Index - 7
-		}
-		// Write CBOR tag header (minimal encoding)
-		if tag < 24 {
-			buf.WriteByte(0xc0 + byte(tag))
-		} else if tag < 256 {
-			buf.WriteByte(0xd8)
-			buf.WriteByte(byte(tag))
-		} else {
-			buf.WriteByte(0xd9)
-			buf.WriteByte(byte(tag >> 8))
-			buf.WriteByte(byte(tag))
-		}
-		// Empty arrays use definite-length encoding, non-empty use indefinite
+// AsBytes returns the bytestring held by p, and whether p is one.
+func (p PlutusData) AsBytes() ([]byte, bool) {
+	return p.ByteString, p.Kind() == KindBytes
+}
+
+// AsList returns the items of the list held by p, and whether p is one.
+func (p PlutusData) AsList() ([]PlutusData, bool) {
+	return p.List, p.Kind() == KindList
+}
+
+// AsMap returns the entries of the map held by p, and whether p is one.
+func (p PlutusData) AsMap() ([]PlutusDataMapEntry, bool) {
+	return p.Map, p.Kind() == KindMap
+}
+
+// MarshalCBOR serializes PlutusData to CBOR bytes using indefinite-length arrays.
+func (p PlutusData) MarshalCBOR() ([]byte, error) {
+	return p.AppendCBOR(nil)
+}
+
+// AppendCBOR appends the CBOR encoding of p to dst.
+func (p PlutusData) AppendCBOR(dst []byte) ([]byte, error) {
+	return appendPlutusData(dst, p), nil
+}
+
+// UnmarshalCBOR deserializes PlutusData from CBOR bytes, with the default
+// DecodeOptions.
+func (p *PlutusData) UnmarshalCBOR(data []byte) error {
+	return unmarshalPlutusCBOR(data, p.decodeCBOR)
+}
+
+func (p *PlutusData) decodeCBOR(d *plutusCBORDecoder) error {
+	return d.readData(p)
+}
+
+func appendPlutusData(dst []byte, p PlutusData) []byte {
+	switch p.Kind() {
+	case KindConstr:
+		dst = appendCBORConstr(dst, p.Constr.Index, len(p.Constr.Fields))
 		if len(p.Constr.Fields) == 0 {
-			buf.WriteByte(0x80) // empty array
-		} else {
-			buf.WriteByte(0x9f) // indefinite-length array start
-			for _, f := range p.Constr.Fields {
-				fieldBytes, err := f.toCBORBytes()
-				if err != nil {
-					return nil, err
-				}
-				buf.Write(fieldBytes)
-			}
-			buf.WriteByte(0xff) // break
+			return dst
 		}
-		return buf.Bytes(), nil
-	case p.Integer != nil:
-		return em.Marshal(p.Integer)
-	case p.ByteString != nil:
-		return em.Marshal(p.ByteString)
-	case p.List != nil:
-		var buf bytes.Buffer
-		// Write indefinite-length array start
-		buf.WriteByte(0x9f)
+		for _, f := range p.Constr.Fields {
+			dst = appendPlutusData(dst, f)
+		}
+		return append(dst, 0xff) // break
+	case KindInteger:
+		if p.Integer == nil {
+			return append(dst, 0x00)
+		}
+		return appendCBORBigInt(dst, p.Integer)
+	case KindBytes:
+		return append(appendCBORHead(dst, 2, uint64(len(p.ByteString))), p.ByteString...)
+	case KindList:
+		dst = append(dst, 0x9f) // indefinite-length array start
 		for _, item := range p.List {
-			itemBytes, err := item.toCBORBytes()
-			if err != nil {
-				return nil, err
-			}
-			buf.Write(itemBytes)
+			dst = appendPlutusData(dst, item)
 		}
-		// Write indefinite-length array end (break)
-		buf.WriteByte(0xff)
-		return buf.Bytes(), nil
-	case p.Map != nil:
-		var buf bytes.Buffer
+		return append(dst, 0xff) // break
+	case KindMap:
 		// Empty maps use definite-length, non-empty use indefinite
 		if len(p.Map) == 0 {
-			buf.WriteByte(0xa0) // empty map (definite-length)
-		} else {
-			buf.WriteByte(0xbf) // indefinite-length map start
-			for _, entry := range p.Map {
-				keyBytes, err := entry.Key.toCBORBytes()
-				if err != nil {
-					return nil, err
-				}
-				buf.Write(keyBytes)
-				valBytes, err := entry.Value.toCBORBytes()
-				if err != nil {
-					return nil, err
-				}
-				buf.Write(valBytes)
-			}
-			buf.WriteByte(0xff) // break
+			return append(dst, 0xa0)
 		}
-		return buf.Bytes(), nil
+		dst = append(dst, 0xbf) // indefinite-length map start
+		for _, entry := range p.Map {
+			dst = appendPlutusData(dst, entry.Key)
+			dst = appendPlutusData(dst, entry.Value)
+		}
+		return append(dst, 0xff) // break
 	default:
-		// Empty constructor 0
-		return []byte{0xd8, 0x79, 0x9f, 0xff}, nil
+		// The zero PlutusData is the unit constructor
+		return appendCBORConstr(dst, 0, 0)
 	}
 }
 
-func plutusDataFromCBORValue(v interface{}) (PlutusData, error) {
-	switch val := v.(type) {
-	case cbor.Tag:
-		var index uint64
-		switch {
-		case val.Number >= cborTagConstr0 && val.Number <= cborTagConstr6:
-			index = val.Number - cborTagConstr0
-		case val.Number >= cborTagConstrBase:
-			index = val.Number - cborTagConstrBase + 7
-		default:
-			return PlutusData{}, fmt.Errorf("unsupported CBOR tag: %d", val.Number)
-		}
-		content, ok := val.Content.([]interface{})
-		if !ok {
-			return PlutusData{}, errors.New("constructor content is not an array")
-		}
-		fields := make([]PlutusData, len(content))
-		for i, item := range content {
-			pd, err := plutusDataFromCBORValue(item)
-			if err != nil {
-				return PlutusData{}, err
-			}
-			fields[i] = pd
-		}
-		return PlutusData{Constr: &ConstrPlutusData{Index: index, Fields: fields}}, nil
-	case *big.Int:
-		return PlutusData{Integer: val}, nil
-	case int64:
-		return PlutusData{Integer: big.NewInt(val)}, nil
-	case uint64:
-		return PlutusData{Integer: new(big.Int).SetUint64(val)}, nil
-	case []byte:
-		return PlutusData{ByteString: val}, nil
-	case cbor.ByteString:
-		return PlutusData{ByteString: []byte(val)}, nil
-	case []interface{}:
-		items := make([]PlutusData, len(val))
-		for i, item := range val {
-			pd, err := plutusDataFromCBORValue(item)
-			if err != nil {
-				return PlutusData{}, err
-			}
-			items[i] = pd
+// appendCBORHead appends a CBOR item head with the shortest encoding of arg.
+func appendCBORHead(dst []byte, major byte, arg uint64) []byte {
+	major <<= 5
+	switch {
+	case arg < 24:
+		return append(dst, major|byte(arg))
+	case arg <= 0xff:
+		return append(dst, major|24, byte(arg))
+	case arg <= 0xffff:
+		return append(dst, major|25, byte(arg>>8), byte(arg))
+	case arg <= 0xffffffff:
+		return append(dst, major|26, byte(arg>>24), byte(arg>>16), byte(arg>>8), byte(arg))
+	default:
+		return append(dst, major|27, byte(arg>>56), byte(arg>>48), byte(arg>>40), byte(arg>>32),
+			byte(arg>>24), byte(arg>>16), byte(arg>>8), byte(arg))
+	}
+}
+
+// appendCBORConstr appends the tag and array head of a constructor with n
+// fields. Fields are written as an indefinite-length array, so callers must
+// append a break (0xff) after them when n > 0.
+func appendCBORConstr(dst []byte, index uint64, n int) []byte {
+	switch {
+	case index <= 6:
+		dst = appendCBORHead(dst, 6, cborTagConstr0+index)
+	case index <= 127:
+		dst = appendCBORHead(dst, 6, cborTagConstrBase+index-7)
+	default:
+		dst = appendCBORHead(dst, 6, cborTagConstrAny)
+		dst = appendCBORHead(append(dst, 0x82), 0, index) // [index, fields]
+	}
+	// Empty arrays use definite-length encoding, non-empty use indefinite
+	if n == 0 {
+		return append(dst, 0x80)
+	}
+	return append(dst, 0x9f)
+}
+
+// appendCBORBigInt appends i as a CBOR integer, or as a bignum (tags 2 and
+// 3) when it doesn't fit in 64 bits.
+func appendCBORBigInt(dst []byte, i *big.Int) []byte {
+	if i.Sign() >= 0 {
+		if i.IsUint64() {
+			return appendCBORHead(dst, 0, i.Uint64())
 		}
-		return PlutusData{List: items}, nil
-	case map[interface{}]interface{}:
-		entries := make([]PlutusDataMapEntry, 0, len(val))
-		for k, v := range val {
-			key, err := plutusDataFromCBORValue(k)
-			if err != nil {
-				return PlutusData{}, err
-			}
-			value, err := plutusDataFromCBORValue(v)
-			if err != nil {
-				return PlutusData{}, err
-			}
-			entries = append(entries, PlutusDataMapEntry{Key: key, Value: value})
+		b := i.Bytes()
+		return append(appendCBORHead(appendCBORHead(dst, 6, 2), 2, uint64(len(b))), b...)
+	}
+	// Negative integers encode -1 - i
+	if i.IsInt64() {
+		return appendCBORHead(dst, 1, uint64(-1-i.Int64()))
+	}
+	n := new(big.Int).Not(i)
+	if n.IsUint64() {
+		return appendCBORHead(dst, 1, n.Uint64())
+	}
+	b := n.Bytes()
+	return append(appendCBORHead(appendCBORHead(dst, 6, 3), 2, uint64(len(b))), b...)
+}
+
+// PlutusMarshaler is implemented by every generated type and enum variant.
+type PlutusMarshaler interface {
+	ToPlutusData() (PlutusData, error)
+}
+
+// PlutusUnmarshaler is implemented by pointers to every generated type.
+// FromPlutusData takes no DecodeOptions; DecodeWithOptions applies them.
+type PlutusUnmarshaler interface {
+	FromPlutusData(pd PlutusData) error
+}
+
+// CBORAppender is implemented by every generated type, including the
+// generic containers of generics mode, and by PlutusData. It writes the same
+// bytes as ToPlutusData followed by MarshalCBOR, without building the
+// intermediate PlutusData.
+type CBORAppender interface {
+	AppendCBOR(dst []byte) ([]byte, error)
+}
+
+var (
+	plutusEnumDecoders     = map[reflect.Type]func(PlutusData) (interface{}, error){}
+	plutusEnumCBORDecoders = map[reflect.Type]func(*plutusCBORDecoder) (interface{}, error){}
+)
+
+// RegisterEnum registers the factory used by Decode for the enum interface T.
+// Generated code registers every XxxFromPlutusData enum factory.
+func RegisterEnum[T any](decode func(PlutusData) (T, error)) {
+	plutusEnumDecoders[reflect.TypeOf((*T)(nil)).Elem()] = func(pd PlutusData) (interface{}, error) {
+		return decode(pd)
+	}
+}
+
+// registerEnumCBOR registers the streaming decoder used by DecodeCBOR for
+// the enum interface T.
+func registerEnumCBOR[T any](decode func(*plutusCBORDecoder, *T) error) {
+	plutusEnumCBORDecoders[reflect.TypeOf((*T)(nil)).Elem()] = func(d *plutusCBORDecoder) (interface{}, error) {
+		var v T
+		err := decode(d, &v)
+		return v, err
+	}
+}
+
+// Encode converts v to PlutusData.
+func Encode[T PlutusMarshaler](v T) (PlutusData, error) {
+	if any(v) == nil {
+		return PlutusData{}, errors.New("cannot encode nil value")
+	}
+	return v.ToPlutusData()
+}
+
+// Decode converts pd to a T. T is either a type whose pointer implements
+// PlutusUnmarshaler or a registered enum interface.
+func Decode[T any](pd PlutusData) (T, error) {
+	var v T
+	if err := decodePlutusInto(&v, pd); err != nil {
+		var zero T
+		return zero, err
+	}
+	return v, nil
+}
+
+// EncodeCBOR converts v to CBOR bytes. Generated types are encoded straight
+// to CBOR.
+func EncodeCBOR[T PlutusMarshaler](v T) ([]byte, error) {
+	if a, ok := any(v).(CBORAppender); ok {
+		return a.AppendCBOR(nil)
+	}
+	pd, err := Encode(v)
+	if err != nil {
+		return nil, err
+	}
+	return pd.MarshalCBOR()
+}
+
+// DecodeCBOR converts CBOR bytes to a T. See Decode. Generated types and
+// enums are decoded straight from the CBOR bytes.
+func DecodeCBOR[T any](data []byte) (T, error) {
+	return DecodeCBORWithOptions[T](data, DecodeOptions{})
+}
+
+// DecodeCBORWithOptions is DecodeCBOR with the given options.
+func DecodeCBORWithOptions[T any](data []byte, opts DecodeOptions) (T, error) {
+	var v T
+	if err := opts.unmarshal(data, func(d *plutusCBORDecoder) error { return readCBORAny(d, &v) }); err != nil {
+		var zero T
+		return zero, err
+	}
+	return v, nil
+}
+
+// DecodeWithOptions is Decode with the given options. pd is checked as if it
+// had been decoded from its CBOR encoding.
+func DecodeWithOptions[T any](pd PlutusData, opts DecodeOptions) (T, error) {
+	return DecodeCBORWithOptions[T](appendPlutusData(nil, pd), opts)
+}
+
+func decodePlutusInto[T any](dst *T, pd PlutusData) error {
+	if u, ok := any(dst).(PlutusUnmarshaler); ok {
+		return u.FromPlutusData(pd)
+	}
+	if decode, ok := plutusEnumDecoders[reflect.TypeOf(dst).Elem()]; ok {
+		v, err := decode(pd)
+		if err != nil {
+			return err
 		}
-		return PlutusData{Map: entries}, nil
-	case nil:
-		// CBOR null - not standard PlutusData, but some serializers use it
-		// Treat as Void/Unit (constructor 0 with no fields)
-		return PlutusData{Constr: &ConstrPlutusData{Index: 0, Fields: []PlutusData{}}}, nil
-	default:
-		return PlutusData{}, fmt.Errorf("unsupported CBOR type: %T", v)
+		*dst = v.(T)
+		return nil
 	}
+	return fmt.Errorf("%T cannot be decoded from PlutusData", *dst)
+}
+
+// ToPlutusData returns p itself, so that raw PlutusData can be used wherever
+// a generated type is expected.
+func (p PlutusData) ToPlutusData() (PlutusData, error) {
+	return p, nil
+}
+
+// FromPlutusData stores pd into p.
+func (p *PlutusData) FromPlutusData(pd PlutusData) error {
+	*p = pd
+	return nil
 }
 
 // ToHex returns the CBOR encoding as a hex string.
@@ -263,23 +426,1284 @@ func (p PlutusData) Equals(other PlutusData) bool {
 }
 
 func plutusDataTypeString(pd PlutusData) string {
-	switch {
-	case pd.Constr != nil:
+	if pd.Kind() == KindConstr {
 		return fmt.Sprintf("constructor(%d)", pd.Constr.Index)
-	case pd.Integer != nil:
+	}
+	return pd.Kind().String()
+}
+
+// DecodeError describes where and why decoding PlutusData into a Go value
+// failed. Generated decoders return one for every error, adding to Path as it
+// travels out through fields, items, entries and Option values, so it can be
+// matched with errors.As.
+type DecodeError struct {
+	Path     string // location in the decoded value, e.g. "Payouts[3].Beneficiary.Some"
+	Type     string // Go type being decoded at Path, when known
+	Expected string // expected PlutusData kind, for a value of the wrong kind
+	Actual   string // actual PlutusData kind
+
+	// ExpectedIndex holds the constructor indices valid at Path and
+	// ActualIndex the one found, for an unknown constructor index.
+	ExpectedIndex []uint64
+	ActualIndex   uint64
+
+	Err error // underlying error, for other failures
+}
+
+func (e *DecodeError) Error() string {
+	var msg string
+	switch {
+	case len(e.ExpectedIndex) > 0:
+		expected := make([]string, len(e.ExpectedIndex))
+		for i, index := range e.ExpectedIndex {
+			expected[i] = fmt.Sprint(index)
+		}
+		want := expected[0]
+		if len(expected) > 1 {
+			want = "one of " + strings.Join(expected, ", ")
+		}
+		msg = fmt.Sprintf("wrong constructor index%s: expected %s, got %d", e.forType(), want, e.ActualIndex)
+	case e.Expected != "":
+		msg = fmt.Sprintf("expected %s%s, got %s", e.Expected, e.forType(), e.Actual)
+	case e.Type != "":
+		msg = fmt.Sprintf("%s: %v", e.Type, e.Err)
+	default:
+		msg = fmt.Sprint(e.Err)
+	}
+	if e.Path == "" {
+		return msg
+	}
+	return e.Path + ": " + msg
+}
+
+func (e *DecodeError) forType() string {
+	if e.Type == "" {
+		return ""
+	}
+	return " for " + e.Type
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// decodeKindError reports a value of the wrong kind for typ.
+func decodeKindError(typ, expected string, pd PlutusData) error {
+	return &DecodeError{Type: typ, Expected: expected, Actual: plutusDataTypeString(pd)}
+}
+
+// decodeIndexError reports an unknown constructor index for typ.
+func decodeIndexError(typ string, got uint64, expected ...uint64) error {
+	return &DecodeError{Type: typ, ExpectedIndex: expected, ActualIndex: got}
+}
+
+// decodeCountError reports a wrong number of constructor fields or tuple
+// items for typ.
+func decodeCountError(typ, what string, expected, got int) error {
+	return &DecodeError{Type: typ, Err: fmt.Errorf("wrong number of %s: expected %d, got %d", what, expected, got)}
+}
+
+// decodeErrorIn sets the type of an error raised while decoding typ, unless
+// a nested decoder already did.
+func decodeErrorIn(typ string, err error) error {
+	if de, ok := err.(*DecodeError); ok {
+		if de.Type == "" && de.Path == "" {
+			de.Type = typ
+		}
+		return de
+	}
+	return &DecodeError{Type: typ, Err: err}
+}
+
+// decodeErrorAt adds path in front of the path of err. Elements are field
+// names, "Some", "Key", "Value" or pathItem indices.
+func decodeErrorAt(err error, path ...string) error {
+	de, ok := err.(*DecodeError)
+	if !ok {
+		de = &DecodeError{Err: err}
+	}
+	de.Path = prependPath(path, de.Path)
+	return de
+}
+
+// prependPath adds the elements in front of path, with a dot before each
+// one but indices.
+func prependPath(elems []string, path string) string {
+	for i := len(elems) - 1; i >= 0; i-- {
+		switch {
+		case path == "":
+			path = elems[i]
+		case path[0] == '[':
+			path = elems[i] + path
+		default:
+			path = elems[i] + "." + path
+		}
+	}
+	return path
+}
+
+// pathItem is the path element of list item or map entry i.
+func pathItem(i int) string {
+	return fmt.Sprintf("[%d]", i)
+}
+
+// ValidationError is returned by Validate for a value that is unset: a nil
+// *big.Int, enum or list. ToPlutusData and AppendCBOR return it for the
+// nil *big.Int and enum values they can't encode.
+type ValidationError struct {
+	// Path locates the value from the validated one, in the format of
+	// DecodeError.Path, e.g. "Permissions.Reorganize.Scripts[1].Required".
+	// Map values are located by their key instead of their index.
+	Path string
+	Err  error
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// validationErrorAt adds path in front of the path of err, as
+// decodeErrorAt does.
+func validationErrorAt(err error, path ...string) error {
+	ve, ok := err.(*ValidationError)
+	if !ok {
+		ve = &ValidationError{Err: err}
+	}
+	ve.Path = prependPath(path, ve.Path)
+	return ve
+}
+
+// nilValueError reports a nil value where one of type expected must be set.
+func nilValueError(expected string) error {
+	return &ValidationError{Err: fmt.Errorf("value is nil (expected %s)", expected)}
+}
+
+// pathKey is the path element of the entry of a Go map with key k: byte
+// string keys, held as strings, are written in hex.
+func pathKey(k any) string {
+	if s, ok := k.(string); ok {
+		return fmt.Sprintf("[%x]", s)
+	}
+	return fmt.Sprintf("[%v]", k)
+}
+
+// Default decoding limits, used when the corresponding DecodeOptions field is
+// zero.
+const (
+	DefaultMaxDepth = 128
+	DefaultMaxItems = 1 << 20
+	DefaultMaxBytes = 16 << 20
+)
+
+// DecodeOptions configures decoding from CBOR. The zero value is the lenient
+// mode with the default limits used by UnmarshalCBOR and DecodeCBOR.
+type DecodeOptions struct {
+	// Strict rejects CBOR that isn't canonical PlutusData: null, non-minimal
+	// integer, length and tag encodings, tag 102 for constructors 0-127 and
+	// bignums that fit in 64 bits. Floats, text strings, unknown tags,
+	// trailing bytes, Bool constructors other than 0 and 1, and fields on
+	// constructors that have none are rejected in both modes.
+	Strict bool
+
+	// MaxDepth limits the nesting of lists, maps and constructors.
+	MaxDepth int
+	// MaxItems limits the total number of list items, map entries,
+	// constructor fields and chunks of indefinite-length byte strings.
+	MaxItems int
+	// MaxBytes limits the size of the input, and so of every byte string
+	// and bignum in it.
+	MaxBytes int
+	// Zero limits use the defaults above; negative limits are disabled.
+}
+
+// DecodeLimitError is returned when the input exceeds a limit of
+// DecodeOptions.
+type DecodeLimitError struct {
+	Limit string // "MaxDepth", "MaxItems" or "MaxBytes"
+	Max   int
+}
+
+func (e *DecodeLimitError) Error() string {
+	return fmt.Sprintf("CBOR input exceeds %s of %d", e.Limit, e.Max)
+}
+
+// limit returns the effective value of a limit field.
+func (o DecodeOptions) limit(v, def int) int {
+	if v == 0 {
+		return def
+	}
+	return v
+}
+
+// plutusCBORDecoder reads PlutusData CBOR items straight from the input
+// bytes. Generated decodeCBOR methods use it to fill Go values without
+// building an intermediate PlutusData tree.
+type plutusCBORDecoder struct {
+	data []byte
+	pos  int
+	opts DecodeOptions
+
+	depth, maxDepth int
+	items, maxItems int
+}
+
+// cborSeq tracks the remaining items of a CBOR array or map.
+type cborSeq struct {
+	remaining  uint64
+	indefinite bool
+	open       bool // counted in the decoder depth
+}
+
+// unmarshalPlutusCBOR decodes a single CBOR item from data with decode and
+// rejects trailing bytes.
+func unmarshalPlutusCBOR(data []byte, decode func(*plutusCBORDecoder) error) error {
+	return DecodeOptions{}.unmarshal(data, decode)
+}
+
+func (o DecodeOptions) unmarshal(data []byte, decode func(*plutusCBORDecoder) error) error {
+	if maxBytes := o.limit(o.MaxBytes, DefaultMaxBytes); maxBytes > 0 && len(data) > maxBytes {
+		return &DecodeLimitError{Limit: "MaxBytes", Max: maxBytes}
+	}
+	d := &plutusCBORDecoder{
+		data:     data,
+		opts:     o,
+		maxDepth: o.limit(o.MaxDepth, DefaultMaxDepth),
+		maxItems: o.limit(o.MaxItems, DefaultMaxItems),
+	}
+	if err := decode(d); err != nil {
+		return err
+	}
+	if d.pos != len(d.data) {
+		return fmt.Errorf("%d bytes of extraneous data after CBOR item", len(d.data)-d.pos)
+	}
+	return nil
+}
+
+func (d *plutusCBORDecoder) readHead() (major byte, info byte, arg uint64, err error) {
+	if d.pos >= len(d.data) {
+		return 0, 0, 0, errors.New("unexpected end of CBOR data")
+	}
+	b := d.data[d.pos]
+	d.pos++
+	major, info = b>>5, b&0x1f
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info <= 27:
+		n := 1 << (info - 24)
+		if len(d.data)-d.pos < n {
+			return 0, 0, 0, errors.New("unexpected end of CBOR data")
+		}
+		for _, c := range d.data[d.pos : d.pos+n] {
+			arg = arg<<8 | uint64(c)
+		}
+		d.pos += n
+		// Major type 7 holds floats, which are rejected by the callers
+		if d.opts.Strict && major != 7 && (info == 24 && arg < 24 || info > 24 && arg < 1<<(8*(n/2))) {
+			return 0, 0, 0, fmt.Errorf("non-minimal encoding of %d", arg)
+		}
+		return major, info, arg, nil
+	case info == 31:
+		return major, info, 0, nil
+	default:
+		return 0, 0, 0, fmt.Errorf("invalid CBOR additional info %d", info)
+	}
+}
+
+// kindAt describes the item starting at pos for error messages.
+func (d *plutusCBORDecoder) kindAt(pos int) string {
+	tmp := plutusCBORDecoder{data: d.data, pos: pos}
+	major, info, arg, err := tmp.readHead()
+	if err != nil {
+		return "invalid CBOR"
+	}
+	switch major {
+	case 0, 1:
 		return "integer"
-	case pd.ByteString != nil:
+	case 2:
 		return "bytes"
-	case pd.List != nil:
+	case 3:
+		return "text string"
+	case 4:
 		return "list"
-	case pd.Map != nil:
+	case 5:
 		return "map"
+	case 6:
+		if index, ok := constrIndexFromTag(arg); ok {
+			return fmt.Sprintf("constructor(%d)", index)
+		}
+		if arg == cborTagConstrAny {
+			if index, err := tmp.readConstrAny(); err == nil {
+				return fmt.Sprintf("constructor(%d)", index)
+			}
+			return "constructor"
+		}
+		if arg == 2 || arg == 3 {
+			return "integer"
+		}
+		return fmt.Sprintf("tag %d", arg)
 	default:
-		return "null"
+		switch info {
+		case 22:
+			return "null"
+		case 25, 26, 27:
+			return "float"
+		}
+		return "simple value"
+	}
+}
+
+func constrIndexFromTag(tag uint64) (uint64, bool) {
+	switch {
+	case tag >= cborTagConstr0 && tag <= cborTagConstr6:
+		return tag - cborTagConstr0, true
+	case tag >= cborTagConstrBase && tag <= cborTagConstr127:
+		return tag - cborTagConstrBase + 7, true
+	default:
+		return 0, false
+	}
+}
+
+func (d *plutusCBORDecoder) readSeqHead(major byte, what string) (cborSeq, error) {
+	start := d.pos
+	m, info, arg, err := d.readHead()
+	if err != nil {
+		return cborSeq{}, err
+	}
+	if m != major {
+		return cborSeq{}, &DecodeError{Expected: what, Actual: d.kindAt(start)}
+	}
+	if d.depth++; d.maxDepth > 0 && d.depth > d.maxDepth {
+		return cborSeq{}, &DecodeLimitError{Limit: "MaxDepth", Max: d.maxDepth}
+	}
+	return cborSeq{remaining: arg, indefinite: info == 31, open: true}, nil
+}
+
+// readList starts reading a list.
+func (d *plutusCBORDecoder) readList() (cborSeq, error) {
+	return d.readSeqHead(4, "list")
+}
+
+// readConstr starts reading a constructor and returns its index.
+func (d *plutusCBORDecoder) readConstr() (uint64, cborSeq, error) {
+	start := d.pos
+	major, info, tag, err := d.readHead()
+	if err != nil {
+		return 0, cborSeq{}, err
 	}
+	if major == 7 && info == 22 && !d.opts.Strict {
+		// CBOR null - not standard PlutusData, but some serializers use it
+		// Treat as Void/Unit (constructor 0 with no fields)
+		return 0, cborSeq{}, nil
+	}
+	index, ok := constrIndexFromTag(tag)
+	if major == 6 && tag == cborTagConstrAny {
+		if index, err = d.readConstrAny(); err != nil {
+			return 0, cborSeq{}, err
+		}
+		ok = true
+	}
+	if major != 6 || !ok {
+		return 0, cborSeq{}, &DecodeError{Expected: "constructor", Actual: d.kindAt(start)}
+	}
+	seq, err := d.readList()
+	if err != nil {
+		return 0, cborSeq{}, fmt.Errorf("constructor fields: %w", err)
+	}
+	return index, seq, nil
+}
+
+// readConstrAny reads the array head and index of a constructor in the
+// general form, tag 102 wrapping [index, fields], leaving the fields to read.
+func (d *plutusCBORDecoder) readConstrAny() (uint64, error) {
+	major, info, n, err := d.readHead()
+	if err != nil {
+		return 0, err
+	}
+	if major != 4 || info == 31 || n != 2 {
+		return 0, errors.New("constructor tag 102 must wrap a 2-element array")
+	}
+	start := d.pos
+	major, info, index, err := d.readHead()
+	if err != nil {
+		return 0, err
+	}
+	if major != 0 || info == 31 {
+		return 0, &DecodeError{Expected: "constructor index", Actual: d.kindAt(start)}
+	}
+	if d.opts.Strict && index <= 127 {
+		return 0, fmt.Errorf("non-canonical tag 102 for constructor %d", index)
+	}
+	return index, nil
+}
+
+// expectConstr starts reading a constructor with the given index.
+func (d *plutusCBORDecoder) expectConstr(index uint64) (cborSeq, error) {
+	got, seq, err := d.readConstr()
+	if err != nil {
+		return cborSeq{}, err
+	}
+	if got != index {
+		return cborSeq{}, decodeIndexError("", got, index)
+	}
+	return seq, nil
+}
+
+// peekConstrIndex returns the index of the next constructor without
+// consuming it.
+func (d *plutusCBORDecoder) peekConstrIndex() (uint64, error) {
+	start, depth := d.pos, d.depth
+	defer func() { d.pos, d.depth = start, depth }()
+	index, _, err := d.readConstr()
+	return index, err
+}
+
+// more reports whether seq has another item, consuming the break of an
+// indefinite-length sequence.
+func (d *plutusCBORDecoder) more(seq *cborSeq) (bool, error) {
+	if seq.indefinite {
+		if d.pos >= len(d.data) {
+			return false, errors.New("unexpected end of CBOR data")
+		}
+		if d.data[d.pos] == 0xff {
+			d.pos++
+			seq.indefinite = false
+			d.close(seq)
+			return false, nil
+		}
+	} else if seq.remaining == 0 {
+		d.close(seq)
+		return false, nil
+	} else {
+		seq.remaining--
+	}
+	if d.items++; d.maxItems > 0 && d.items > d.maxItems {
+		return false, &DecodeLimitError{Limit: "MaxItems", Max: d.maxItems}
+	}
+	return true, nil
+}
+
+// close leaves the nesting level of a finished seq.
+func (d *plutusCBORDecoder) close(seq *cborSeq) {
+	if seq.open {
+		seq.open = false
+		d.depth--
+	}
+}
+
+// end checks that seq has no items left.
+func (d *plutusCBORDecoder) end(seq *cborSeq) error {
+	more, err := d.more(seq)
+	if err != nil {
+		return err
+	}
+	if more {
+		return errors.New("too many items")
+	}
+	return nil
+}
+
+// sizeHint returns a safe capacity for the items of seq.
+func (d *plutusCBORDecoder) sizeHint(seq cborSeq) int {
+	if seq.indefinite {
+		return 0
+	}
+	// Every item takes at least one byte
+	if left := uint64(len(d.data) - d.pos); seq.remaining > left {
+		return int(left)
+	}
+	return int(seq.remaining)
+}
+
+func (d *plutusCBORDecoder) readInt(dst **big.Int) error {
+	start := d.pos
+	major, _, arg, err := d.readHead()
+	if err != nil {
+		return err
+	}
+	switch {
+	case major == 0:
+		*dst = new(big.Int).SetUint64(arg)
+		return nil
+	case major == 1:
+		n := new(big.Int).SetUint64(arg)
+		*dst = n.Not(n) // -1 - arg
+		return nil
+	case major == 6 && (arg == 2 || arg == 3):
+		var b []byte
+		if err := d.readBytes(&b); err != nil {
+			return fmt.Errorf("bignum: %w", err)
+		}
+		// Bignums are only canonical for values that don't fit in 64 bits
+		if d.opts.Strict && (len(b) <= 8 || b[0] == 0) {
+			return errors.New("non-minimal bignum")
+		}
+		n := new(big.Int).SetBytes(b)
+		if arg == 3 {
+			n.Not(n)
+		}
+		*dst = n
+		return nil
+	default:
+		return &DecodeError{Type: "*big.Int", Expected: "integer", Actual: d.kindAt(start)}
+	}
+}
+
+func (d *plutusCBORDecoder) readBytes(dst *[]byte) error {
+	start := d.pos
+	major, info, arg, err := d.readHead()
+	if err != nil {
+		return err
+	}
+	if major != 2 {
+		return &DecodeError{Type: "[]byte", Expected: "bytes", Actual: d.kindAt(start)}
+	}
+	if info != 31 {
+		if arg > uint64(len(d.data)-d.pos) {
+			return errors.New("unexpected end of CBOR data")
+		}
+		b := make([]byte, arg)
+		copy(b, d.data[d.pos:])
+		d.pos += int(arg)
+		*dst = b
+		return nil
+	}
+	// Indefinite-length byte string: a sequence of definite-length chunks,
+	// each counted as an item
+	b := []byte{}
+	for {
+		if d.pos >= len(d.data) {
+			return errors.New("unexpected end of CBOR data")
+		}
+		if d.data[d.pos] == 0xff {
+			d.pos++
+			*dst = b
+			return nil
+		}
+		if d.items++; d.maxItems > 0 && d.items > d.maxItems {
+			return &DecodeLimitError{Limit: "MaxItems", Max: d.maxItems}
+		}
+		major, info, arg, err := d.readHead()
+		if err != nil {
+			return fmt.Errorf("byte string chunk: %w", err)
+		}
+		if major != 2 || info == 31 {
+			return errors.New("byte string chunk: expected definite-length bytes")
+		}
+		if arg > uint64(len(d.data)-d.pos) {
+			return errors.New("byte string chunk: unexpected end of CBOR data")
+		}
+		b = append(b, d.data[d.pos:d.pos+int(arg)]...)
+		d.pos += int(arg)
+	}
+}
+
+// readBytesString reads bytes into a string, for byte string map keys.
+func (d *plutusCBORDecoder) readBytesString(dst *string) error {
+	var b []byte
+	if err := d.readBytes(&b); err != nil {
+		return err
+	}
+	*dst = string(b)
+	return nil
+}
+
+func (d *plutusCBORDecoder) readBool(dst *bool) error {
+	index, seq, err := d.readConstr()
+	if err != nil {
+		return decodeErrorIn("bool", err)
+	}
+	if index > 1 {
+		return decodeIndexError("bool", index, 0, 1)
+	}
+	if err := d.end(&seq); err != nil {
+		return decodeErrorIn("bool", err)
+	}
+	*dst = index == 1
+	return nil
+}
+
+func (d *plutusCBORDecoder) readVoid(*struct{}) error {
+	seq, err := d.expectConstr(0)
+	if err != nil {
+		return err
+	}
+	return d.end(&seq)
+}
+
+// readData reads any PlutusData value.
+func (d *plutusCBORDecoder) readData(dst *PlutusData) error {
+	start := d.pos
+	major, info, arg, err := d.readHead()
+	if err != nil {
+		return err
+	}
+	switch {
+	case major == 0 || major == 1 || (major == 6 && (arg == 2 || arg == 3)):
+		d.pos = start
+		var n *big.Int
+		if err := d.readInt(&n); err != nil {
+			return err
+		}
+		*dst = NewIntPlutusData(n)
+		return nil
+	case major == 2:
+		d.pos = start
+		var b []byte
+		if err := d.readBytes(&b); err != nil {
+			return err
+		}
+		*dst = NewBytesPlutusData(b)
+		return nil
+	case major == 4:
+		d.pos = start
+		var items []PlutusData
+		if err := readCBORList(d, &items, (*plutusCBORDecoder).readData); err != nil {
+			return err
+		}
+		*dst = NewListPlutusData(items...)
+		return nil
+	case major == 5:
+		d.pos = start
+		seq, err := d.readSeqHead(5, "map")
+		if err != nil {
+			return err
+		}
+		entries := make([]PlutusDataMapEntry, 0, d.sizeHint(seq))
+		for {
+			more, err := d.more(&seq)
+			if err != nil {
+				return err
+			}
+			if !more {
+				break
+			}
+			var entry PlutusDataMapEntry
+			if err := d.readData(&entry.Key); err != nil {
+				return fmt.Errorf("map key: %w", err)
+			}
+			if err := d.readData(&entry.Value); err != nil {
+				return fmt.Errorf("map value: %w", err)
+			}
+			entries = append(entries, entry)
+		}
+		*dst = NewMapPlutusData(entries...)
+		return nil
+	case major == 6 || (major == 7 && info == 22):
+		d.pos = start
+		index, seq, err := d.readConstr()
+		if err != nil {
+			return err
+		}
+		fields := make([]PlutusData, 0, d.sizeHint(seq))
+		for {
+			more, err := d.more(&seq)
+			if err != nil {
+				return err
+			}
+			if !more {
+				break
+			}
+			var field PlutusData
+			if err := d.readData(&field); err != nil {
+				return err
+			}
+			fields = append(fields, field)
+		}
+		*dst = NewConstrPlutusData(index, fields...)
+		return nil
+	default:
+		return fmt.Errorf("unsupported CBOR type: %s", d.kindAt(start))
+	}
+}
+
+// readCBORField reads the next item of seq into dst.
+func readCBORField[T any](d *plutusCBORDecoder, seq *cborSeq, dst *T, decode func(*plutusCBORDecoder, *T) error) error {
+	more, err := d.more(seq)
+	if err != nil {
+		return err
+	}
+	if !more {
+		return errors.New("missing item")
+	}
+	return decode(d, dst)
+}
+
+// localTypeName renders t the way generated code spells it, dropping the
+// qualifier of the package the runtime was embedded into.
+func localTypeName(t reflect.Type) string {
+	self := reflect.TypeOf(DecodeError{})
+	// Type arguments are qualified by the package path
+	name := strings.ReplaceAll(t.String(), self.PkgPath()+".", "")
+	return strings.ReplaceAll(name, strings.TrimSuffix(self.String(), "DecodeError"), "")
+}
+
+func readCBORList[T any](d *plutusCBORDecoder, dst *[]T, decodeItem func(*plutusCBORDecoder, *T) error) error {
+	seq, err := d.readList()
+	if err != nil {
+		return decodeErrorIn(localTypeName(reflect.TypeOf(dst).Elem()), err)
+	}
+	items := make([]T, 0, d.sizeHint(seq))
+	for i := 0; ; i++ {
+		more, err := d.more(&seq)
+		if err != nil {
+			return err
+		}
+		if !more {
+			break
+		}
+		var item T
+		if err := decodeItem(d, &item); err != nil {
+			return decodeErrorAt(err, pathItem(i))
+		}
+		items = append(items, item)
+	}
+	*dst = items
+	return nil
+}
+
+func readCBORMap[K comparable, V any](d *plutusCBORDecoder, dst *map[K]V, decodeKey func(*plutusCBORDecoder, *K) error, decodeValue func(*plutusCBORDecoder, *V) error) error {
+	seq, err := d.readSeqHead(5, "map")
+	if err != nil {
+		return decodeErrorIn(localTypeName(reflect.TypeOf(dst).Elem()), err)
+	}
+	m := make(map[K]V, d.sizeHint(seq))
+	for i := 0; ; i++ {
+		more, err := d.more(&seq)
+		if err != nil {
+			return err
+		}
+		if !more {
+			break
+		}
+		var key K
+		if err := decodeKey(d, &key); err != nil {
+			return decodeErrorAt(err, pathItem(i), "Key")
+		}
+		var value V
+		if err := decodeValue(d, &value); err != nil {
+			return decodeErrorAt(err, pathItem(i), "Value")
+		}
+		m[key] = value
+	}
+	*dst = m
+	return nil
+}
+
+// readCBOROption reads an Option as its Value and IsSet fields.
+func readCBOROption[T any](d *plutusCBORDecoder, value *T, isSet *bool, decode func(*plutusCBORDecoder, *T) error) error {
+	index, seq, err := d.readConstr()
+	if err != nil {
+		return err
+	}
+	switch index {
+	case 0: // Some
+		if err := readCBORField(d, &seq, value, decode); err != nil {
+			return decodeErrorAt(err, "Some")
+		}
+		*isSet = true
+	case 1: // None
+		var zero T
+		*value, *isSet = zero, false
+	default:
+		return decodeIndexError("", index, 0, 1)
+	}
+	return d.end(&seq)
+}
+
+// readCBORValue reads a generated type through its decodeCBOR method.
+func readCBORValue[T any, P interface {
+	*T
+	decodeCBOR(*plutusCBORDecoder) error
+}](d *plutusCBORDecoder, dst *T) error {
+	return P(dst).decodeCBOR(d)
+}
+
+// readCBORAny reads a T chosen at run time: a type with a decodeCBOR method,
+// a registered enum interface or a PlutusUnmarshaler. DecodeCBOR and the
+// generic container types use it.
+func readCBORAny[T any](d *plutusCBORDecoder, dst *T) error {
+	if u, ok := any(dst).(interface {
+		decodeCBOR(*plutusCBORDecoder) error
+	}); ok {
+		return u.decodeCBOR(d)
+	}
+	if decode, ok := plutusEnumCBORDecoders[reflect.TypeOf(dst).Elem()]; ok {
+		v, err := decode(d)
+		if err != nil {
+			return err
+		}
+		*dst = v.(T)
+		return nil
+	}
+	if u, ok := any(dst).(PlutusUnmarshaler); ok {
+		return readCBORViaPlutusData(d, u)
+	}
+	return fmt.Errorf("%T cannot be decoded from PlutusData", *dst)
+}
+
+// readCBORViaPlutusData reads a PlutusData tree and decodes it with
+// FromPlutusData, for types without a streaming decoder.
+func readCBORViaPlutusData(d *plutusCBORDecoder, dst PlutusUnmarshaler) error {
+	var pd PlutusData
+	if err := d.readData(&pd); err != nil {
+		return err
+	}
+	return dst.FromPlutusData(pd)
+}
+
+// appendCBORInt appends an integer. A nil *big.Int is an error, as in
+// ToPlutusData.
+func appendCBORInt(dst []byte, v *big.Int) ([]byte, error) {
+	if v == nil {
+		return nil, nilValueError("*big.Int")
+	}
+	return appendPlutusData(dst, NewIntPlutusData(v)), nil
+}
+
+// appendCBORBytes appends a byte string. A nil slice is the empty
+// bytestring.
+func appendCBORBytes(dst []byte, v []byte) ([]byte, error) {
+	return append(appendCBORHead(dst, 2, uint64(len(v))), v...), nil
+}
+
+// appendCBORBytesString appends a map key stored as a string.
+func appendCBORBytesString(dst []byte, v string) ([]byte, error) {
+	return append(appendCBORHead(dst, 2, uint64(len(v))), v...), nil
+}
+
+// appendCBORBool appends a Bool: constructor 0 is False, 1 is True.
+func appendCBORBool(dst []byte, v bool) ([]byte, error) {
+	if v {
+		return appendCBORConstr(dst, 1, 0), nil
+	}
+	return appendCBORConstr(dst, 0, 0), nil
+}
+
+// appendCBORVoid appends the Void value, constructor 0 without fields.
+func appendCBORVoid(dst []byte, _ struct{}) ([]byte, error) {
+	return appendCBORConstr(dst, 0, 0), nil
+}
+
+// appendCBORList appends v as an indefinite-length array.
+func appendCBORList[T any](dst []byte, v []T, appendItem func([]byte, T) ([]byte, error)) ([]byte, error) {
+	dst = append(dst, 0x9f)
+	for i, item := range v {
+		var err error
+		if dst, err = appendItem(dst, item); err != nil {
+			return nil, validationErrorAt(err, pathItem(i))
+		}
+	}
+	return append(dst, 0xff), nil
+}
+
+// appendCBORMap appends m with its entries sorted by their encoded key, as
+// sortPlutusMapEntries does for generated ToPlutusData methods.
+func appendCBORMap[K comparable, V any](dst []byte, m map[K]V, appendKey func([]byte, K) ([]byte, error), appendValue func([]byte, V) ([]byte, error)) ([]byte, error) {
+	if len(m) == 0 {
+		return append(dst, 0xa0), nil
+	}
+	var err error
+	if len(m) == 1 {
+		dst = append(dst, 0xbf)
+		for k, v := range m {
+			if dst, err = appendKey(dst, k); err != nil {
+				return nil, validationErrorAt(err, pathKey(k), "Key")
+			}
+			if dst, err = appendValue(dst, v); err != nil {
+				return nil, validationErrorAt(err, pathKey(k), "Value")
+			}
+		}
+		return append(dst, 0xff), nil
+	}
+	// Encode the entries after dst, then copy them back in order
+	start := len(dst)
+	entries := make([]cborMapEntry, 0, len(m))
+	for k, v := range m {
+		entry := cborMapEntry{start: len(dst) - start}
+		if dst, err = appendKey(dst, k); err != nil {
+			return nil, validationErrorAt(err, pathKey(k), "Key")
+		}
+		entry.keyEnd = len(dst) - start
+		if dst, err = appendValue(dst, v); err != nil {
+			return nil, validationErrorAt(err, pathKey(k), "Value")
+		}
+		entry.end = len(dst) - start
+		entries = append(entries, entry)
+	}
+	encoded := append([]byte(nil), dst[start:]...)
+	less := func(a, b cborMapEntry) bool {
+		return bytes.Compare(encoded[a.start:a.keyEnd], encoded[b.start:b.keyEnd]) < 0
+	}
+	if len(entries) <= 12 {
+		// Insertion sort avoids the allocations of sort.Slice on small maps
+		for i := 1; i < len(entries); i++ {
+			for j := i; j > 0 && less(entries[j], entries[j-1]); j-- {
+				entries[j], entries[j-1] = entries[j-1], entries[j]
+			}
+		}
+	} else {
+		sort.Slice(entries, func(i, j int) bool { return less(entries[i], entries[j]) })
+	}
+	dst = append(dst[:start], 0xbf)
+	for _, entry := range entries {
+		dst = append(dst, encoded[entry.start:entry.end]...)
+	}
+	return append(dst, 0xff), nil
+}
+
+// cborMapEntry locates an encoded map entry relative to the start of the map.
+type cborMapEntry struct {
+	start, keyEnd, end int
+}
+
+// sortPlutusMapEntries sorts entries built from a Go map by their encoded
+// key, so that ToPlutusData is deterministic.
+func sortPlutusMapEntries(entries []PlutusDataMapEntry) {
+	if len(entries) < 2 {
+		return
+	}
+	keys := make([][]byte, len(entries))
+	for i, entry := range entries {
+		keys[i] = appendPlutusData(nil, entry.Key)
+	}
+	sort.Sort(plutusMapEntriesByKey{entries, keys})
+}
+
+type plutusMapEntriesByKey struct {
+	entries []PlutusDataMapEntry
+	keys    [][]byte
+}
+
+func (s plutusMapEntriesByKey) Len() int           { return len(s.entries) }
+func (s plutusMapEntriesByKey) Less(i, j int) bool { return bytes.Compare(s.keys[i], s.keys[j]) < 0 }
+func (s plutusMapEntriesByKey) Swap(i, j int) {
+	s.entries[i], s.entries[j] = s.entries[j], s.entries[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
+
+// appendCBOROption appends an Option: Some is constructor 0 with the value,
+// None is constructor 1.
+func appendCBOROption[T any](dst []byte, value T, isSet bool, appendValue func([]byte, T) ([]byte, error)) ([]byte, error) {
+	if !isSet {
+		return appendCBORConstr(dst, 1, 0), nil
+	}
+	dst, err := appendValue(appendCBORConstr(dst, 0, 1), value)
+	if err != nil {
+		return nil, err
+	}
+	return append(dst, 0xff), nil
+}
+
+// appendCBORValue appends a generated type through its AppendCBOR method.
+func appendCBORValue[T CBORAppender](dst []byte, v T) ([]byte, error) {
+	return v.AppendCBOR(dst)
+}
+
+// appendCBOREnum appends a value of the enum interface T, which must not be
+// nil.
+func appendCBOREnum[T CBORAppender](dst []byte, v T) ([]byte, error) {
+	if any(v) == nil {
+		return nil, nilValueError(reflect.TypeOf((*T)(nil)).Elem().Name())
+	}
+	return v.AppendCBOR(dst)
+}
+
+// appendCBORViaPlutusData encodes v with ToPlutusData, for types without a
+// direct encoder.
+func appendCBORViaPlutusData(dst []byte, v PlutusMarshaler) ([]byte, error) {
+	pd, err := v.ToPlutusData()
+	if err != nil {
+		return nil, err
+	}
+	return appendPlutusData(dst, pd), nil
+}
+
+// PlutusData encoders and decoders, the counterparts of the appendCBOR and
+// readCBOR functions for ToPlutusData and FromPlutusData. Generated types
+// whose values nest containers compose them.
+
+func encodePlutusInt(v *big.Int) (PlutusData, error) {
+	if v == nil {
+		return PlutusData{}, nilValueError("*big.Int")
+	}
+	return NewIntPlutusData(v), nil
+}
+
+func encodePlutusBytes(v []byte) (PlutusData, error) {
+	return NewBytesPlutusData(v), nil
+}
+
+// encodePlutusBytesString encodes a map key stored as a string.
+func encodePlutusBytesString(v string) (PlutusData, error) {
+	return NewBytesPlutusData([]byte(v)), nil
+}
+
+func encodePlutusBool(v bool) (PlutusData, error) {
+	if v {
+		return NewConstrPlutusData(1), nil
+	}
+	return NewConstrPlutusData(0), nil
+}
+
+func encodePlutusVoid(struct{}) (PlutusData, error) {
+	return NewConstrPlutusData(0), nil
+}
+
+// encodePlutusList encodes v as a list.
+func encodePlutusList[T any](v []T, encodeItem func(T) (PlutusData, error)) (PlutusData, error) {
+	items := make([]PlutusData, len(v))
+	for i, item := range v {
+		pd, err := encodeItem(item)
+		if err != nil {
+			return PlutusData{}, validationErrorAt(err, pathItem(i))
+		}
+		items[i] = pd
+	}
+	return NewListPlutusData(items...), nil
+}
+
+// encodePlutusMap encodes m as a map sorted by encoded key.
+func encodePlutusMap[K comparable, V any](m map[K]V, encodeKey func(K) (PlutusData, error), encodeValue func(V) (PlutusData, error)) (PlutusData, error) {
+	entries := make([]PlutusDataMapEntry, 0, len(m))
+	for k, v := range m {
+		key, err := encodeKey(k)
+		if err != nil {
+			return PlutusData{}, validationErrorAt(err, pathKey(k), "Key")
+		}
+		value, err := encodeValue(v)
+		if err != nil {
+			return PlutusData{}, validationErrorAt(err, pathKey(k), "Value")
+		}
+		entries = append(entries, PlutusDataMapEntry{Key: key, Value: value})
+	}
+	sortPlutusMapEntries(entries)
+	return NewMapPlutusData(entries...), nil
+}
+
+// equalPlutusEncodings reports whether a and b encode to the same
+// PlutusData, for values such as maps keyed by pointers that can't be
+// compared directly.
+func equalPlutusEncodings[T any](a, b T, encode func(T) (PlutusData, error)) bool {
+	pa, errA := encode(a)
+	pb, errB := encode(b)
+	return errA == nil && errB == nil && pa.Equals(pb)
+}
+
+// Validators of the values generated types can't encode, or that are
+// unset, for their Validate methods. Nil lists are reported, though they
+// encode as empty lists; nil maps and byte strings are valid.
+
+func validatePlutusInt(v *big.Int) error {
+	if v == nil {
+		return nilValueError("*big.Int")
+	}
+	return nil
+}
+
+// validatePlutusEnum checks that the enum v is set, and validates the
+// variant.
+func validatePlutusEnum[T interface{ Validate() error }](v T) error {
+	if any(v) == nil {
+		return nilValueError(reflect.TypeOf((*T)(nil)).Elem().Name())
+	}
+	return v.Validate()
+}
+
+// validatePlutusList checks that v is set and validates its items, unless
+// validateItem is nil.
+func validatePlutusList[T any](v []T, validateItem func(T) error) error {
+	if v == nil {
+		return nilValueError(localTypeName(reflect.TypeOf(v)))
+	}
+	if validateItem == nil {
+		return nil
+	}
+	for i, item := range v {
+		if err := validateItem(item); err != nil {
+			return validationErrorAt(err, pathItem(i))
+		}
+	}
+	return nil
+}
+
+// validatePlutusMap validates the keys and values of m, either function
+// being nil if they need no check.
+func validatePlutusMap[K comparable, V any](m map[K]V, validateKey func(K) error, validateValue func(V) error) error {
+	for k, v := range m {
+		if validateKey != nil {
+			if err := validateKey(k); err != nil {
+				return validationErrorAt(err, pathKey(k), "Key")
+			}
+		}
+		if validateValue != nil {
+			if err := validateValue(v); err != nil {
+				return validationErrorAt(err, pathKey(k), "Value")
+			}
+		}
+	}
+	return nil
+}
+
+// validatePlutusData checks that pd and the values it holds all have a kind,
+// for PlutusData held by generated types: the zero PlutusData would encode
+// as a unit constructor that was never there.
+func validatePlutusData(pd PlutusData) error {
+	switch pd.Kind() {
+	case KindNone:
+		return &ValidationError{Err: errors.New("value is the zero PlutusData")}
+	case KindConstr:
+		for i, f := range pd.Constr.Fields {
+			if err := validatePlutusData(f); err != nil {
+				return validationErrorAt(err, "Fields", pathItem(i))
+			}
+		}
+	case KindList:
+		for i, item := range pd.List {
+			if err := validatePlutusData(item); err != nil {
+				return validationErrorAt(err, pathItem(i))
+			}
+		}
+	case KindMap:
+		for i, entry := range pd.Map {
+			if err := validatePlutusData(entry.Key); err != nil {
+				return validationErrorAt(err, pathItem(i), "Key")
+			}
+			if err := validatePlutusData(entry.Value); err != nil {
+				return validationErrorAt(err, pathItem(i), "Value")
+			}
+		}
+	}
+	return nil
+}
+
+func decodePlutusInt(pd PlutusData, dst **big.Int) error {
+	i, ok := pd.AsInteger()
+	if !ok {
+		return decodeKindError("*big.Int", "integer", pd)
+	}
+	*dst = i
+	return nil
+}
+
+func decodePlutusBytes(pd PlutusData, dst *[]byte) error {
+	b, ok := pd.AsBytes()
+	if !ok {
+		return decodeKindError("[]byte", "bytes", pd)
+	}
+	*dst = b
+	return nil
+}
+
+// decodePlutusBytesString decodes a map key stored as a string.
+func decodePlutusBytesString(pd PlutusData, dst *string) error {
+	b, ok := pd.AsBytes()
+	if !ok {
+		return decodeKindError("string", "bytes", pd)
+	}
+	*dst = string(b)
+	return nil
+}
+
+func decodePlutusBool(pd PlutusData, dst *bool) error {
+	v, err := boolFromPlutusData("bool", pd)
+	*dst = v
+	return err
+}
+
+// boolFromPlutusData decodes pd as the Bool typ: constructor 0 is False and
+// 1 is True, both without fields.
+func boolFromPlutusData(typ string, pd PlutusData) (bool, error) {
+	c, ok := pd.AsConstr()
+	if !ok {
+		return false, decodeKindError(typ, "constructor", pd)
+	}
+	if c.Index > 1 {
+		return false, decodeIndexError(typ, c.Index, 0, 1)
+	}
+	if len(c.Fields) != 0 {
+		return false, decodeCountError(typ, "fields", 0, len(c.Fields))
+	}
+	return c.Index == 1, nil
+}
+
+// decodePlutusVoid checks that pd is the Void value, constructor 0 without
+// fields, as readVoid does.
+func decodePlutusVoid(pd PlutusData, _ *struct{}) error {
+	c, ok := pd.AsConstr()
+	if !ok {
+		return decodeKindError("struct{}", "constructor", pd)
+	}
+	if c.Index != 0 {
+		return decodeIndexError("struct{}", c.Index, 0)
+	}
+	if len(c.Fields) != 0 {
+		return decodeCountError("struct{}", "fields", 0, len(c.Fields))
+	}
+	return nil
+}
+
+// decodePlutusValue decodes a generated type or a registered enum, as
+// Decode does.
+func decodePlutusValue[T any](pd PlutusData, dst *T) error {
+	return decodePlutusInto(dst, pd)
+}
+
+// decodePlutusEnum returns a decoder calling the factory of an enum.
+func decodePlutusEnum[T any](decode func(PlutusData) (T, error)) func(PlutusData, *T) error {
+	return func(pd PlutusData, dst *T) error {
+		v, err := decode(pd)
+		if err != nil {
+			return err
+		}
+		*dst = v
+		return nil
+	}
+}
+
+func decodePlutusList[T any](pd PlutusData, dst *[]T, decodeItem func(PlutusData, *T) error) error {
+	if pd.Kind() != KindList {
+		return decodeKindError(localTypeName(reflect.TypeOf(dst).Elem()), "list", pd)
+	}
+	items := make([]T, len(pd.List))
+	for i, item := range pd.List {
+		if err := decodeItem(item, &items[i]); err != nil {
+			return decodeErrorAt(err, pathItem(i))
+		}
+	}
+	*dst = items
+	return nil
+}
+
+func decodePlutusMap[K comparable, V any](pd PlutusData, dst *map[K]V, decodeKey func(PlutusData, *K) error, decodeValue func(PlutusData, *V) error) error {
+	if pd.Kind() != KindMap {
+		return decodeKindError(localTypeName(reflect.TypeOf(dst).Elem()), "map", pd)
+	}
+	m := make(map[K]V, len(pd.Map))
+	for i, entry := range pd.Map {
+		var key K
+		if err := decodeKey(entry.Key, &key); err != nil {
+			return decodeErrorAt(err, pathItem(i), "Key")
+		}
+		var value V
+		if err := decodeValue(entry.Value, &value); err != nil {
+			return decodeErrorAt(err, pathItem(i), "Value")
+		}
+		m[key] = value
+	}
+	*dst = m
+	return nil
 }
 
 var _ = errors.New
 var _ = big.NewInt
 var _ = PlutusData{}
 var _ = reflect.DeepEqual
+var _ = strings.Join
@@ -1,9 +1,15 @@
 package blueprint
 
 import (
+	"bytes"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"math/big"
+	"strings"
 	"testing"
+
+	"github.com/fxamacker/cbor/v2"
 )
 
 // fromHex is a test helper that decodes hex and unmarshals PlutusData
@@ -121,6 +127,112 @@ func TestPlutusData_Constructor_HighIndex(t *testing.T) {
 	}
 }
 
+func TestPlutusData_ConstructorTags(t *testing.T) {
+	tests := []struct {
+		index uint64
+		hex   string
+	}{
+		{0, "d87980"},
+		{6, "d87f80"},
+		{7, "d9050080"},
+		{127, "d9057880"},
+		{128, "d866821880" + "80"},
+		{1 << 16, "d866821a00010000" + "80"},
+		{1<<64 - 1, "d866821bffffffffffffffff" + "80"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.index), func(t *testing.T) {
+			for _, fields := range [][]PlutusData{nil, {NewIntPlutusData(big.NewInt(1))}} {
+				pd := NewConstrPlutusData(tt.index, fields...)
+				want := tt.hex
+				if len(fields) > 0 {
+					want = strings.TrimSuffix(want, "80") + "9f01ff"
+				}
+				data, err := pd.MarshalCBOR()
+				if err != nil {
+					t.Fatalf("failed to marshal constructor: %v", err)
+				}
+				if got := hex.EncodeToString(data); got != want {
+					t.Errorf("expected %s, got %s", want, got)
+				}
+
+				decoded := fromHex(t, want)
+				if !decoded.Equals(pd) {
+					t.Errorf("round trip of %s failed", want)
+				}
+				strict, err := DecodeCBORWithOptions[PlutusData](data, DecodeOptions{Strict: true})
+				if err != nil || !strict.Equals(pd) {
+					t.Errorf("strict decode of %s: %v", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestPlutusData_EmptyValues(t *testing.T) {
+	tests := []struct {
+		name string
+		pd   PlutusData
+		kind PlutusDataKind
+		hex  string
+	}{
+		{"empty list", NewListPlutusData(), KindList, "9fff"},
+		{"nil bytes", NewBytesPlutusData(nil), KindBytes, "40"},
+		{"empty bytes", NewBytesPlutusData([]byte{}), KindBytes, "40"},
+		{"empty map", NewMapPlutusData(), KindMap, "a0"},
+		{"nil integer", NewIntPlutusData(nil), KindInteger, "00"},
+		{"constructor without fields", NewConstrPlutusData(0), KindConstr, "d87980"},
+		{"zero value", PlutusData{}, KindNone, "d87980"},
+		{"literal", PlutusData{ByteString: []byte{}}, KindBytes, "40"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.pd.Kind() != tt.kind {
+				t.Errorf("expected kind %s, got %s", tt.kind, tt.pd.Kind())
+			}
+			h, err := tt.pd.ToHex()
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+			if h != tt.hex {
+				t.Errorf("expected %s, got %s", tt.hex, h)
+			}
+			decoded := fromHex(t, h)
+			if tt.kind != KindNone && decoded.Kind() != tt.kind {
+				t.Errorf("decoded kind %s, want %s", decoded.Kind(), tt.kind)
+			}
+			if !decoded.Equals(tt.pd) {
+				t.Error("round trip changed the value")
+			}
+		})
+	}
+}
+
+func TestPlutusData_Accessors(t *testing.T) {
+	list := NewListPlutusData()
+	if items, ok := list.AsList(); !ok || len(items) != 0 {
+		t.Errorf("AsList() = %v, %v", items, ok)
+	}
+	if _, ok := list.AsMap(); ok {
+		t.Error("AsMap() succeeded on a list")
+	}
+	if _, ok := list.AsConstr(); ok {
+		t.Error("AsConstr() succeeded on a list")
+	}
+	if i, ok := NewIntPlutusData(nil).AsInteger(); !ok || i.Sign() != 0 {
+		t.Errorf("AsInteger() = %v, %v", i, ok)
+	}
+	if b, ok := NewBytesPlutusData(nil).AsBytes(); !ok || len(b) != 0 {
+		t.Errorf("AsBytes() = %x, %v", b, ok)
+	}
+	if c, ok := NewConstrPlutusData(3).AsConstr(); !ok || c.Index != 3 {
+		t.Errorf("AsConstr() = %v, %v", c, ok)
+	}
+	if _, ok := (PlutusData{}).AsConstr(); ok {
+		t.Error("AsConstr() succeeded on the zero value")
+	}
+}
+
 func TestPlutusData_Unit(t *testing.T) {
 	// Unit/Void is constructor 0 with no fields
 	pd := NewConstrPlutusData(0)
@@ -135,6 +247,19 @@ func TestPlutusData_Unit(t *testing.T) {
 	}
 }
 
+// TestDecodeVoid tests that Void decoded from PlutusData and streamed
+// accept the same input: constructor 0 without fields.
+func TestDecodeVoid(t *testing.T) {
+	for h, valid := range map[string]bool{"d87980": true, "d87a80": false, "d8799f01ff": false, "d8799f80ff": false} {
+		data, _ := hex.DecodeString(h)
+		errPlutusData := decodePlutusVoid(fromHex(t, h), nil)
+		errStreamed := DecodeOptions{}.unmarshal(data, func(d *plutusCBORDecoder) error { return d.readVoid(nil) })
+		if (errPlutusData == nil) != valid || (errStreamed == nil) != valid {
+			t.Errorf("%s: decodePlutusVoid: %v, readVoid: %v, want valid %v", h, errPlutusData, errStreamed, valid)
+		}
+	}
+}
+
 func TestPlutusData_Bool(t *testing.T) {
 	// False = constructor 0, True = constructor 1
 	falsePd := NewConstrPlutusData(0)
@@ -271,3 +396,426 @@ func TestPlutusData_IndefiniteLengthArrays(t *testing.T) {
 		}
 	})
 }
+
+func TestPlutusData_GenericHelpers(t *testing.T) {
+	original := NewConstrPlutusData(0, NewIntPlutusData(big.NewInt(42)))
+
+	data, err := EncodeCBOR(original)
+	if err != nil {
+		t.Fatalf("EncodeCBOR failed: %v", err)
+	}
+	if hex.EncodeToString(data) != "d8799f182aff" {
+		t.Errorf("unexpected encoding: %x", data)
+	}
+
+	decoded, err := DecodeCBOR[PlutusData](data)
+	if err != nil {
+		t.Fatalf("DecodeCBOR failed: %v", err)
+	}
+	if !decoded.Equals(original) {
+		t.Error("decoded value differs from original")
+	}
+
+	if _, err := Decode[int](original); err == nil {
+		t.Error("expected error decoding into a type without a codec")
+	}
+	if _, err := Encode[PlutusMarshaler](nil); err == nil {
+		t.Error("expected error encoding nil")
+	}
+}
+
+// plutusDataFromCBORLibrary converts a value decoded by the cbor library to
+// PlutusData. PlutusData.UnmarshalCBOR used to decode this way; the decoder
+// tests compare against it.
+func plutusDataFromCBORLibrary(v interface{}) (PlutusData, error) {
+	switch val := v.(type) {
+	case cbor.Tag:
+		var index uint64
+		content, ok := val.Content.([]interface{})
+		if !ok {
+			return PlutusData{}, errors.New("constructor content is not an array")
+		}
+		switch {
+		case val.Number >= cborTagConstr0 && val.Number <= cborTagConstr6:
+			index = val.Number - cborTagConstr0
+		case val.Number >= cborTagConstrBase && val.Number <= cborTagConstr127:
+			index = val.Number - cborTagConstrBase + 7
+		case val.Number == cborTagConstrAny:
+			if len(content) != 2 {
+				return PlutusData{}, errors.New("general constructor is not a 2-element array")
+			}
+			if index, ok = content[0].(uint64); !ok {
+				return PlutusData{}, errors.New("general constructor index is not an unsigned integer")
+			}
+			if content, ok = content[1].([]interface{}); !ok {
+				return PlutusData{}, errors.New("general constructor fields are not an array")
+			}
+		default:
+			return PlutusData{}, fmt.Errorf("unsupported CBOR tag: %d", val.Number)
+		}
+		fields := make([]PlutusData, len(content))
+		for i, item := range content {
+			pd, err := plutusDataFromCBORLibrary(item)
+			if err != nil {
+				return PlutusData{}, err
+			}
+			fields[i] = pd
+		}
+		return PlutusData{Constr: &ConstrPlutusData{Index: index, Fields: fields}}, nil
+	case *big.Int:
+		return PlutusData{Integer: val}, nil
+	case int64:
+		return PlutusData{Integer: big.NewInt(val)}, nil
+	case uint64:
+		return PlutusData{Integer: new(big.Int).SetUint64(val)}, nil
+	case []byte:
+		return PlutusData{ByteString: val}, nil
+	case cbor.ByteString:
+		return PlutusData{ByteString: []byte(val)}, nil
+	case []interface{}:
+		items := make([]PlutusData, len(val))
+		for i, item := range val {
+			pd, err := plutusDataFromCBORLibrary(item)
+			if err != nil {
+				return PlutusData{}, err
+			}
+			items[i] = pd
+		}
+		return PlutusData{List: items}, nil
+	case map[interface{}]interface{}:
+		entries := make([]PlutusDataMapEntry, 0, len(val))
+		for k, v := range val {
+			key, err := plutusDataFromCBORLibrary(k)
+			if err != nil {
+				return PlutusData{}, err
+			}
+			value, err := plutusDataFromCBORLibrary(v)
+			if err != nil {
+				return PlutusData{}, err
+			}
+			entries = append(entries, PlutusDataMapEntry{Key: key, Value: value})
+		}
+		return PlutusData{Map: entries}, nil
+	case nil:
+		// CBOR null - not standard PlutusData, but some serializers use it
+		// Treat as Void/Unit (constructor 0 with no fields)
+		return PlutusData{Constr: &ConstrPlutusData{Index: 0, Fields: []PlutusData{}}}, nil
+	default:
+		return PlutusData{}, fmt.Errorf("unsupported CBOR type: %T", v)
+	}
+}
+
+func TestPlutusCBORDecoder_MatchesCBORLibrary(t *testing.T) {
+	tests := []struct {
+		name string
+		hex  string
+	}{
+		{"small int", "182a"},
+		{"negative int", "3903e7"},
+		{"positive bignum", "c249010000000000000000"},
+		{"negative bignum", "c349010000000000000000"},
+		{"bytes", "43010203"},
+		{"indefinite bytes", "5f4201024103ff"},
+		{"definite list", "83010203"},
+		{"indefinite list", "9f010203ff"},
+		{"empty list", "80"},
+		{"constructor", "d8799f182aff"},
+		{"high constructor", "d905009f01ff"},
+		{"general constructor", "d866821901009f01ff"},
+		{"general small constructor", "d866820580"},
+		{"definite map", "a1410101"},
+		{"null as unit", "f6"},
+		{"aiken datum", "d8799f9fd8799fd8799fd8799fd8799f450102000403ffd8799fd8799fd8799f450102000403ffffffffd87980ff01d8799f4ed8799f48736f6d65486173680cffffffffff"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := hex.DecodeString(tt.hex)
+			if err != nil {
+				t.Fatalf("invalid hex: %v", err)
+			}
+			var raw interface{}
+			dm, _ := cbor.DecOptions{BigIntDec: cbor.BigIntDecodePointer}.DecMode()
+			if err := dm.Unmarshal(data, &raw); err != nil {
+				t.Fatalf("cbor library decode failed: %v", err)
+			}
+			expected, err := plutusDataFromCBORLibrary(raw)
+			if err != nil {
+				t.Fatalf("conversion failed: %v", err)
+			}
+
+			var got PlutusData
+			if err := unmarshalPlutusCBOR(data, func(d *plutusCBORDecoder) error { return d.readData(&got) }); err != nil {
+				t.Fatalf("streaming decode failed: %v", err)
+			}
+			if !got.Equals(expected) {
+				gotHex, _ := got.ToHex()
+				expectedHex, _ := expected.ToHex()
+				t.Errorf("expected %s, got %s", expectedHex, gotHex)
+			}
+		})
+	}
+}
+
+func TestPlutusCBORDecoder_KeepsMapOrder(t *testing.T) {
+	data, _ := hex.DecodeString("bf410202410101ff")
+	var got PlutusData
+	if err := unmarshalPlutusCBOR(data, func(d *plutusCBORDecoder) error { return d.readData(&got) }); err != nil {
+		t.Fatalf("streaming decode failed: %v", err)
+	}
+	if h, _ := got.ToHex(); h != "bf410202410101ff" {
+		t.Errorf("map entries reordered: %s", h)
+	}
+}
+
+func TestPlutusCBORDecoder_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		hex    string
+		decode func(d *plutusCBORDecoder) error
+		errMsg string
+	}{
+		{"trailing bytes", "0102", func(d *plutusCBORDecoder) error { var n *big.Int; return d.readInt(&n) }, "extraneous data"},
+		{"truncated", "430102", func(d *plutusCBORDecoder) error { var b []byte; return d.readBytes(&b) }, "unexpected end"},
+		{"nested indefinite chunk", "5f5f4101ffff", func(d *plutusCBORDecoder) error { var b []byte; return d.readBytes(&b) }, "expected definite-length bytes"},
+		{"text chunk", "5f6161ff", func(d *plutusCBORDecoder) error { var pd PlutusData; return d.readData(&pd) }, "expected definite-length bytes"},
+		{"truncated chunk", "5f4301ff", func(d *plutusCBORDecoder) error { var b []byte; return d.readBytes(&b) }, "unexpected end"},
+		{"wrong kind", "43010203", func(d *plutusCBORDecoder) error { var n *big.Int; return d.readInt(&n) }, "expected integer for *big.Int, got bytes"},
+		{"text string", "6161", func(d *plutusCBORDecoder) error { var pd PlutusData; return d.readData(&pd) }, "unsupported CBOR type: text string"},
+		{"tag past constructor 127", "d9057980", func(d *plutusCBORDecoder) error { var pd PlutusData; return d.readData(&pd) }, "expected constructor, got tag 1401"},
+		{"general constructor of 3 items", "d8668318808080", func(d *plutusCBORDecoder) error { var pd PlutusData; return d.readData(&pd) }, "must wrap a 2-element array"},
+		{"general constructor without index", "d866824080", func(d *plutusCBORDecoder) error { var pd PlutusData; return d.readData(&pd) }, "expected constructor index, got bytes"},
+		{"wrong general constructor", "d86682188080", func(d *plutusCBORDecoder) error { _, err := d.expectConstr(0); return err }, "expected 0, got 128"},
+		{"wrong constructor", "d87a80", func(d *plutusCBORDecoder) error { _, err := d.expectConstr(0); return err }, "expected 0, got 1"},
+		{"too many items", "d87980", func(d *plutusCBORDecoder) error {
+			seq := cborSeq{remaining: 1}
+			return d.end(&seq)
+		}, "too many items"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := hex.DecodeString(tt.hex)
+			if err != nil {
+				t.Fatalf("invalid hex: %v", err)
+			}
+			err = unmarshalPlutusCBOR(data, tt.decode)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestDecodeOptions_Strict(t *testing.T) {
+	readData := func(d *plutusCBORDecoder) error { var pd PlutusData; return d.readData(&pd) }
+	readBool := func(d *plutusCBORDecoder) error { var b bool; return d.readBool(&b) }
+	readVoid := func(d *plutusCBORDecoder) error { return d.readVoid(nil) }
+	tests := []struct {
+		name      string
+		hex       string
+		decode    func(d *plutusCBORDecoder) error
+		lenient   string // expected error in lenient mode, "" for success
+		strictErr string
+	}{
+		{"null", "f6", readData, "", "expected constructor, got null"},
+		{"non-minimal int", "1801", readData, "", "non-minimal encoding of 1"},
+		{"non-minimal length", "5900020102", readData, "", "non-minimal encoding of 2"},
+		{"non-minimal tag", "d9007980", readData, "", "non-minimal encoding of 121"},
+		{"small bignum", "c2420100", readData, "", "non-minimal bignum"},
+		{"padded bignum", "c249000100000000000000", readData, "", "non-minimal bignum"},
+		{"bool index 2", "d87b80", readBool, "wrong constructor index for bool: expected one of 0, 1, got 2", "wrong constructor index for bool: expected one of 0, 1, got 2"},
+		{"bool with fields", "d87a9f01ff", readBool, "too many items", "too many items"},
+		{"fields on fieldless constructor", "d8799f01ff", readVoid, "too many items", "too many items"},
+		{"float", "f93c00", readData, "unsupported CBOR type: float", "unsupported CBOR type: float"},
+		{"text string", "6161", readData, "unsupported CBOR type: text string", "unsupported CBOR type: text string"},
+		{"trailing bytes", "0102", readData, "extraneous data", "extraneous data"},
+		{"general constructor 5", "d866820580", readData, "", "non-canonical tag 102 for constructor 5"},
+		{"general constructor 127", "d86682187f80", readData, "", "non-canonical tag 102 for constructor 127"},
+		{"canonical datum", "d8799f1864c249010000000000000000d87a80ff", readData, "", ""},
+	}
+	check := func(t *testing.T, err error, want string) {
+		t.Helper()
+		if want == "" {
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error containing %q, got %v", want, err)
+		}
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := hex.DecodeString(tt.hex)
+			if err != nil {
+				t.Fatalf("invalid hex: %v", err)
+			}
+			check(t, DecodeOptions{}.unmarshal(data, tt.decode), tt.lenient)
+			check(t, DecodeOptions{Strict: true}.unmarshal(data, tt.decode), tt.strictErr)
+		})
+	}
+}
+
+func TestDecodeCBORWithOptions(t *testing.T) {
+	data, _ := hex.DecodeString("1801")
+	pd, err := DecodeCBOR[PlutusData](data)
+	if err != nil {
+		t.Fatalf("lenient decode failed: %v", err)
+	}
+	if pd.Integer == nil || pd.Integer.Int64() != 1 {
+		t.Errorf("expected 1, got %v", pd.Integer)
+	}
+	if _, err := DecodeCBORWithOptions[PlutusData](data, DecodeOptions{Strict: true}); err == nil {
+		t.Error("expected strict decode to reject a non-minimal integer")
+	}
+
+	// DecodeWithOptions checks the canonical encoding of pd
+	n, _ := new(big.Int).SetString("18446744073709551616", 10)
+	if _, err := DecodeWithOptions[PlutusData](NewIntPlutusData(n), DecodeOptions{Strict: true}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestDecodeOptions_Limits(t *testing.T) {
+	nested := func(depth int) []byte {
+		return append(bytes.Repeat([]byte{0x81}, depth), 0x00)
+	}
+	list := func(n int) []byte {
+		return append([]byte{0x98, byte(n)}, make([]byte, n)...)
+	}
+	tests := []struct {
+		name  string
+		data  []byte
+		opts  DecodeOptions
+		limit string // "" for success
+	}{
+		{"default depth", nested(DefaultMaxDepth), DecodeOptions{}, ""},
+		{"too deep", nested(DefaultMaxDepth + 1), DecodeOptions{}, "MaxDepth"},
+		{"custom depth", nested(4), DecodeOptions{MaxDepth: 3}, "MaxDepth"},
+		{"depth disabled", nested(1000), DecodeOptions{MaxDepth: -1}, ""},
+		{"depth of siblings", []byte{0x83, 0x81, 0x00, 0x81, 0x00, 0x81, 0x00}, DecodeOptions{MaxDepth: 2}, ""},
+		{"constructor depth", []byte{0xd8, 0x79, 0x9f, 0xd8, 0x79, 0x80, 0xff}, DecodeOptions{MaxDepth: 1}, "MaxDepth"},
+		{"items", list(10), DecodeOptions{MaxItems: 10}, ""},
+		{"too many items", list(10), DecodeOptions{MaxItems: 9}, "MaxItems"},
+		{"items across lists", []byte{0x82, 0x82, 0x00, 0x00, 0x82, 0x00, 0x00}, DecodeOptions{MaxItems: 5}, "MaxItems"},
+		{"indefinite items", []byte{0x9f, 0x00, 0x00, 0x00, 0xff}, DecodeOptions{MaxItems: 2}, "MaxItems"},
+		{"byte string chunks", []byte{0x5f, 0x41, 0x01, 0x41, 0x02, 0x41, 0x03, 0xff}, DecodeOptions{MaxItems: 2}, "MaxItems"},
+		{"too many bytes", []byte{0x44, 0x01, 0x02, 0x03, 0x04}, DecodeOptions{MaxBytes: 4}, "MaxBytes"},
+		{"bytes disabled", append([]byte{0x5a, 0x01, 0x00, 0x00, 0x00}, make([]byte, 1<<24)...), DecodeOptions{MaxBytes: -1}, ""},
+		{"default bytes", append([]byte{0x5a, 0x01, 0x00, 0x00, 0x00}, make([]byte, 1<<24)...), DecodeOptions{}, "MaxBytes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeCBORWithOptions[PlutusData](tt.data, tt.opts)
+			if tt.limit == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var limitErr *DecodeLimitError
+			if !errors.As(err, &limitErr) {
+				t.Fatalf("expected *DecodeLimitError, got %v", err)
+			}
+			if limitErr.Limit != tt.limit {
+				t.Errorf("expected limit %s, got %s", tt.limit, limitErr.Limit)
+			}
+		})
+	}
+
+	// PlutusData.UnmarshalCBOR applies the default limits
+	var pd PlutusData
+	var limitErr *DecodeLimitError
+	if err := pd.UnmarshalCBOR(nested(DefaultMaxDepth + 1)); !errors.As(err, &limitErr) {
+		t.Errorf("expected *DecodeLimitError, got %v", err)
+	}
+
+	// A run of indefinite byte string heads fails on the first nested one
+	// instead of recursing
+	if err := pd.UnmarshalCBOR(bytes.Repeat([]byte{0x5f}, 4<<20)); err == nil {
+		t.Error("expected an error for nested indefinite byte strings")
+	}
+}
+
+// TestPlutusData_AppendCBORMatchesCBORLibrary checks integers and byte
+// strings against the encoding of the cbor library, which MarshalCBOR used to
+// delegate to.
+func TestPlutusData_AppendCBORMatchesCBORLibrary(t *testing.T) {
+	em, err := cbor.EncOptions{BigIntConvert: cbor.BigIntConvertShortest}.EncMode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var values []interface{}
+	for _, s := range []string{
+		"0", "23", "24", "255", "256", "65535", "65536", "4294967295", "4294967296",
+		"9223372036854775807", "18446744073709551615", "18446744073709551616",
+		"-1", "-24", "-25", "-256", "-257", "-9223372036854775808",
+		"-18446744073709551616", "-18446744073709551617", "1606938044258990275541962092341162602522202993782792835301376",
+	} {
+		n, _ := new(big.Int).SetString(s, 10)
+		values = append(values, n)
+	}
+	for _, n := range []int{0, 23, 24, 255, 256, 70000} {
+		values = append(values, bytes.Repeat([]byte{0xab}, n))
+	}
+	for _, v := range values {
+		want, err := em.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var pd PlutusData
+		switch v := v.(type) {
+		case *big.Int:
+			pd = NewIntPlutusData(v)
+		case []byte:
+			pd = NewBytesPlutusData(v)
+		}
+		got, err := pd.AppendCBOR([]byte{0x01})
+		if err != nil {
+			t.Fatalf("AppendCBOR failed: %v", err)
+		}
+		if !bytes.Equal(got[1:], want) || got[0] != 0x01 {
+			t.Errorf("%v: expected %x, got %x", v, want, got[1:])
+		}
+	}
+}
+
+func TestSortPlutusMapEntries(t *testing.T) {
+	entries := []PlutusDataMapEntry{
+		{Key: NewBytesPlutusData([]byte("bb")), Value: NewIntPlutusData(big.NewInt(1))},
+		{Key: NewBytesPlutusData([]byte("c")), Value: NewIntPlutusData(big.NewInt(2))},
+		{Key: NewBytesPlutusData([]byte("a")), Value: NewIntPlutusData(big.NewInt(3))},
+	}
+	sortPlutusMapEntries(entries)
+
+	m := map[string]*big.Int{"bb": big.NewInt(1), "c": big.NewInt(2), "a": big.NewInt(3)}
+	direct, err := appendCBORMap(nil, m, appendCBORBytesString, appendCBORInt)
+	if err != nil {
+		t.Fatalf("appendCBORMap failed: %v", err)
+	}
+
+	// Shorter encoded keys sort first
+	want := "bf41610341630242626201ff"
+	if got, _ := NewMapPlutusData(entries...).ToHex(); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	if got := hex.EncodeToString(direct); got != want {
+		t.Errorf("appendCBORMap: expected %s, got %s", want, got)
+	}
+
+	// Larger maps take another sorting path
+	large := map[string]*big.Int{}
+	entries = nil
+	for i := 0; i < 40; i++ {
+		key := strings.Repeat("k", i%7) + string(rune('a'+i))
+		large[key] = big.NewInt(int64(i))
+		entries = append(entries, PlutusDataMapEntry{Key: NewBytesPlutusData([]byte(key)), Value: NewIntPlutusData(big.NewInt(int64(i)))})
+	}
+	sortPlutusMapEntries(entries)
+	want, _ = NewMapPlutusData(entries...).ToHex()
+	direct, err = appendCBORMap(nil, large, appendCBORBytesString, appendCBORInt)
+	if err != nil {
+		t.Fatalf("appendCBORMap failed: %v", err)
+	}
+	if got := hex.EncodeToString(direct); got != want {
+		t.Errorf("large map: expected %s, got %s", want, got)
+	}
+}
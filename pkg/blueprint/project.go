@@ -0,0 +1,186 @@
+package blueprint
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Project is an Aiken project directory: an aiken.toml and the blueprint
+// that `aiken build` writes next to it.
+type Project struct {
+	Dir string
+
+	// Fields of aiken.toml
+	Name        string // e.g. "test/string_validator"
+	Version     string
+	Compiler    string
+	Plutus      string
+	License     string
+	Description string
+
+	// BlueprintPath is plutus.json in Dir, unless [config] of aiken.toml
+	// sets blueprint to another path, relative to Dir.
+	BlueprintPath string
+}
+
+// LoadProject reads the aiken.toml of an Aiken project directory. A
+// directory without aiken.toml is still a project if it has a plutus.json.
+func LoadProject(dir string) (*Project, error) {
+	p := &Project{Dir: dir, BlueprintPath: filepath.Join(dir, "plutus.json")}
+
+	values, err := readAikenTOML(filepath.Join(dir, "aiken.toml"))
+	if os.IsNotExist(err) {
+		if _, statErr := os.Stat(p.BlueprintPath); statErr != nil {
+			return nil, fmt.Errorf("%s is not an Aiken project: no aiken.toml or plutus.json", dir)
+		}
+		return p, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p.Name = values["name"]
+	p.Version = values["version"]
+	p.Compiler = values["compiler"]
+	p.Plutus = values["plutus"]
+	p.License = values["license"]
+	p.Description = values["description"]
+	if path := values["config.blueprint"]; path != "" {
+		p.BlueprintPath = filepath.Join(dir, filepath.FromSlash(path))
+	}
+	return p, nil
+}
+
+// LoadBlueprint loads the project's blueprint, completing its preamble with
+// the fields of aiken.toml it lacks.
+func (p *Project) LoadBlueprint() (*Blueprint, error) {
+	bp, err := loadBlueprintFile(p.BlueprintPath)
+	if err != nil {
+		return nil, err
+	}
+	pre := &bp.Preamble
+	if pre.Title == "" {
+		pre.Title = p.Name
+	}
+	if pre.Description == "" {
+		pre.Description = p.Description
+	}
+	if pre.Version == "" {
+		pre.Version = p.Version
+	}
+	if pre.PlutusVersion == "" {
+		pre.PlutusVersion = p.Plutus
+	}
+	if pre.License == "" {
+		pre.License = p.License
+	}
+	if pre.Compiler.Version == "" && p.Compiler != "" {
+		pre.Compiler = Compiler{Name: "Aiken", Version: p.Compiler}
+	}
+	return bp, nil
+}
+
+// PackageName derives a Go package name from the last segment of the
+// project name, e.g. "stringvalidator" for "test/string_validator". It
+// returns "" when the project has no usable name.
+func (p *Project) PackageName() string {
+	name := p.Name
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	pkg := b.String()
+	if pkg == "" || unicode.IsDigit(rune(pkg[0])) {
+		return ""
+	}
+	return pkg
+}
+
+// readAikenTOML reads the string values of an aiken.toml. Keys of tables
+// are prefixed with the table name ("config.blueprint"); arrays of tables
+// such as [[dependencies]] and non-string values are skipped. This covers
+// the part of TOML that aiken.toml uses for project metadata.
+func readAikenTOML(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := make(map[string]string)
+	table := ""
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "" || strings.HasPrefix(text, "#"):
+			continue
+		case strings.HasPrefix(text, "[["):
+			table = "[["
+			continue
+		case strings.HasPrefix(text, "["):
+			end := strings.Index(text, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("%s:%d: unterminated table header", path, line)
+			}
+			table = strings.TrimSpace(text[1:end])
+			continue
+		}
+		if table == "[[" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected key = value", path, line)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		s, ok := tomlString(value)
+		if !ok {
+			continue
+		}
+		if table != "" {
+			key = table + "." + key
+		}
+		values[key] = s
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// tomlString parses a basic ("...") or literal ('...') TOML string,
+// ignoring a trailing comment.
+func tomlString(value string) (string, bool) {
+	if strings.HasPrefix(value, "'") {
+		end := strings.Index(value[1:], "'")
+		if end < 0 {
+			return "", false
+		}
+		return value[1 : end+1], true
+	}
+	if !strings.HasPrefix(value, `"`) {
+		return "", false
+	}
+	for i := 1; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case '"':
+			s, err := strconv.Unquote(value[:i+1])
+			return s, err == nil
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,103 @@
+package blueprint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadProject(t *testing.T) {
+	project, err := LoadProject("../../testdata/all_types")
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if project.Name != "test/string_validator" || project.Version != "0.0.0" || project.Compiler != "v1.1.19" {
+		t.Errorf("unexpected project: %+v", project)
+	}
+	if project.Description != "Aiken contracts for project 'test/string_validator'" {
+		t.Errorf("unexpected description %q", project.Description)
+	}
+	if want := filepath.Join("../../testdata/all_types", "plutus.json"); project.BlueprintPath != want {
+		t.Errorf("expected blueprint %s, got %s", want, project.BlueprintPath)
+	}
+	if got := project.PackageName(); got != "stringvalidator" {
+		t.Errorf("expected package stringvalidator, got %q", got)
+	}
+
+	// LoadBlueprint accepts the project directory
+	bp, err := LoadBlueprint("../../testdata/all_types")
+	if err != nil {
+		t.Fatalf("LoadBlueprint: %v", err)
+	}
+	if bp.Preamble.Title != "test/string_validator" || len(bp.Sources) != 1 || bp.Sources[0].Path != "../../testdata/all_types/plutus.json" {
+		t.Errorf("unexpected blueprint: %+v, %v", bp.Preamble, bp.Sources)
+	}
+}
+
+func TestLoadProjectCustomBlueprint(t *testing.T) {
+	dir := t.TempDir()
+	toml := `name = "acme/oracle-feed"
+version = "1.2.0" # released
+compiler = "v1.1.9"
+plutus = 'v3'
+
+[[dependencies]]
+name = "aiken-lang/stdlib"
+version = "v2.1.0"
+
+[config]
+blueprint = "build/blueprint.json"
+`
+	if err := os.WriteFile(filepath.Join(dir, "aiken.toml"), []byte(toml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "build"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	blueprint := `{"preamble": {"title": "acme/oracle-feed"}, "validators": [], "definitions": {}}`
+	if err := os.WriteFile(filepath.Join(dir, "build", "blueprint.json"), []byte(blueprint), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	project, err := LoadProject(dir)
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if project.Name != "acme/oracle-feed" || project.Version != "1.2.0" || project.Plutus != "v3" {
+		t.Errorf("unexpected project: %+v", project)
+	}
+	if got := project.PackageName(); got != "oraclefeed" {
+		t.Errorf("expected package oraclefeed, got %q", got)
+	}
+
+	// The preamble is completed from aiken.toml
+	bp, err := project.LoadBlueprint()
+	if err != nil {
+		t.Fatalf("LoadBlueprint: %v", err)
+	}
+	want := Preamble{Title: "acme/oracle-feed", Version: "1.2.0", PlutusVersion: "v3", Compiler: Compiler{Name: "Aiken", Version: "v1.1.9"}}
+	if bp.Preamble != want {
+		t.Errorf("unexpected preamble %+v, want %+v", bp.Preamble, want)
+	}
+
+	code, _, err := NewGenerator(bp, GeneratorOptions{PackageName: "oraclefeed"}).Generate()
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+	for _, line := range []string{
+		`BlueprintVersion         = "1.2.0"`,
+		`BlueprintCompiler        = "Aiken"`,
+		`BlueprintCompilerVersion = "v1.1.9"`,
+	} {
+		if !strings.Contains(code, line) {
+			t.Errorf("expected generated code to contain %q", line)
+		}
+	}
+}
+
+func TestLoadProjectMissing(t *testing.T) {
+	if _, err := LoadProject(t.TempDir()); err == nil {
+		t.Error("expected an error for a directory without aiken.toml or plutus.json")
+	}
+}
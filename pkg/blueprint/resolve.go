@@ -0,0 +1,237 @@
+package blueprint
+
+import (
+	"strings"
+
+	"github.com/pgrange/aiken_to_go/pkg/ir"
+)
+
+// Resolve builds the typed model of bp: a definition for each of its
+// definitions, under its unescaped name, and its validators.
+//
+// Inline schemas resolve in place, to types without a name. References to
+// missing definitions resolve to an *ir.Opaque named after them, except
+// List$, Option$ and Pairs$ instances, which are resolved from their name.
+func Resolve(bp *Blueprint) *ir.Module {
+	r := &resolver{
+		bp:    bp,
+		types: make(map[string]ir.Type, len(bp.Definitions)),
+	}
+
+	defs := make([]*ir.Definition, 0, len(bp.Definitions))
+	for name := range bp.Definitions {
+		name = unescapeRef(name)
+		defs = append(defs, &ir.Definition{Name: name, Type: r.resolveRef(name)})
+	}
+
+	validators := make([]*ir.Validator, len(bp.Validators))
+	for i, v := range bp.Validators {
+		validator := &ir.Validator{
+			Title:        v.Title,
+			Redeemer:     r.parameter(&v.Redeemer),
+			CompiledCode: v.CompiledCode,
+			Hash:         v.Hash,
+		}
+		if v.Datum != nil {
+			validator.Datum = r.parameter(v.Datum)
+		}
+		for j := range v.Parameters {
+			validator.Parameters = append(validator.Parameters, r.parameter(&v.Parameters[j]))
+		}
+		validators[i] = validator
+	}
+
+	return ir.NewModule(ir.Preamble{
+		Title:           bp.Preamble.Title,
+		Description:     bp.Preamble.Description,
+		Version:         bp.Preamble.Version,
+		PlutusVersion:   bp.Preamble.PlutusVersion,
+		CompilerName:    bp.Preamble.Compiler.Name,
+		CompilerVersion: bp.Preamble.Compiler.Version,
+		License:         bp.Preamble.License,
+	}, defs, validators)
+}
+
+type resolver struct {
+	bp    *Blueprint
+	types map[string]ir.Type // resolved definitions, by unescaped name
+}
+
+func (r *resolver) parameter(p *Parameter) *ir.Parameter {
+	return &ir.Parameter{Title: p.Title, Type: r.resolve(&p.Schema)}
+}
+
+// resolveRef returns the type of the definition name. Each definition is
+// resolved once, and is recorded before what it references is resolved,
+// so that recursive types resolve to a cycle of pointers.
+func (r *resolver) resolveRef(name string) ir.Type {
+	switch name {
+	case "Int":
+		return ir.Int
+	case "ByteArray":
+		return ir.ByteArray
+	case "Bool":
+		return ir.Bool
+	case "Void":
+		return ir.Void
+	}
+	if t, ok := r.types[name]; ok {
+		return t
+	}
+	schema, ok := r.bp.Definitions[name]
+	if !ok {
+		schema, ok = r.bp.Definitions[strings.ReplaceAll(name, "/", "~1")]
+	}
+	if !ok {
+		return r.resolveMissing(name)
+	}
+
+	if name == "Data" {
+		t := &ir.Opaque{Name: name}
+		r.types[name] = t
+		return t
+	}
+	if schema.IsRef() || schema.IsInteger() || schema.IsBytes() || schema.IsBoolean() || schema.IsUnit() {
+		// A definition of a primitive other than Bool or Void under a name
+		// of its own, e.g. a PolicyId
+		alias := &ir.Alias{Name: name, Title: schema.Title, Description: schema.Description}
+		r.types[name] = alias
+		alias.Target = r.resolve(schema)
+		return alias
+	}
+	t := r.shape(schema, name)
+	r.types[name] = t
+	r.fill(t, schema)
+	return t
+}
+
+// resolveMissing resolves a reference to a definition the blueprint
+// doesn't have.
+func (r *resolver) resolveMissing(name string) ir.Type {
+	var t ir.Type
+	switch {
+	case strings.HasPrefix(name, "List$"):
+		list := &ir.List{Name: name}
+		r.types[name] = list
+		list.Elem = r.resolveRef(strings.TrimPrefix(name, "List$"))
+		t = list
+	case strings.HasPrefix(name, "Option$"):
+		option := &ir.Option{Name: name}
+		r.types[name] = option
+		option.Elem = r.resolveRef(strings.TrimPrefix(name, "Option$"))
+		t = option
+	case strings.HasPrefix(name, "Pairs$"):
+		pairs := &ir.Pairs{Name: name, Key: ir.ByteArray, Value: &ir.Opaque{}}
+		r.types[name] = pairs
+		if parts := strings.SplitN(strings.TrimPrefix(name, "Pairs$"), "_", 2); len(parts) == 2 {
+			pairs.Key, pairs.Value = r.resolveRef(parts[0]), r.resolveRef(parts[1])
+		}
+		t = pairs
+	default:
+		t = &ir.Opaque{Name: name}
+		r.types[name] = t
+	}
+	return t
+}
+
+// resolve returns the type of a schema used inline, e.g. as a field.
+func (r *resolver) resolve(schema *Schema) ir.Type {
+	switch {
+	case schema.IsRef():
+		return r.resolveRef(schema.RefName())
+	case schema.IsInteger():
+		return ir.Int
+	case schema.IsBytes():
+		return ir.ByteArray
+	}
+	t := r.shape(schema, "")
+	r.fill(t, schema)
+	return t
+}
+
+// shape returns the empty type for the schema, to be completed by fill.
+// The order of the cases matches writeTypeDef.
+func (r *resolver) shape(schema *Schema, name string) ir.Type {
+	switch {
+	case schema.IsBoolean():
+		return ir.Bool
+	case schema.IsUnit():
+		return ir.Void
+	case schema.IsOption():
+		return &ir.Option{Name: name}
+	case schema.IsSingleConstructor(), schema.IsConstructor():
+		return &ir.Record{Name: name, Title: schema.Title, Description: schema.Description}
+	case schema.IsEnum():
+		return &ir.Enum{Name: name, Title: schema.Title, Description: schema.Description}
+	case schema.IsList() && len(schema.Items) > 1:
+		return &ir.Tuple{Name: name}
+	case schema.IsList():
+		return &ir.List{Name: name}
+	case schema.IsMap():
+		return &ir.Pairs{Name: name}
+	default:
+		return &ir.Opaque{Name: name}
+	}
+}
+
+func (r *resolver) fill(t ir.Type, schema *Schema) {
+	switch t := t.(type) {
+	case *ir.Option:
+		t.Elem = &ir.Opaque{}
+		if inner := schema.OptionInnerType(); inner != nil {
+			t.Elem = r.resolve(inner)
+		}
+	case *ir.Record:
+		constr := schema
+		if schema.IsSingleConstructor() {
+			constr = &schema.AnyOf[0]
+		}
+		if constr.Index != nil {
+			t.Index = *constr.Index
+		}
+		t.Fields = r.fields(constr.Fields)
+	case *ir.Enum:
+		for i := range schema.AnyOf {
+			variant := &schema.AnyOf[i]
+			index := i
+			if variant.Index != nil {
+				index = *variant.Index
+			}
+			t.Variants = append(t.Variants, &ir.Variant{
+				Title:       variant.Title,
+				Description: variant.Description,
+				Index:       index,
+				Fields:      r.fields(variant.Fields),
+			})
+		}
+	case *ir.Tuple:
+		for _, item := range schema.Items {
+			t.Items = append(t.Items, r.resolve(item))
+		}
+	case *ir.List:
+		t.Elem = &ir.Opaque{}
+		if item := schema.Items.Single(); item != nil {
+			t.Elem = r.resolve(item)
+		}
+	case *ir.Pairs:
+		t.Key, t.Value = ir.ByteArray, &ir.Opaque{}
+		if schema.Keys != nil {
+			t.Key = r.resolve(schema.Keys)
+		}
+		if schema.Values != nil {
+			t.Value = r.resolve(schema.Values)
+		}
+	}
+}
+
+func (r *resolver) fields(fields []Schema) []*ir.Field {
+	out := make([]*ir.Field, len(fields))
+	for i := range fields {
+		out[i] = &ir.Field{
+			Title:       fields[i].Title,
+			Description: fields[i].Description,
+			Type:        r.resolve(&fields[i]),
+		}
+	}
+	return out
+}
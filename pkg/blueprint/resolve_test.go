@@ -0,0 +1,156 @@
+package blueprint
+
+import (
+	"testing"
+
+	"github.com/pgrange/aiken_to_go/pkg/ir"
+)
+
+// TestResolve checks the typed model built from a blueprint: records,
+// enums, aliases, containers, recursion and validators.
+func TestResolve(t *testing.T) {
+	bp, err := LoadBlueprint("../../testdata/complex/plutus.json")
+	if err != nil {
+		t.Fatalf("failed to load blueprint: %v", err)
+	}
+	m := Resolve(bp)
+
+	if m.Preamble.Title == "" {
+		t.Error("expected the preamble title to be resolved")
+	}
+	for i := 1; i < len(m.Definitions); i++ {
+		if m.Definitions[i-1].Name >= m.Definitions[i].Name {
+			t.Fatalf("definitions are not sorted: %q before %q", m.Definitions[i-1].Name, m.Definitions[i].Name)
+		}
+	}
+
+	// Escaped definition keys are looked up by their unescaped name
+	policyDef := m.Lookup("cardano/assets/PolicyId")
+	if policyDef == nil {
+		t.Fatal("cardano/assets/PolicyId not found")
+	}
+	policy, ok := policyDef.Type.(*ir.Alias)
+	if !ok || policy.Title != "PolicyId" || policy.Target != ir.ByteArray {
+		t.Errorf("PolicyId: got %#v, want an alias of ByteArray", policyDef.Type)
+	}
+	if ir.Underlying(policy) != ir.ByteArray {
+		t.Errorf("Underlying(PolicyId) = %v, want ByteArray", ir.Underlying(policy))
+	}
+
+	outRef, ok := m.Lookup("cardano/transaction/OutputReference").Type.(*ir.Record)
+	if !ok {
+		t.Fatalf("OutputReference: got %T, want *ir.Record", m.Lookup("cardano/transaction/OutputReference").Type)
+	}
+	if outRef.Name != "cardano/transaction/OutputReference" || outRef.Description == "" || outRef.Index != 0 {
+		t.Errorf("OutputReference: unexpected record %#v", outRef)
+	}
+	if len(outRef.Fields) != 2 || outRef.Fields[0].Title != "transaction_id" || outRef.Fields[1].Type != ir.Int {
+		t.Errorf("OutputReference: unexpected fields %#v", outRef.Fields)
+	}
+
+	status, ok := m.Lookup("types/PayoutStatus").Type.(*ir.Enum)
+	if !ok {
+		t.Fatalf("PayoutStatus: got %T, want *ir.Enum", m.Lookup("types/PayoutStatus").Type)
+	}
+	for i, v := range status.Variants {
+		if v.Index != i || len(v.Fields) != 0 {
+			t.Errorf("PayoutStatus variant %d: got index %d with %d fields", i, v.Index, len(v.Fields))
+		}
+	}
+
+	// A recursive type reaches itself through the same pointer
+	script, ok := m.Lookup("multisig/MultisigScript").Type.(*ir.Enum)
+	if !ok || len(script.Variants) != 7 {
+		t.Fatalf("MultisigScript: got %#v, want an enum of 7 variants", m.Lookup("multisig/MultisigScript").Type)
+	}
+	allOf := script.Variants[1]
+	list, ok := allOf.Fields[0].Type.(*ir.List)
+	if allOf.Title != "AllOf" || allOf.Index != 1 || !ok || list.Elem != ir.Type(script) {
+		t.Errorf("MultisigScript.AllOf: expected a list of MultisigScript, got %#v", allOf.Fields[0].Type)
+	}
+	if m.Lookup("List$multisig/MultisigScript").Type != ir.Type(list) {
+		t.Error("List$multisig/MultisigScript should resolve to the same list as its uses")
+	}
+	if list.Name != "List$multisig/MultisigScript" {
+		t.Errorf("MultisigScript.AllOf: got list name %q, want List$multisig/MultisigScript", list.Name)
+	}
+
+	value, ok := m.Lookup("Pairs$cardano/assets/PolicyId_Pairs$cardano/assets/AssetName_Int").Type.(*ir.Pairs)
+	if !ok || value.Key != ir.Type(policy) {
+		t.Fatalf("Value: got %#v, want pairs keyed by PolicyId", value)
+	}
+	if inner, ok := value.Value.(*ir.Pairs); !ok || inner.Value != ir.Int {
+		t.Errorf("Value: got values %#v, want pairs of Int", value.Value)
+	}
+
+	if _, ok := m.Lookup("Data").Type.(*ir.Opaque); !ok {
+		t.Errorf("Data: got %T, want *ir.Opaque", m.Lookup("Data").Type)
+	}
+	if m.Lookup("Void").Type != ir.Void {
+		t.Errorf("Void: got %#v, want ir.Void", m.Lookup("Void").Type)
+	}
+
+	if len(m.Validators) != 3 {
+		t.Fatalf("got %d validators, want 3", len(m.Validators))
+	}
+	spend := m.Validators[0]
+	if spend.Title != "treasury.treasury.spend" || spend.Datum == nil || spend.Hash == "" {
+		t.Errorf("unexpected validator %#v", spend)
+	}
+	if _, ok := spend.Redeemer.Type.(*ir.Enum); !ok || spend.Redeemer.Title != "redeemer" {
+		t.Errorf("spend redeemer: got %#v, want an enum", spend.Redeemer)
+	}
+	if len(spend.Parameters) != 1 || spend.Parameters[0].Type != m.Lookup("types/TreasuryConfiguration").Type {
+		t.Errorf("spend parameters: got %#v", spend.Parameters)
+	}
+	if m.Validators[1].Datum != nil {
+		t.Error("treasury.treasury.else should have no datum")
+	}
+}
+
+// TestResolve_Inline checks that inline schemas and generic instances
+// missing from the definitions resolve to anonymous types.
+func TestResolve_Inline(t *testing.T) {
+	bp := loadBlueprintFromJSON(t, `{
+  "preamble": {"title": "test/resolve"},
+  "validators": [],
+  "definitions": {
+    "Int": {"dataType": "integer"},
+    "Entry": {
+      "title": "Entry",
+      "anyOf": [{
+        "title": "Entry",
+        "dataType": "constructor",
+        "index": 3,
+        "fields": [
+          {"title": "pair", "dataType": "list", "items": [{"dataType": "integer"}, {"dataType": "bytes"}]},
+          {"title": "tags", "$ref": "#/definitions/List$Int"},
+          {"title": "next", "$ref": "#/definitions/Option$Entry"},
+          {"title": "extra", "$ref": "#/definitions/Unknown"}
+        ]
+      }]
+    }
+  }
+}`)
+	m := Resolve(bp)
+
+	entry, ok := m.Lookup("Entry").Type.(*ir.Record)
+	if !ok || entry.Index != 3 || len(entry.Fields) != 4 {
+		t.Fatalf("Entry: got %#v, want a record of 4 fields at index 3", m.Lookup("Entry").Type)
+	}
+	if tuple, ok := entry.Fields[0].Type.(*ir.Tuple); !ok || len(tuple.Items) != 2 || tuple.Items[0] != ir.Int || tuple.Items[1] != ir.ByteArray {
+		t.Errorf("pair: got %#v, want a tuple of Int and ByteArray", entry.Fields[0].Type)
+	}
+	if list, ok := entry.Fields[1].Type.(*ir.List); !ok || list.Elem != ir.Int {
+		t.Errorf("tags: got %#v, want a list of Int", entry.Fields[1].Type)
+	}
+	if option, ok := entry.Fields[2].Type.(*ir.Option); !ok || option.Elem != ir.Type(entry) {
+		t.Errorf("next: got %#v, want an option of Entry", entry.Fields[2].Type)
+	}
+	if opaque, ok := entry.Fields[3].Type.(*ir.Opaque); !ok || opaque.Name != "Unknown" {
+		t.Errorf("extra: got %#v, want an opaque Unknown", entry.Fields[3].Type)
+	}
+	if m.Lookup("List$Int") != nil {
+		t.Error("generic instances missing from the blueprint should not become definitions")
+	}
+}
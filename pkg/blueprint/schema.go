@@ -81,11 +81,15 @@ func (s *Schema) RefName() string {
 	if !s.IsRef() {
 		return ""
 	}
-	name := strings.TrimPrefix(s.Ref, "#/definitions/")
-	// Handle JSON Pointer escaping: ~1 = /, ~0 = ~
-	name = strings.ReplaceAll(name, "~1", "/")
-	name = strings.ReplaceAll(name, "~0", "~")
-	return name
+	return unescapeRef(strings.TrimPrefix(s.Ref, "#/definitions/"))
+}
+
+// unescapeRef undoes the JSON Pointer escaping of a definition name: ~1
+// for / and ~0 for ~.
+func unescapeRef(ref string) string {
+	ref = strings.ReplaceAll(ref, "~1", "/")
+	ref = strings.ReplaceAll(ref, "~0", "~")
+	return ref
 }
 
 // IsInteger returns true if this is an integer type.
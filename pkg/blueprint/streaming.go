@@ -0,0 +1,186 @@
+package blueprint
+
+import (
+	"fmt"
+
+	"github.com/pgrange/aiken_to_go/pkg/ir"
+)
+
+// Streaming decoders let generated UnmarshalCBOR methods fill Go values
+// straight from the CBOR bytes, without the interface{} and PlutusData trees
+// built by PlutusData.UnmarshalCBOR and FromPlutusData.
+//
+// Every generated type gets a decodeCBOR method and every enum a
+// decodeXxxCBOR function. Generic containers decode their type arguments
+// with readCBORAny. A type with a field that has no streaming decoder, such
+// as a reference to a missing definition, falls back to reading a PlutusData
+// tree and calling FromPlutusData, which only honours the CBOR-level checks
+// of strict decoding; TestNoPlutusDataFallback checks that the blueprints
+// in testdata need none.
+
+// cborDecoder returns an expression of type func(*plutusCBORDecoder, *T) error
+// decoding a value of T = goType(t). ok is false when there is no streaming
+// decoder for t.
+func (g *Generator) cborDecoder(t ir.Type) (string, bool) {
+	switch {
+	case g.isGeneric(t):
+		return fmt.Sprintf("readCBORAny[%s]", g.goType(t)), true
+	case isInt(t):
+		return "(*plutusCBORDecoder).readInt", true
+	case isBytes(t):
+		return "(*plutusCBORDecoder).readBytes", true
+	case t == ir.Bool:
+		return "(*plutusCBORDecoder).readBool", true
+	case t == ir.Void:
+		return "(*plutusCBORDecoder).readVoid", true
+	case isData(t):
+		return "(*plutusCBORDecoder).readData", true
+	case g.isEnum(t):
+		return fmt.Sprintf("decode%sCBOR", g.goType(t)), true
+	}
+
+	switch t := t.(type) {
+	case *ir.List:
+		if !g.isContainer(t) {
+			break
+		}
+		// Untyped items are held as PlutusData
+		itemDecoder, ok := g.cborDecoder(t.Elem)
+		if !ok {
+			return "", false
+		}
+		return g.cborListDecoder(g.goType(t.Elem), itemDecoder), true
+	case *ir.Pairs:
+		if !g.isContainer(t) {
+			break
+		}
+		keyType, keyDecoder, ok := g.cborMapKeyDecoder(g.goType(t.Key), func() (string, bool) {
+			return g.cborDecoder(t.Key)
+		})
+		if !ok {
+			return "", false
+		}
+		valueDecoder, ok := g.cborDecoder(t.Value)
+		if !ok {
+			return "", false
+		}
+		return g.cborMapDecoder(keyType, g.goType(t.Value), keyDecoder, valueDecoder), true
+	}
+	if g.hasValidate(t) || isNamedBoolOrUnit(t) {
+		// A generated type, with a decodeCBOR method
+		return fmt.Sprintf("readCBORValue[%s]", g.goType(t)), true
+	}
+	return "", false
+}
+
+// cborMapKeyDecoder returns the Go key type and decoder of a map. Byte
+// string keys are stored as string, as in mapKeyGoType.
+func (g *Generator) cborMapKeyDecoder(goType string, decoder func() (string, bool)) (string, string, bool) {
+	if goType == "[]byte" {
+		return "string", "(*plutusCBORDecoder).readBytesString", true
+	}
+	dec, ok := decoder()
+	return goType, dec, ok
+}
+
+func (g *Generator) cborListDecoder(itemType, itemDecoder string) string {
+	return fmt.Sprintf("func(d *plutusCBORDecoder, v *[]%s) error { return readCBORList(d, v, %s) }", itemType, itemDecoder)
+}
+
+func (g *Generator) cborMapDecoder(keyType, valueType, keyDecoder, valueDecoder string) string {
+	return fmt.Sprintf("func(d *plutusCBORDecoder, v *map[%s]%s) error { return readCBORMap(d, v, %s, %s) }", keyType, valueType, keyDecoder, valueDecoder)
+}
+
+// Direct encoders are the counterpart of streaming decoders: every generated
+// type gets AppendCBOR and MarshalCBOR methods writing the bytes that
+// ToPlutusData followed by PlutusData.MarshalCBOR would produce, straight
+// into the caller's buffer. Generic containers encode their type arguments
+// with appendCBORCodec. As for decoding, a type with a field that has no
+// direct encoder falls back to ToPlutusData.
+
+// cborEncoder returns an expression of type func([]byte, T) ([]byte, error)
+// encoding a value of T = goType(t). ok is false when there is no direct
+// encoder for t.
+func (g *Generator) cborEncoder(t ir.Type) (string, bool) {
+	switch {
+	case g.isGeneric(t):
+		return fmt.Sprintf("appendCBORValue[%s]", g.goType(t)), true
+	case isInt(t):
+		return "appendCBORInt", true
+	case isBytes(t):
+		return "appendCBORBytes", true
+	case t == ir.Bool:
+		return "appendCBORBool", true
+	case t == ir.Void:
+		return "appendCBORVoid", true
+	case isData(t):
+		return "appendCBORValue[PlutusData]", true
+	case g.isEnum(t):
+		return fmt.Sprintf("appendCBOREnum[%s]", g.goType(t)), true
+	}
+
+	switch t := t.(type) {
+	case *ir.List:
+		if !g.isContainer(t) {
+			break
+		}
+		itemEncoder, ok := g.cborEncoder(t.Elem)
+		if !ok {
+			return "", false
+		}
+		return g.cborListEncoder(g.goType(t.Elem), itemEncoder), true
+	case *ir.Pairs:
+		if !g.isContainer(t) {
+			break
+		}
+		keyType, keyEncoder, ok := g.cborMapKeyEncoder(g.goType(t.Key), func() (string, bool) {
+			return g.cborEncoder(t.Key)
+		})
+		if !ok {
+			return "", false
+		}
+		valueEncoder, ok := g.cborEncoder(t.Value)
+		if !ok {
+			return "", false
+		}
+		return g.cborMapEncoder(keyType, g.goType(t.Value), keyEncoder, valueEncoder), true
+	}
+	if g.hasValidate(t) || isNamedBoolOrUnit(t) {
+		// A generated type, with an AppendCBOR method
+		return fmt.Sprintf("appendCBORValue[%s]", g.goType(t)), true
+	}
+	return "", false
+}
+
+// cborMapKeyEncoder returns the Go key type and encoder of a map, as
+// cborMapKeyDecoder.
+func (g *Generator) cborMapKeyEncoder(goType string, encoder func() (string, bool)) (string, string, bool) {
+	if goType == "[]byte" {
+		return "string", "appendCBORBytesString", true
+	}
+	enc, ok := encoder()
+	return goType, enc, ok
+}
+
+func (g *Generator) cborListEncoder(itemType, itemEncoder string) string {
+	return fmt.Sprintf("func(dst []byte, v []%s) ([]byte, error) { return appendCBORList(dst, v, %s) }", itemType, itemEncoder)
+}
+
+func (g *Generator) cborMapEncoder(keyType, valueType, keyEncoder, valueEncoder string) string {
+	return fmt.Sprintf("func(dst []byte, v map[%s]%s) ([]byte, error) { return appendCBORMap(dst, v, %s, %s) }", keyType, valueType, keyEncoder, valueEncoder)
+}
+
+// setCBORCodecs sets the CBOR decoder and encoder of each field of data from
+// its type in fields, and whether all of them have one.
+func (g *Generator) setCBORCodecs(data *StructData, fields []ir.Type) {
+	data.Streamable, data.Appendable = true, true
+	for i, field := range fields {
+		var ok bool
+		if data.Fields[i].CBORDecoder, ok = g.cborDecoder(field); !ok {
+			data.Streamable = false
+		}
+		if data.Fields[i].CBOREncoder, ok = g.cborEncoder(field); !ok {
+			data.Appendable = false
+		}
+	}
+}
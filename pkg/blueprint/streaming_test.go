@@ -0,0 +1,390 @@
+package blueprint
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupStreamingModule writes a Go module containing the code generated from
+// the complex blueprint with the given options in types/, along with the
+// VendorDatum fixture of the benchmarks, and the given files at its root.
+func setupStreamingModule(tb testing.TB, opts GeneratorOptions, files map[string]string) string {
+	tb.Helper()
+	fixture, err := os.ReadFile("internal/benchtypes/fixture_test.go")
+	if err != nil {
+		tb.Fatalf("failed to read fixture: %v", err)
+	}
+	files["types/fixture.go"] = strings.Replace(string(fixture), "package benchtypes", "package types", 1)
+	return setupTypesModule(tb, "../../testdata/complex/plutus.json", opts, files)
+}
+
+// setupTypesModule writes a Go module containing the code generated from the
+// given blueprint in types/ and the given files at its root.
+func setupTypesModule(tb testing.TB, blueprintPath string, opts GeneratorOptions, files map[string]string) string {
+	tb.Helper()
+	if _, err := exec.LookPath("go"); err != nil {
+		tb.Skip("go compiler not found")
+	}
+
+	tmpDir := tb.TempDir()
+	typesDir := filepath.Join(tmpDir, "types")
+	if err := os.MkdirAll(typesDir, 0755); err != nil {
+		tb.Fatalf("failed to create types dir: %v", err)
+	}
+
+	bp, err := LoadBlueprint(blueprintPath)
+	if err != nil {
+		tb.Fatalf("failed to load blueprint: %v", err)
+	}
+	code, _, err := NewGenerator(bp, opts).Generate()
+	if err != nil {
+		tb.Fatalf("failed to generate code: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(typesDir, "types.go"), []byte(code), 0644); err != nil {
+		tb.Fatalf("failed to write types file: %v", err)
+	}
+
+	files["go.mod"] = `module testpkg
+
+go 1.21
+`
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			tb.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	cmd := exec.Command("go", "mod", "tidy")
+	cmd.Dir = tmpDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		tb.Fatalf("go mod tidy failed: %v\n%s", err, output)
+	}
+	return tmpDir
+}
+
+// TestStreamingDecode tests that the generated UnmarshalCBOR methods decode
+// the same values as PlutusData.UnmarshalCBOR followed by FromPlutusData.
+func TestStreamingDecode(t *testing.T) {
+	testProgram := `package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"testpkg/types"
+)
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
+
+func main() {
+	original := types.VendorDatum(3)
+	data, err := types.EncodeCBOR(original)
+	if err != nil {
+		fail("EncodeCBOR: %v", err)
+	}
+
+	var viaPlutusData types.TypesVendorDatum
+	var pd types.PlutusData
+	if err := pd.UnmarshalCBOR(data); err != nil {
+		fail("PlutusData.UnmarshalCBOR: %v", err)
+	}
+	if err := viaPlutusData.FromPlutusData(pd); err != nil {
+		fail("FromPlutusData: %v", err)
+	}
+
+	var streamed types.TypesVendorDatum
+	if err := streamed.UnmarshalCBOR(data); err != nil {
+		fail("UnmarshalCBOR: %v", err)
+	}
+	if !streamed.Equals(original) {
+		fail("streamed datum differs from original")
+	}
+	if !streamed.Vendor.(types.MultisigMultisigScriptAtLeast).Equals(viaPlutusData.Vendor.(types.MultisigMultisigScriptAtLeast)) {
+		fail("streamed vendor differs from PlutusData path")
+	}
+	if got := streamed.Payouts[2].Value["token"]; got != nil {
+		fail("unexpected policy entry: %v", got)
+	}
+	if got := streamed.Payouts[2].Value[string(bytes.Repeat([]byte{0xcd}, 28))]["token"]; got.Int64() != 2 {
+		fail("unexpected token quantity: %v", got)
+	}
+
+	// Enums decode through the registered streaming decoder
+	script, err := types.DecodeCBOR[types.MultisigMultisigScript](mustEncode(original.Vendor))
+	if err != nil {
+		fail("DecodeCBOR enum: %v", err)
+	}
+	if _, ok := script.(types.MultisigMultisigScriptAtLeast); !ok {
+		fail("expected MultisigMultisigScriptAtLeast, got %T", script)
+	}
+
+	// Definite-length arrays, as produced by other serializers, are accepted
+	var ref types.CardanoTransactionOutputReference
+	if err := ref.UnmarshalCBOR([]byte{0xd8, 0x79, 0x82, 0x41, 0x01, 0x05}); err != nil {
+		fail("definite-length constructor: %v", err)
+	}
+	if ref.OutputIndex.Int64() != 5 {
+		fail("unexpected output index: %v", ref.OutputIndex)
+	}
+
+	// Strict decoding rejects encodings that lenient decoding accepts
+	nonMinimal := []byte{0xd8, 0x79, 0x82, 0x41, 0x01, 0x18, 0x05}
+	if err := ref.UnmarshalCBOR(nonMinimal); err != nil {
+		fail("lenient non-minimal integer: %v", err)
+	}
+	_, err = types.DecodeCBORWithOptions[types.CardanoTransactionOutputReference](nonMinimal, types.DecodeOptions{Strict: true})
+	if err == nil || !strings.Contains(err.Error(), "non-minimal encoding") {
+		fail("strict non-minimal integer: expected error, got %v", err)
+	}
+	if _, err := types.DecodeCBORWithOptions[types.TypesVendorDatum](data, types.DecodeOptions{Strict: true}); err != nil {
+		fail("strict decode of canonical datum: %v", err)
+	}
+
+	// Limits are reported as *DecodeLimitError through the field path
+	var limitErr *types.DecodeLimitError
+	_, err = types.DecodeCBORWithOptions[types.TypesVendorDatum](data, types.DecodeOptions{MaxItems: 5})
+	if !errors.As(err, &limitErr) || limitErr.Limit != "MaxItems" {
+		fail("MaxItems: expected *DecodeLimitError, got %v", err)
+	}
+
+	expectError := func(name string, data []byte, target interface{ UnmarshalCBOR([]byte) error }, msg string) {
+		err := target.UnmarshalCBOR(data)
+		if err == nil || !strings.Contains(err.Error(), msg) {
+			fail("%s: expected error containing %q, got %v", name, msg, err)
+		}
+	}
+	expectError("trailing bytes", append(append([]byte{}, data...), 0x00), &streamed, "extraneous data")
+	expectError("wrong constructor", []byte{0xd8, 0x7a, 0x80}, &ref, "wrong constructor index")
+	expectError("missing field", []byte{0xd8, 0x79, 0x81, 0x41, 0x01}, &ref, "OutputIndex: missing item")
+	expectError("extra field", []byte{0xd8, 0x79, 0x83, 0x41, 0x01, 0x05, 0x05}, &ref, "too many items")
+	expectError("wrong type", []byte{0xd8, 0x79, 0x82, 0x01, 0x05}, &ref, "TransactionId: expected bytes for []byte, got integer")
+
+	fmt.Printf("✓ streamed %d bytes\n", len(data))
+}
+
+func mustEncode(v types.PlutusMarshaler) []byte {
+	data, err := types.EncodeCBOR(v)
+	if err != nil {
+		fail("EncodeCBOR: %v", err)
+	}
+	return data
+}
+`
+	tmpDir := setupStreamingModule(t, GeneratorOptions{PackageName: "types"}, map[string]string{"main.go": testProgram})
+
+	cmd := exec.Command("go", "run", "main.go")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("test program failed: %v\n%s", err, output)
+	}
+	t.Logf("Test output:\n%s", output)
+}
+
+// TestDecodeErrorPaths tests that FromPlutusData and UnmarshalCBOR report
+// the same *DecodeError, with the path of the offending value.
+func TestDecodeErrorPaths(t *testing.T) {
+	testProgram := `package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+
+	"testpkg/types"
+)
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
+
+func check(name string, corrupt func(pd types.PlutusData), want types.DecodeError) {
+	pd, err := types.VendorDatum(3).ToPlutusData()
+	if err != nil {
+		fail("%s: ToPlutusData: %v", name, err)
+	}
+	corrupt(pd)
+	data, err := pd.MarshalCBOR()
+	if err != nil {
+		fail("%s: MarshalCBOR: %v", name, err)
+	}
+
+	var datum types.TypesVendorDatum
+	for path, err := range map[string]error{
+		"FromPlutusData": datum.FromPlutusData(pd),
+		"UnmarshalCBOR":  datum.UnmarshalCBOR(data),
+	} {
+		var de *types.DecodeError
+		if !errors.As(err, &de) {
+			fail("%s: %s: expected *DecodeError, got %v", name, path, err)
+		}
+		if !reflect.DeepEqual(*de, want) {
+			fail("%s: %s: expected %+v, got %+v (%v)", name, path, want, *de, err)
+		}
+	}
+}
+
+func main() {
+	payout := func(pd types.PlutusData, i int) types.PlutusData { return pd.Constr.Fields[1].List[i] }
+
+	check("wrong kind", func(pd types.PlutusData) {
+		payout(pd, 2).Constr.Fields[0] = types.NewBytesPlutusData([]byte{1})
+	}, types.DecodeError{Path: "Payouts[2].Maturation", Type: "*big.Int", Expected: "integer", Actual: "bytes"})
+
+	check("unknown variant", func(pd types.PlutusData) {
+		payout(pd, 1).Constr.Fields[2] = types.NewConstrPlutusData(7)
+	}, types.DecodeError{Path: "Payouts[1].Status", Type: "TypesPayoutStatus", ExpectedIndex: []uint64{0, 1}, ActualIndex: 7})
+
+	check("nested enum", func(pd types.PlutusData) {
+		scripts := pd.Constr.Fields[0].Constr.Fields[1].List
+		scripts[1].Constr.Fields[0].List[0] = types.NewConstrPlutusData(9)
+	}, types.DecodeError{Path: "Vendor.Scripts[1].Scripts[0]", Type: "MultisigMultisigScript", ExpectedIndex: []uint64{0, 1, 2, 3, 4, 5, 6}, ActualIndex: 9})
+
+	check("map field", func(pd types.PlutusData) {
+		payout(pd, 0).Constr.Fields[1] = types.NewIntPlutusData(big.NewInt(1))
+	}, types.DecodeError{Path: "Payouts[0].Value", Type: "map[string]map[string]*big.Int", Expected: "map", Actual: "integer"})
+
+	var ref types.CardanoTransactionOutputReference
+	err := ref.FromPlutusData(types.NewConstrPlutusData(0, types.NewBytesPlutusData(nil)))
+	if err == nil || err.Error() != "CardanoTransactionOutputReference: wrong number of fields: expected 2, got 1" {
+		fail("field count: unexpected error %v", err)
+	}
+
+	fmt.Println("✓ decode errors carry their path")
+}
+`
+	tmpDir := setupStreamingModule(t, GeneratorOptions{PackageName: "types"}, map[string]string{"main.go": testProgram})
+
+	cmd := exec.Command("go", "run", "main.go")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("test program failed: %v\n%s", err, output)
+	}
+	t.Logf("Test output:\n%s", output)
+}
+
+// TestDirectEncode tests that the generated AppendCBOR methods write the same
+// bytes as ToPlutusData followed by PlutusData.MarshalCBOR.
+func TestDirectEncode(t *testing.T) {
+	testProgram := `package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"testpkg/types"
+)
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
+
+func main() {
+	original := types.VendorDatum(3)
+	pd, err := original.ToPlutusData()
+	if err != nil {
+		fail("ToPlutusData: %v", err)
+	}
+	want, err := pd.MarshalCBOR()
+	if err != nil {
+		fail("MarshalCBOR: %v", err)
+	}
+
+	got, err := original.MarshalCBOR()
+	if err != nil {
+		fail("generated MarshalCBOR: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		fail("MarshalCBOR differs:\n got  %x\n want %x", got, want)
+	}
+	viaHelper, err := types.EncodeCBOR(original)
+	if err != nil || !bytes.Equal(viaHelper, want) {
+		fail("EncodeCBOR differs: %x, %v", viaHelper, err)
+	}
+
+	// AppendCBOR keeps what is already in the buffer
+	appended, err := original.Vendor.AppendCBOR([]byte("prefix"))
+	if err != nil {
+		fail("AppendCBOR: %v", err)
+	}
+	vendorPd, _ := original.Vendor.ToPlutusData()
+	vendorBytes, _ := vendorPd.MarshalCBOR()
+	if !bytes.Equal(appended, append([]byte("prefix"), vendorBytes...)) {
+		fail("AppendCBOR did not append: %x", appended)
+	}
+
+	var decoded types.TypesVendorDatum
+	if err := decoded.UnmarshalCBOR(got); err != nil {
+		fail("UnmarshalCBOR: %v", err)
+	}
+	if !decoded.Equals(original) {
+		fail("decoded datum differs from original")
+	}
+
+	// Nil enum fields are reported with their path
+	_, err = types.TypesVendorDatum{}.MarshalCBOR()
+	if err == nil || err.Error() != "Vendor: value is nil (expected MultisigMultisigScript)" {
+		fail("expected nil field error, got %v", err)
+	}
+	_, err = types.MultisigMultisigScriptAllOf{Scripts: []types.MultisigMultisigScript{nil}}.MarshalCBOR()
+	if err == nil || err.Error() != "Scripts[0]: value is nil (expected MultisigMultisigScript)" {
+		fail("expected nil list item error, got %v", err)
+	}
+
+	fmt.Printf("✓ encoded %d bytes\n", len(got))
+}
+`
+	tmpDir := setupStreamingModule(t, GeneratorOptions{PackageName: "types"}, map[string]string{"main.go": testProgram})
+
+	cmd := exec.Command("go", "run", "main.go")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("test program failed: %v\n%s", err, output)
+	}
+	t.Logf("Test output:\n%s", output)
+}
+
+// TestNoPlutusDataFallback tests that every type generated from the
+// blueprints in testdata has a direct encoder and a streaming decoder, in
+// both modes, rather than going through ToPlutusData and FromPlutusData.
+func TestNoPlutusDataFallback(t *testing.T) {
+	paths, err := filepath.Glob("../../testdata/*/plutus.json")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no blueprints found: %v", err)
+	}
+	runtime := strings.Count(plutusDataSource, "ViaPlutusData(")
+	for _, path := range paths {
+		bp, err := LoadBlueprint(path)
+		if err != nil {
+			t.Fatalf("failed to load %s: %v", path, err)
+		}
+		for _, opts := range []GeneratorOptions{
+			{PackageName: "types"},
+			{PackageName: "types", Generics: true, UnknownVariants: true},
+		} {
+			code, _, err := NewGenerator(bp, opts).Generate()
+			if err != nil {
+				t.Fatalf("%s: failed to generate code: %v", path, err)
+			}
+			if n := strings.Count(code, "ViaPlutusData(") - runtime; n != 0 {
+				t.Errorf("%s (generics %v): %d generated methods fall back to PlutusData", path, opts.Generics, n)
+			}
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package blueprint
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"text/template"
+)
+
+// Every declaration and method of a generated file, except the embedded
+// PlutusData runtime, is written by executing one of the templates in
+// templates/. The templates can be overridden with
+// GeneratorOptions.TemplateFS: a file there replaces the built-in template
+// of the same name, so copying one from templates/ and editing it is the way
+// to start.
+//
+// Each template receives one of the data types below. Go code that depends
+// on the shape of a field (how an Int or a nested list is encoded, compared
+// or decoded) is passed as ready-made statements, indented for the function
+// body they appear in and ending with a newline, so templates insert it at
+// the start of a line: "{{.ToPlutusData}}".
+//
+// Those statements and expressions are written by the generator in Go, not
+// by templates, and so can't be overridden: the ToPlutusData,
+// FromPlutusData, Equals, Validate, CBORDecoder and CBOREncoder strings of
+// FieldData, OptionData, ListData and MapData, and MigrationData.Fields. A
+// template can move, wrap or drop them, but changing how a kind of field is
+// encoded, decoded, compared or validated takes a change to the generator.
+//
+//	file_header.go.tmpl           *Blueprint
+//	blueprint_info.go.tmpl        Preamble
+//	generics.go.tmpl              nil
+//	bool_type.go.tmpl             TypeData
+//	unit_type.go.tmpl             TypeData
+//	option_type.go.tmpl           OptionData
+//	struct_type.go.tmpl           StructData
+//	tuple_type.go.tmpl            StructData
+//	generic_type.go.tmpl          StructData
+//	list_type.go.tmpl             ListData
+//	map_type.go.tmpl              MapData
+//	enum_type.go.tmpl             EnumData
+//	enum_variant.go.tmpl          StructData
+//	enum_variant_wrapper.go.tmpl  StructData
+//	enum_variant_empty.go.tmpl    StructData
+//	enum_variant_unknown.go.tmpl  StructData
+//	const_enum_type.go.tmpl       EnumData
+//	record_migration.go.tmpl      MigrationData
+//	enum_migration.go.tmpl        EnumMigrationData
+//	const_enum_migration.go.tmpl  EnumMigrationData
+//	type_extras.go.tmpl           TypeData
+//
+// type_extras.go.tmpl is empty by default. It is executed after each
+// generated type and enum variant, to add methods to all of them.
+//
+// variant_methods.go.tmpl writes the Index and VariantName methods of the
+// declared enum variants and is included by each enum_variant*.go.tmpl but
+// the unknown one.
+//
+// decode_cbor.go.tmpl and append_cbor.go.tmpl write the CBOR methods of
+// StructData types and are included by struct_type.go.tmpl,
+// tuple_type.go.tmpl, generic_type.go.tmpl (decoding only) and
+// enum_variant_wrapper.go.tmpl.
+//
+// constructor.go.tmpl and validate.go.tmpl write the NewX constructor and
+// the Validate method of StructData types, and are included by the same
+// templates.
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var defaultTemplates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+// loadTemplates returns the built-in templates, with the *.tmpl files at
+// the root of override replacing those of the same name.
+func loadTemplates(override fs.FS) (*template.Template, error) {
+	if override == nil {
+		return defaultTemplates, nil
+	}
+	tmpl, err := defaultTemplates.Clone()
+	if err != nil {
+		return nil, err
+	}
+	matches, err := fs.Glob(override, "*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("reading templates: %w", err)
+	}
+	if len(matches) == 0 {
+		return tmpl, nil
+	}
+	if tmpl, err = tmpl.ParseFS(override, matches...); err != nil {
+		return nil, fmt.Errorf("parsing templates: %w", err)
+	}
+	return tmpl, nil
+}
+
+// TypeData describes a generated type, for bool_type.go.tmpl,
+// unit_type.go.tmpl and type_extras.go.tmpl.
+type TypeData struct {
+	// Name is the Go type name.
+	Name string
+	// Receiver is the type of method receivers: Name, with its type
+	// parameters for a generic type (Pair[A, B]).
+	Receiver string
+	// Kind is "bool", "unit", "option", "record", "tuple", "generic",
+	// "list", "map", "enum", "const_enum" or "variant".
+	Kind string
+	// EnumName is the enum of a variant.
+	EnumName string
+}
+
+// FieldData is a field of a StructData.
+type FieldData struct {
+	// Name is the Go field name.
+	Name string
+	// Title is the Aiken field name, empty for positional fields.
+	Title string
+	// GoType is the Go type of the field.
+	GoType string
+	// Index is the position of the field.
+	Index int
+	// ToPlutusData are the statements encoding the field into fields[Index]
+	// (items[Index] for a tuple), returning on errors. For
+	// enum_variant_wrapper.go.tmpl they encode the whole variant and return.
+	ToPlutusData string
+	// FromPlutusData are the statements decoding the field from
+	// pd.Constr.Fields[Index] (pd.List[Index] for a tuple).
+	FromPlutusData string
+	// Equals are the statements returning false if v and other differ in
+	// the field. For enum_variant_wrapper.go.tmpl they return the result of
+	// the comparison.
+	Equals string
+	// CBORDecoder is a func(*plutusCBORDecoder, *GoType) error expression,
+	// empty if the field can't be streamed.
+	CBORDecoder string
+	// CBOREncoder is a func([]byte, GoType) ([]byte, error) expression,
+	// empty if the field can't be encoded directly.
+	CBOREncoder string
+	// Validate are the statements returning an error if the field holds a
+	// value ToPlutusData can't encode, empty if it can't hold any.
+	Validate string
+	// Param is the parameter of the field in the NewX constructor.
+	Param string
+}
+
+// StructData describes a record, a tuple, a generic record type or an enum
+// variant.
+type StructData struct {
+	TypeData
+	// TypeParams declares the type parameters of a generic type
+	// (A PlutusCodec, B PlutusCodec).
+	TypeParams string
+	// Title is the Aiken type name, empty for an anonymous type.
+	Title string
+	// ConstrIndex is the constructor index, -1 for a tuple.
+	ConstrIndex int
+	// MethodName is the marker method of the enum of a variant, and
+	// ShortName the variant's name within it (Active for
+	// TypesPayoutStatusActive).
+	MethodName string
+	ShortName  string
+	Fields     []*FieldData
+	// Streamable tells whether every field has a CBORDecoder, and
+	// Appendable whether every field has a CBOREncoder. The CBOR methods
+	// go through PlutusData otherwise.
+	Streamable bool
+	Appendable bool
+	// DeclaredIndexes are, for enum_variant_unknown.go.tmpl, the
+	// constructor indexes of the declared variants, which an unknown
+	// variant can't hold.
+	DeclaredIndexes []int
+}
+
+// OptionData describes an Option type.
+type OptionData struct {
+	TypeData
+	// InnerType is the Go type of Value.
+	InnerType string
+	// ToPlutusDataInner are the statements returning the encoding of a set
+	// option, FromPlutusDataInner those decoding Value from
+	// pd.Constr.Fields[0], and EqualsInner those returning whether two set
+	// options are equal.
+	ToPlutusDataInner   string
+	FromPlutusDataInner string
+	EqualsInner         string
+	// ValidateInner is an error expression validating the Value of a set
+	// option, empty if every value can be encoded.
+	ValidateInner string
+	// CBORDecoder and CBOREncoder stream Value, as in FieldData.
+	CBORDecoder string
+	CBOREncoder string
+}
+
+// ListData describes a named list type.
+type ListData struct {
+	TypeData
+	// ItemType is the Go type of the items.
+	ItemType string
+	// ItemToPlutusData are the statements encoding item into items[i],
+	// ItemFromPlutusData those decoding item into (*v)[i], and ItemEquals
+	// those returning false if v[i] and other[i] differ.
+	ItemToPlutusData   string
+	ItemFromPlutusData string
+	ItemEquals         string
+	// Validate is an error expression validating the items of v, empty if
+	// every item can be encoded.
+	Validate string
+	// CBORDecoder and CBOREncoder stream an item, as in FieldData.
+	CBORDecoder string
+	CBOREncoder string
+}
+
+// MapData describes a named map type.
+type MapData struct {
+	TypeData
+	// KeyType and ValueType are the Go types of keys and values.
+	KeyType   string
+	ValueType string
+	// ToPlutusData is an expression of (PlutusData, error) encoding v, and
+	// FromPlutusData an expression of error decoding pd into v.
+	ToPlutusData   string
+	FromPlutusData string
+	// Validate is an error expression validating the keys and values of v,
+	// empty if every entry can be encoded.
+	Validate string
+	// The CBOR decoders and encoders of keys and values, as in FieldData.
+	KeyCBORDecoder   string
+	ValueCBORDecoder string
+	KeyCBOREncoder   string
+	ValueCBOREncoder string
+}
+
+// EnumData describes an enum type.
+type EnumData struct {
+	TypeData
+	// MethodName is the marker method of the interface.
+	MethodName string
+	// Variants are the variant types, or for const_enum_type.go.tmpl the
+	// constants, which have no Fields.
+	Variants []*StructData
+	// UnknownVariant is the variant constructors of undeclared indexes
+	// decode to, nil unless GeneratorOptions.UnknownVariants is set.
+	UnknownVariant *StructData
+	// Iota tells whether the constructor indexes are 0, 1, 2... in order,
+	// for const_enum_type.go.tmpl.
+	Iota bool
+}
+
+// MigrationData describes the FromOld method of a record or enum variant.
+type MigrationData struct {
+	// Name and OldName are the Go types migrated to and from.
+	Name    string
+	OldName string
+	// Problems lists why the migration always fails, empty if it doesn't.
+	Problems string
+	// NeedsErr tells whether a field migration assigns to err.
+	NeedsErr bool
+	// Fields are the statements migrating each field.
+	Fields []string
+}
+
+// EnumMigrationData describes the NameFromOldName function of an enum.
+type EnumMigrationData struct {
+	Name     string
+	OldName  string
+	Variants []*VariantMigrationData
+}
+
+// VariantMigrationData is a variant of an EnumMigrationData.
+type VariantMigrationData struct {
+	// Name is the Go identifier of the variant title.
+	Name string
+	// Old is the Go type of the old variant, and New that of the new one,
+	// empty if the new enum has no such variant.
+	Old string
+	New string
+}
+
+// executeTemplate writes the template name, executed with data.
+func (g *Generator) executeTemplate(name string, data interface{}) error {
+	if err := g.templates.ExecuteTemplate(&g.buf, name, data); err != nil {
+		return fmt.Errorf("executing template %s: %w", name, err)
+	}
+	return nil
+}
+
+// writeTemplate writes the template name followed by a blank line.
+func (g *Generator) writeTemplate(name string, data interface{}) error {
+	if err := g.executeTemplate(name, data); err != nil {
+		return err
+	}
+	g.writeLine("")
+	return nil
+}
+
+// writeExtras writes type_extras.go.tmpl for a generated type, followed by
+// a blank line unless it is empty.
+func (g *Generator) writeExtras(data TypeData) error {
+	start := g.buf.Len()
+	if err := g.executeTemplate("type_extras.go.tmpl", data); err != nil {
+		return err
+	}
+	if g.buf.Len() > start {
+		g.writeLine("")
+	}
+	return nil
+}
+
+// capture returns what write writes at the given indentation, instead of
+// writing it.
+func (g *Generator) capture(indent int, write func()) string {
+	start, saved := g.buf.Len(), g.indent
+	g.indent = indent
+	write()
+	code := g.buf.String()[start:]
+	g.buf.Truncate(start)
+	g.indent = saved
+	return code
+}
@@ -0,0 +1,121 @@
+package blueprint
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// TestTemplateOverrides tests that templates given in TemplateFS replace
+// the built-in ones, and that type_extras.go.tmpl adds methods to every
+// generated type.
+func TestTemplateOverrides(t *testing.T) {
+	templates := fstest.MapFS{
+		"file_header.go.tmpl": {Data: []byte("// Code generated by aiken2go. DO NOT EDIT.\n// Team header for {{.Preamble.Title}}\n")},
+		"type_extras.go.tmpl": {Data: []byte(`{{- if ne .Kind "enum" -}}
+func (v {{.Receiver}}) TypeName() string {
+	return {{template "kind" .}} + " {{.Name}}"
+}
+{{end -}}
+`)},
+		"helpers.tmpl": {Data: []byte(`{{define "kind"}}"{{.Kind}}"{{end}}`)},
+	}
+
+	testProgram := `package main
+
+import (
+	"fmt"
+	"os"
+
+	"testpkg/types"
+)
+
+func main() {
+	for _, v := range []interface{ TypeName() string }{
+		types.NestedTorture{},
+		types.NestedActionMint{},
+		types.NestedActionBurn{},
+		types.NestedActionNoop{},
+		types.NestedActionUnknown{},
+		types.OptionInt{},
+		types.TupleIntListByteArray{},
+		types.NestedMatrix{},
+		types.NestedTortureIndexValueItemMap{},
+	} {
+		fmt.Println(v.TypeName())
+	}
+	if got := (types.NestedActionNoop{}).TypeName(); got != "variant NestedActionNoop" {
+		fmt.Fprintf(os.Stderr, "unexpected TypeName: %s\n", got)
+		os.Exit(1)
+	}
+}
+`
+	opts := GeneratorOptions{PackageName: "types", UnknownVariants: true, TemplateFS: templates}
+	tmpDir := setupTypesModule(t, "../../testdata/nested/plutus.json", opts, map[string]string{"main.go": testProgram})
+
+	cmd := exec.Command("go", "run", "main.go")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("test program failed: %v\n%s", err, output)
+	}
+	for _, want := range []string{"record NestedTorture", "variant NestedActionBurn", "option OptionInt", "tuple TupleIntListByteArray", "list NestedMatrix", "map NestedTortureIndexValueItemMap"} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	bp, err := LoadBlueprint("../../testdata/nested/plutus.json")
+	if err != nil {
+		t.Fatalf("failed to load blueprint: %v", err)
+	}
+	code, _, err := NewGenerator(bp, opts).Generate()
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+	if !strings.Contains(code, "// Team header for "+bp.Preamble.Title+"\n") || strings.Contains(code, "// Source:") {
+		t.Error("file_header.go.tmpl was not overridden")
+	}
+}
+
+// TestTemplateDefaults tests that an override without templates generates
+// the same code as the built-in templates.
+func TestTemplateDefaults(t *testing.T) {
+	bp, err := LoadBlueprint("../../testdata/complex/plutus.json")
+	if err != nil {
+		t.Fatalf("failed to load blueprint: %v", err)
+	}
+	want, _, err := NewGenerator(bp, GeneratorOptions{}).Generate()
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+	got, _, err := NewGenerator(bp, GeneratorOptions{TemplateFS: fstest.MapFS{"README.md": {}}}).Generate()
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+	if got != want {
+		t.Error("an empty template override changed the generated code")
+	}
+}
+
+// TestTemplateErrors tests that broken templates fail generation.
+func TestTemplateErrors(t *testing.T) {
+	bp, err := LoadBlueprint("../../testdata/complex/plutus.json")
+	if err != nil {
+		t.Fatalf("failed to load blueprint: %v", err)
+	}
+	tests := []struct {
+		file, data, want string
+	}{
+		{"struct_type.go.tmpl", "type {{.Name", "parsing templates"},
+		{"struct_type.go.tmpl", "type {{.Nmae}} struct{}\n", "executing template struct_type.go.tmpl"},
+	}
+	for _, tt := range tests {
+		templates := fstest.MapFS{tt.file: {Data: []byte(tt.data)}}
+		_, _, err := NewGenerator(bp, GeneratorOptions{TemplateFS: templates}).Generate()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%q: got error %v, want %q", tt.data, err, tt.want)
+		}
+	}
+}
@@ -60,7 +60,7 @@ func TestTupleFieldNamesFromRefs(t *testing.T) {
 	bp := loadBlueprintFromJSON(t, blueprintJSON)
 
 	gen := NewGenerator(bp, GeneratorOptions{PackageName: "test"})
-	code, err := gen.Generate()
+	code, _, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("failed to generate code: %v", err)
 	}
@@ -124,7 +124,7 @@ func TestTupleFieldNamesDuplicates(t *testing.T) {
 	bp := loadBlueprintFromJSON(t, blueprintJSON)
 
 	gen := NewGenerator(bp, GeneratorOptions{PackageName: "test"})
-	code, err := gen.Generate()
+	code, _, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("failed to generate code: %v", err)
 	}
@@ -181,7 +181,7 @@ func TestTupleFieldNamesFallback(t *testing.T) {
 	bp := loadBlueprintFromJSON(t, blueprintJSON)
 
 	gen := NewGenerator(bp, GeneratorOptions{PackageName: "test"})
-	code, err := gen.Generate()
+	code, _, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("failed to generate code: %v", err)
 	}
@@ -226,7 +226,7 @@ func TestTupleFieldNamesNestedPath(t *testing.T) {
 	bp := loadBlueprintFromJSON(t, blueprintJSON)
 
 	gen := NewGenerator(bp, GeneratorOptions{PackageName: "test"})
-	code, err := gen.Generate()
+	code, _, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("failed to generate code: %v", err)
 	}
@@ -295,7 +295,7 @@ func TestTupleFieldNamesCompiles(t *testing.T) {
 	bp := loadBlueprintFromJSON(t, blueprintJSON)
 
 	gen := NewGenerator(bp, GeneratorOptions{PackageName: "tupletest"})
-	code, err := gen.Generate()
+	code, _, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("failed to generate code: %v", err)
 	}
@@ -308,10 +308,6 @@ func TestTupleFieldNamesCompiles(t *testing.T) {
 	goMod := `module testmod
 
 go 1.21
-
-require github.com/fxamacker/cbor/v2 v2.8.0
-
-require github.com/x448/float16 v0.8.4 // indirect
 `
 	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644); err != nil {
 		t.Fatalf("failed to write go.mod: %v", err)
@@ -381,7 +377,7 @@ func TestTupleFieldNamesRoundTrip(t *testing.T) {
 	bp := loadBlueprintFromJSON(t, blueprintJSON)
 
 	gen := NewGenerator(bp, GeneratorOptions{PackageName: "types"})
-	code, err := gen.Generate()
+	code, _, err := gen.Generate()
 	if err != nil {
 		t.Fatalf("failed to generate code: %v", err)
 	}
@@ -394,6 +390,7 @@ func TestTupleFieldNamesRoundTrip(t *testing.T) {
 	testProgram := `package main
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 	"os"
@@ -402,7 +399,8 @@ import (
 )
 
 func testRoundTrip[T interface {
-	ToPlutusData() (types.PlutusData, error)
+	types.PlutusMarshaler
+	types.CBORAppender
 }](name string, original T, decode func(types.PlutusData) (T, error)) error {
 	pd, err := original.ToPlutusData()
 	if err != nil {
@@ -414,6 +412,15 @@ func testRoundTrip[T interface {
 		return fmt.Errorf("%s MarshalCBOR: %v", name, err)
 	}
 
+	// The direct encoder must write the same bytes
+	direct, err := original.AppendCBOR(nil)
+	if err != nil {
+		return fmt.Errorf("%s AppendCBOR: %v", name, err)
+	}
+	if !bytes.Equal(direct, cborBytes) {
+		return fmt.Errorf("%s AppendCBOR: got %x, want %x", name, direct, cborBytes)
+	}
+
 	var decodedPd types.PlutusData
 	if err := decodedPd.UnmarshalCBOR(cborBytes); err != nil {
 		return fmt.Errorf("%s UnmarshalCBOR: %v", name, err)
@@ -472,10 +479,6 @@ func main() {
 	goModContent := `module testpkg
 
 go 1.21
-
-require github.com/fxamacker/cbor/v2 v2.8.0
-
-require github.com/x448/float16 v0.8.4 // indirect
 `
 	goModFile := filepath.Join(tmpDir, "go.mod")
 	if err := os.WriteFile(goModFile, []byte(goModContent), 0644); err != nil {
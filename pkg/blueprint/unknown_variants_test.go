@@ -0,0 +1,184 @@
+package blueprint
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestUnknownVariantsDisabledByDefault tests that enums reject undeclared
+// constructors unless GeneratorOptions.UnknownVariants is set.
+func TestUnknownVariantsDisabledByDefault(t *testing.T) {
+	bp, err := LoadBlueprint("../../testdata/complex/plutus.json")
+	if err != nil {
+		t.Fatalf("failed to load blueprint: %v", err)
+	}
+
+	code, _, err := NewGenerator(bp, GeneratorOptions{PackageName: "types"}).Generate()
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+	if strings.Contains(code, "type TypesPayoutStatusUnknown struct") {
+		t.Error("unknown variant generated without UnknownVariants")
+	}
+
+	code, _, err = NewGenerator(bp, GeneratorOptions{PackageName: "types", UnknownVariants: true}).Generate()
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+	for _, want := range []string{
+		"type TypesPayoutStatusUnknown struct",
+		"type MultisigMultisigScriptUnknown struct",
+		"var v TypesPayoutStatusUnknown",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("expected generated code to contain %q", want)
+		}
+	}
+}
+
+// TestUnknownVariantsRoundTrip tests that undeclared constructors decode into
+// the Unknown variant on both decoding paths and re-encode unchanged.
+func TestUnknownVariantsRoundTrip(t *testing.T) {
+	testProgram := `package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"os"
+
+	"testpkg/types"
+)
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
+
+func main() {
+	pd, err := types.VendorDatum(2).ToPlutusData()
+	if err != nil {
+		fail("ToPlutusData: %v", err)
+	}
+	// A newer contract adds a Payout status with fields and a multisig
+	// script with a constructor index past the compact tag range
+	pd.Constr.Fields[1].List[1].Constr.Fields[2] = types.NewConstrPlutusData(5,
+		types.NewIntPlutusData(big.NewInt(7)), types.NewBytesPlutusData([]byte{0xee}))
+	pd.Constr.Fields[0].Constr.Fields[1].List[0] = types.NewConstrPlutusData(9)
+	data, err := pd.MarshalCBOR()
+	if err != nil {
+		fail("MarshalCBOR: %v", err)
+	}
+
+	var viaPlutusData types.TypesVendorDatum
+	if err := viaPlutusData.FromPlutusData(pd); err != nil {
+		fail("FromPlutusData: %v", err)
+	}
+	var streamed types.TypesVendorDatum
+	if err := streamed.UnmarshalCBOR(data); err != nil {
+		fail("UnmarshalCBOR: %v", err)
+	}
+
+	for name, datum := range map[string]types.TypesVendorDatum{"FromPlutusData": viaPlutusData, "UnmarshalCBOR": streamed} {
+		status, ok := datum.Payouts[1].Status.(types.TypesPayoutStatusUnknown)
+		if !ok {
+			fail("%s: expected TypesPayoutStatusUnknown, got %T", name, datum.Payouts[1].Status)
+		}
+		if status.Constructor != 5 || len(status.Fields) != 2 || status.Fields[0].Integer.Int64() != 7 {
+			fail("%s: unexpected unknown status %+v", name, status)
+		}
+		if _, ok := datum.Payouts[0].Status.(types.TypesPayoutStatusActive); !ok {
+			fail("%s: known variant decoded as %T", name, datum.Payouts[0].Status)
+		}
+		script, ok := datum.Vendor.(types.MultisigMultisigScriptAtLeast).Scripts[0].(types.MultisigMultisigScriptUnknown)
+		if !ok || script.Constructor != 9 {
+			fail("%s: unexpected unknown script %+v", name, script)
+		}
+
+		for _, v := range []types.PlutusMarshaler{status, script} {
+			want, err := unknownPD(v).MarshalCBOR()
+			if err != nil {
+				fail("%s: MarshalCBOR: %v", name, err)
+			}
+			got, err := v.(interface{ MarshalCBOR() ([]byte, error) }).MarshalCBOR()
+			if err != nil || !bytes.Equal(got, want) {
+				fail("%s: %T re-encodes to %x, want %x (%v)", name, v, got, want, err)
+			}
+		}
+	}
+
+	reencoded, err := streamed.MarshalCBOR()
+	if err != nil {
+		fail("MarshalCBOR: %v", err)
+	}
+	if !bytes.Equal(reencoded, data) {
+		fail("re-encoding differs\ngot  %x\nwant %x", reencoded, data)
+	}
+
+	if !viaPlutusData.Payouts[1].Status.(types.TypesPayoutStatusUnknown).Equals(streamed.Payouts[1].Status.(types.TypesPayoutStatusUnknown)) {
+		fail("decoding paths disagree")
+	}
+
+	// An Unknown variant can't hold the index of a declared one, which
+	// would decode as that variant
+	if err := viaPlutusData.Payouts[1].Status.(types.TypesPayoutStatusUnknown).Validate(); err != nil {
+		fail("Validate: %v", err)
+	}
+	declared := types.TypesPayoutStatusUnknown{Constructor: 0}
+	want := "Constructor: constructor 0 is a declared variant of TypesPayoutStatus"
+	if err := declared.Validate(); err == nil || err.Error() != want {
+		fail("Validate: got error %v, want %q", err, want)
+	}
+	if _, err := declared.ToPlutusData(); err == nil {
+		fail("ToPlutusData encoded a declared index")
+	}
+	if _, err := declared.MarshalCBOR(); err == nil {
+		fail("MarshalCBOR encoded a declared index")
+	}
+
+	// Every field, however deep, must be set
+	unset := types.TypesPayoutStatusUnknown{Constructor: 5, Fields: []types.PlutusData{
+		types.NewIntPlutusData(big.NewInt(1)),
+		types.NewListPlutusData(types.NewBytesPlutusData(nil), types.PlutusData{}),
+	}}
+	want = "Fields[1][1]: value is the zero PlutusData"
+	if err := unset.Validate(); err == nil || err.Error() != want {
+		fail("Validate: got error %v, want %q", err, want)
+	}
+	if _, err := unset.MarshalCBOR(); err == nil {
+		fail("MarshalCBOR encoded a zero PlutusData field")
+	}
+
+	// The decoded fields don't share the PlutusData slice
+	var copied types.TypesPayoutStatusUnknown
+	fields := []types.PlutusData{types.NewIntPlutusData(big.NewInt(1))}
+	if err := copied.FromPlutusData(types.NewConstrPlutusData(5, fields...)); err != nil {
+		fail("FromPlutusData: %v", err)
+	}
+	fields[0] = types.NewBytesPlutusData(nil)
+	if copied.Fields[0].Kind() != types.KindInteger {
+		fail("FromPlutusData kept the decoded fields slice")
+	}
+
+	fmt.Println("✓ unknown variants round-trip")
+}
+
+func unknownPD(v types.PlutusMarshaler) types.PlutusData {
+	pd, err := v.ToPlutusData()
+	if err != nil {
+		fail("ToPlutusData: %v", err)
+	}
+	return pd
+}
+`
+	tmpDir := setupStreamingModule(t, GeneratorOptions{PackageName: "types", UnknownVariants: true}, map[string]string{"main.go": testProgram})
+
+	cmd := exec.Command("go", "run", "main.go")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("test program failed: %v\n%s", err, output)
+	}
+	t.Logf("Test output:\n%s", output)
+}
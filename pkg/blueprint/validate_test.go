@@ -0,0 +1,204 @@
+package blueprint
+
+import (
+	"os/exec"
+	"testing"
+)
+
+// TestValidate tests that Validate and the NewX constructors report nil
+// *big.Int, enum and list values as a *ValidationError with their path, and
+// that encoding fails on the first two instead of writing 0. Nil lists
+// still encode as empty ones, and nil maps are valid.
+func TestValidate(t *testing.T) {
+	testProgram := `package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"testpkg/types"
+)
+
+func expectError(what string, err error, wantPath, want string) {
+	var ve *types.ValidationError
+	if !errors.As(err, &ve) || ve.Path != wantPath || err.Error() != wantPath+": "+want {
+		fmt.Fprintf(os.Stderr, "%s: got error %v, want %q at %q\n", what, err, want, wantPath)
+		os.Exit(1)
+	}
+}
+
+func main() {
+	payout, err := types.NewTypesPayout(big.NewInt(1), nil, types.TypesPayoutStatusActive{})
+	if err != nil || !payout.Equals(types.TypesPayout{Maturation: big.NewInt(1), Status: types.TypesPayoutStatusActive{}}) {
+		fmt.Fprintf(os.Stderr, "NewTypesPayout: %+v %v\n", payout, err)
+		os.Exit(1)
+	}
+
+	_, err = types.NewTypesPayout(big.NewInt(1), map[string]map[string]*big.Int{"p": {"a": nil}}, types.TypesPayoutStatusActive{})
+	expectError("NewTypesPayout", err, "Value[70].Value[61].Value", "value is nil (expected *big.Int)")
+	_, err = types.NewTypesPayout(big.NewInt(1), nil, nil)
+	expectError("NewTypesPayout", err, "Status", "value is nil (expected TypesPayoutStatus)")
+	_, err = types.NewMultisigMultisigScriptBefore(nil)
+	expectError("NewMultisigMultisigScriptBefore", err, "Time", "value is nil (expected *big.Int)")
+
+	signature := types.MultisigMultisigScriptSignature{KeyHash: []byte{1}}
+	config := types.TypesTreasuryConfiguration{
+		Permissions: types.TypesTreasuryPermissions{
+			Reorganize: types.MultisigMultisigScriptAllOf{Scripts: []types.MultisigMultisigScript{
+				signature,
+				types.MultisigMultisigScriptAtLeast{Scripts: []types.MultisigMultisigScript{signature}},
+			}},
+			Sweep:    signature,
+			Fund:     signature,
+			Disburse: signature,
+		},
+		Expiration:       big.NewInt(10),
+		PayoutUpperbound: big.NewInt(20),
+	}
+	expectError("Validate", config.Validate(), "Permissions.Reorganize.Scripts[1].Required", "value is nil (expected *big.Int)")
+	_, err = config.ToPlutusData()
+	expectError("ToPlutusData", err, "Permissions.Reorganize.Scripts[1].Required", "value is nil (expected *big.Int)")
+	_, err = config.MarshalCBOR()
+	expectError("MarshalCBOR", err, "Permissions.Reorganize.Scripts[1].Required", "value is nil (expected *big.Int)")
+
+	config.Permissions.Reorganize = signature
+	config.PayoutUpperbound = nil
+	expectError("Validate", config.Validate(), "PayoutUpperbound", "value is nil (expected *big.Int)")
+	_, err = config.ToPlutusData()
+	expectError("ToPlutusData", err, "PayoutUpperbound", "value is nil (expected *big.Int)")
+
+	config.PayoutUpperbound = big.NewInt(20)
+	if err := config.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Validate: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err := config.MarshalCBOR(); err != nil {
+		fmt.Fprintf(os.Stderr, "MarshalCBOR: %v\n", err)
+		os.Exit(1)
+	}
+
+	nilDatum := types.TypesVendorDatum{Vendor: types.MultisigMultisigScriptSignature{}, Payouts: []types.TypesPayout{
+		{Maturation: big.NewInt(1), Status: types.TypesPayoutStatusActive{}},
+	}}
+	emptyDatum := types.TypesVendorDatum{Vendor: types.MultisigMultisigScriptSignature{KeyHash: []byte{}}, Payouts: []types.TypesPayout{
+		{Maturation: big.NewInt(1), Value: map[string]map[string]*big.Int{}, Status: types.TypesPayoutStatusActive{}},
+	}}
+	if err := nilDatum.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Validate: %v\n", err)
+		os.Exit(1)
+	}
+	direct, err := nilDatum.MarshalCBOR()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "MarshalCBOR: %v\n", err)
+		os.Exit(1)
+	}
+	pd, err := nilDatum.ToPlutusData()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ToPlutusData: %v\n", err)
+		os.Exit(1)
+	}
+	viaPlutusData, _ := pd.MarshalCBOR()
+	want, _ := emptyDatum.MarshalCBOR()
+	if !bytes.Equal(direct, want) || !bytes.Equal(viaPlutusData, want) {
+		fmt.Fprintf(os.Stderr, "nil slices and maps encode as %x and %x, want %x\n", direct, viaPlutusData, want)
+		os.Exit(1)
+	}
+	var decoded types.TypesVendorDatum
+	if err := decoded.UnmarshalCBOR(direct); err != nil || !decoded.Equals(nilDatum) {
+		fmt.Fprintf(os.Stderr, "UnmarshalCBOR: %+v %v\n", decoded, err)
+		os.Exit(1)
+	}
+
+	// A nil list is unset, however deep, though it encodes as an empty one
+	_, err = types.NewTypesVendorDatum(nilDatum.Vendor, nil)
+	expectError("NewTypesVendorDatum", err, "Payouts", "value is nil (expected []TypesPayout)")
+	config.Permissions.Sweep = types.MultisigMultisigScriptAnyOf{Scripts: []types.MultisigMultisigScript{types.MultisigMultisigScriptAllOf{}}}
+	expectError("Validate", config.Validate(), "Permissions.Sweep.Scripts[0].Scripts", "value is nil (expected []MultisigMultisigScript)")
+	if _, err := config.MarshalCBOR(); err != nil {
+		fmt.Fprintf(os.Stderr, "MarshalCBOR: %v\n", err)
+		os.Exit(1)
+	}
+}
+`
+	opts := GeneratorOptions{PackageName: "types"}
+	tmpDir := setupTypesModule(t, "../../testdata/complex/plutus.json", opts, map[string]string{"main.go": testProgram})
+
+	cmd := exec.Command("go", "run", "main.go")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("test program failed: %v\n%s", err, output)
+	}
+}
+
+// TestValidateGenerics tests Validate and the NewX constructors of generic
+// types, whose type parameters are checked at run time.
+func TestValidateGenerics(t *testing.T) {
+	testProgram := `package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"testpkg/types"
+)
+
+func main() {
+	if _, err := types.NewTypesWrapper(types.Int{Int: big.NewInt(1)}, big.NewInt(0)); err != nil {
+		fmt.Fprintf(os.Stderr, "NewTypesWrapper: %v\n", err)
+		os.Exit(1)
+	}
+	_, err := types.NewTypesWrapper(types.Int{}, big.NewInt(0))
+	if err == nil || err.Error() != "Inner: value is nil (expected *big.Int)" {
+		fmt.Fprintf(os.Stderr, "NewTypesWrapper: got error %v\n", err)
+		os.Exit(1)
+	}
+
+	datum := types.TypesDatum{
+		Signers:    types.List[types.ByteArray]{},
+		History:    types.List[types.TypesAction]{},
+		Limits:     types.List[types.Option[types.Int]]{types.Some(types.Int{Int: big.NewInt(1)}), types.Some(types.Int{})},
+		Counter:    types.TypesWrapper[types.Int]{Inner: types.Int{Int: big.NewInt(1)}, Version: big.NewInt(0)},
+		Label:      types.TypesWrapper[types.ByteArray]{Version: big.NewInt(0)},
+		LastAction: types.TypesWrapper[types.TypesAction]{Inner: types.TypesActionBurn{}, Version: big.NewInt(0)},
+	}
+	err = datum.Validate()
+	var ve *types.ValidationError
+	if !errors.As(err, &ve) || ve.Path != "Limits[1].Some" || err.Error() != "Limits[1].Some: value is nil (expected *big.Int)" {
+		fmt.Fprintf(os.Stderr, "Validate: got error %v\n", err)
+		os.Exit(1)
+	}
+	datum.Limits = nil
+	datum.LastAction.Inner = nil
+	err = datum.Validate()
+	if err == nil || err.Error() != "Limits: value is nil (expected List[Option[Int]])" {
+		fmt.Fprintf(os.Stderr, "Validate: got error %v\n", err)
+		os.Exit(1)
+	}
+	datum.Limits = types.List[types.Option[types.Int]]{}
+	err = datum.Validate()
+	if err == nil || err.Error() != "LastAction.Inner: value is nil (expected TypesAction)" {
+		fmt.Fprintf(os.Stderr, "Validate: got error %v\n", err)
+		os.Exit(1)
+	}
+	if _, err := datum.ToPlutusData(); err == nil || err.Error() != "LastAction.Inner: value is nil (expected TypesAction)" {
+		fmt.Fprintf(os.Stderr, "ToPlutusData: got error %v\n", err)
+		os.Exit(1)
+	}
+}
+`
+	opts := GeneratorOptions{PackageName: "types", Generics: true}
+	tmpDir := setupTypesModule(t, "../../testdata/generics/plutus.json", opts, map[string]string{"main.go": testProgram})
+
+	cmd := exec.Command("go", "run", "main.go")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("test program failed: %v\n%s", err, output)
+	}
+}
@@ -0,0 +1,224 @@
+// Package ir is a typed model of the types and validators of a Plutus
+// blueprint, for code generators and other emitters to consume instead of
+// analysing raw blueprint schemas.
+//
+// blueprint.Resolve builds a Module from a blueprint. Each definition
+// resolves to a Type:
+//
+//	integer, bytes, False/True, Void    Primitive (Int, ByteArray, Bool, Void)
+//	Data                                *Opaque
+//	a record (single constructor)       *Record
+//	several constructors                *Enum, of *Variant
+//	List<T>, Option<T>, Pairs<K, V>     *List, *Option, *Pairs
+//	a tuple                             *Tuple
+//	a name for another type (PolicyId)  *Alias
+//
+// References between definitions are pointers to the same value, so types
+// may be recursive: a Record can reach itself through its fields.
+package ir
+
+import "sort"
+
+// Type is a resolved blueprint type. It is one of Primitive, *Opaque,
+// *Record, *Enum, *List, *Option, *Pairs, *Tuple or *Alias.
+type Type interface {
+	isType()
+}
+
+// Primitive is a builtin type.
+type Primitive int
+
+const (
+	Int Primitive = iota
+	ByteArray
+	Bool
+	Void
+)
+
+func (p Primitive) String() string {
+	switch p {
+	case Int:
+		return "Int"
+	case ByteArray:
+		return "ByteArray"
+	case Bool:
+		return "Bool"
+	case Void:
+		return "Void"
+	default:
+		return "Primitive(?)"
+	}
+}
+
+// Opaque is any Plutus data: Data, a schema of a data type the blueprint
+// format doesn't define, or a reference to a missing definition.
+type Opaque struct {
+	// Name is the definition name, empty for an inline schema.
+	Name string
+}
+
+// Record is a type with a single constructor.
+type Record struct {
+	// Name is the definition name, empty for an inline schema.
+	Name        string
+	Title       string
+	Description string
+	// Index is the constructor index.
+	Index  int
+	Fields []*Field
+}
+
+// Enum is a type with several constructors.
+type Enum struct {
+	// Name is the definition name, empty for an inline schema.
+	Name        string
+	Title       string
+	Description string
+	Variants    []*Variant
+}
+
+// Variant is a constructor of an Enum.
+type Variant struct {
+	Title       string
+	Description string
+	// Index is the constructor index.
+	Index  int
+	Fields []*Field
+}
+
+// Field is a field of a Record or a Variant.
+type Field struct {
+	// Title is the field name, empty for a positional field.
+	Title       string
+	Description string
+	Type        Type
+}
+
+// List is a homogeneous list.
+type List struct {
+	// Name is the definition name, e.g. "List$Int", empty for an inline
+	// schema.
+	Name string
+	Elem Type
+}
+
+// Option is Aiken's Option: constructor 0 (Some) with one field, or
+// constructor 1 (None).
+type Option struct {
+	// Name is the definition name, e.g. "Option$Int", empty for an inline
+	// schema.
+	Name string
+	Elem Type
+}
+
+// Pairs is a map, encoded as Plutus map entries.
+type Pairs struct {
+	// Name is the definition name, empty for an inline schema.
+	Name       string
+	Key, Value Type
+}
+
+// Tuple is a list of a fixed number of items of fixed types.
+type Tuple struct {
+	// Name is the definition name, empty for an inline schema.
+	Name  string
+	Items []Type
+}
+
+// Alias is a definition naming another type, e.g. PolicyId for ByteArray.
+// A boolean or unit definition other than Bool and Void is an Alias too.
+type Alias struct {
+	Name        string
+	Title       string
+	Description string
+	Target      Type
+}
+
+func (Primitive) isType() {}
+func (*Opaque) isType()   {}
+func (*Record) isType()   {}
+func (*Enum) isType()     {}
+func (*List) isType()     {}
+func (*Option) isType()   {}
+func (*Pairs) isType()    {}
+func (*Tuple) isType()    {}
+func (*Alias) isType()    {}
+
+// Underlying returns the type t names, following aliases.
+func Underlying(t Type) Type {
+	for {
+		alias, ok := t.(*Alias)
+		if !ok || alias.Target == nil {
+			return t
+		}
+		t = alias.Target
+	}
+}
+
+// Definition is a named type of the blueprint.
+type Definition struct {
+	// Name is the definition name, e.g. "cardano/assets/PolicyId" or
+	// "List$Int".
+	Name string
+	Type Type
+}
+
+// Parameter is the datum, redeemer or a parameter of a validator.
+type Parameter struct {
+	Title string
+	Type  Type
+}
+
+// Validator is a compiled validator of the blueprint.
+type Validator struct {
+	Title string
+	// Datum is nil for validators without a datum.
+	Datum        *Parameter
+	Redeemer     *Parameter
+	Parameters   []*Parameter
+	CompiledCode string
+	Hash         string
+}
+
+// Preamble holds the metadata of the blueprint.
+type Preamble struct {
+	Title           string
+	Description     string
+	Version         string
+	PlutusVersion   string
+	CompilerName    string
+	CompilerVersion string
+	License         string
+}
+
+// Module is a resolved blueprint.
+type Module struct {
+	Preamble Preamble
+	// Definitions are sorted by name.
+	Definitions []*Definition
+	Validators  []*Validator
+
+	byName map[string]*Definition
+}
+
+// NewModule returns a module of the definitions, sorting them by name.
+func NewModule(preamble Preamble, definitions []*Definition, validators []*Validator) *Module {
+	m := &Module{
+		Preamble:    preamble,
+		Definitions: definitions,
+		Validators:  validators,
+		byName:      make(map[string]*Definition, len(definitions)),
+	}
+	sort.Slice(m.Definitions, func(i, j int) bool {
+		return m.Definitions[i].Name < m.Definitions[j].Name
+	})
+	for _, def := range definitions {
+		m.byName[def.Name] = def
+	}
+	return m
+}
+
+// Lookup returns the definition named name, or nil.
+func (m *Module) Lookup(name string) *Definition {
+	return m.byName[name]
+}
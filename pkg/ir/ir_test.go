@@ -0,0 +1,296 @@
+package ir_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pgrange/aiken_to_go/pkg/blueprint"
+	"github.com/pgrange/aiken_to_go/pkg/ir"
+)
+
+// resolveJSON resolves the blueprint of the given plutus.json content.
+func resolveJSON(t *testing.T, content string) *ir.Module {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "plutus.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	bp, err := blueprint.LoadBlueprint(path)
+	if err != nil {
+		t.Fatalf("failed to load blueprint: %v", err)
+	}
+	return blueprint.Resolve(bp)
+}
+
+// TestUnderlying checks that Underlying follows chains of aliases.
+func TestUnderlying(t *testing.T) {
+	policy := &ir.Alias{Name: "PolicyId", Target: ir.ByteArray}
+	currency := &ir.Alias{Name: "Currency", Target: policy}
+	if got := ir.Underlying(currency); got != ir.ByteArray {
+		t.Errorf("Underlying(Currency) = %v, want ByteArray", got)
+	}
+	unresolved := &ir.Alias{Name: "Pending"}
+	if got := ir.Underlying(unresolved); got != ir.Type(unresolved) {
+		t.Errorf("Underlying of an alias without target = %#v, want the alias", got)
+	}
+	record := &ir.Record{Name: "Datum"}
+	if got := ir.Underlying(record); got != ir.Type(record) {
+		t.Errorf("Underlying(Datum) = %#v, want the record", got)
+	}
+}
+
+// TestNewModule checks that definitions are sorted and found by name.
+func TestNewModule(t *testing.T) {
+	datum := &ir.Record{Name: "types/Datum"}
+	defs := []*ir.Definition{
+		{Name: "types/Datum", Type: datum},
+		{Name: "Int", Type: ir.Int},
+		{Name: "List$Int", Type: &ir.List{Elem: ir.Int}},
+	}
+	m := ir.NewModule(ir.Preamble{Title: "test/module"}, defs, nil)
+
+	for i, want := range []string{"Int", "List$Int", "types/Datum"} {
+		if m.Definitions[i].Name != want {
+			t.Errorf("definition %d: got %q, want %q", i, m.Definitions[i].Name, want)
+		}
+	}
+	if def := m.Lookup("types/Datum"); def == nil || def.Type != ir.Type(datum) {
+		t.Errorf("Lookup(types/Datum) = %#v", def)
+	}
+	if def := m.Lookup("types/Missing"); def != nil {
+		t.Errorf("Lookup(types/Missing) = %#v, want nil", def)
+	}
+	if got := ir.Bool.String(); got != "Bool" {
+		t.Errorf("Bool.String() = %q", got)
+	}
+}
+
+// TestResolve_Refs checks that references resolve to the definition they
+// name, through aliases and escaped names.
+func TestResolve_Refs(t *testing.T) {
+	m := resolveJSON(t, `{
+  "preamble": {"title": "test/refs"},
+  "validators": [],
+  "definitions": {
+    "ByteArray": {"dataType": "bytes"},
+    "Int": {"dataType": "integer"},
+    "types/Hash": {"title": "Hash", "dataType": "bytes"},
+    "types/KeyHash": {"title": "KeyHash", "$ref": "#/definitions/types~1Hash"},
+    "types/Owner": {
+      "title": "Owner",
+      "anyOf": [{
+        "title": "Owner",
+        "dataType": "constructor",
+        "index": 0,
+        "fields": [
+          {"title": "key", "$ref": "#/definitions/types~1KeyHash"},
+          {"title": "amount", "$ref": "#/definitions/Int"}
+        ]
+      }]
+    }
+  }
+}`)
+
+	hash, ok := m.Lookup("types/Hash").Type.(*ir.Alias)
+	if !ok || hash.Target != ir.ByteArray {
+		t.Fatalf("Hash: got %#v, want an alias of ByteArray", m.Lookup("types/Hash").Type)
+	}
+	key, ok := m.Lookup("types/KeyHash").Type.(*ir.Alias)
+	if !ok || key.Title != "KeyHash" || key.Target != ir.Type(hash) {
+		t.Fatalf("KeyHash: got %#v, want an alias of Hash", m.Lookup("types/KeyHash").Type)
+	}
+	if ir.Underlying(key) != ir.ByteArray {
+		t.Errorf("Underlying(KeyHash) = %v, want ByteArray", ir.Underlying(key))
+	}
+	owner, ok := m.Lookup("types/Owner").Type.(*ir.Record)
+	if !ok || len(owner.Fields) != 2 {
+		t.Fatalf("Owner: got %#v, want a record of 2 fields", m.Lookup("types/Owner").Type)
+	}
+	if owner.Fields[0].Type != ir.Type(key) || owner.Fields[1].Type != ir.Int {
+		t.Errorf("Owner fields: got %#v and %#v", owner.Fields[0].Type, owner.Fields[1].Type)
+	}
+}
+
+// TestResolve_Generics checks the instances of generic types, which are
+// definitions of their own.
+func TestResolve_Generics(t *testing.T) {
+	bp, err := blueprint.LoadBlueprint("../../testdata/generics/plutus.json")
+	if err != nil {
+		t.Fatalf("failed to load blueprint: %v", err)
+	}
+	m := blueprint.Resolve(bp)
+
+	action := m.Lookup("types/Action").Type
+	if _, ok := action.(*ir.Enum); !ok {
+		t.Fatalf("Action: got %T, want *ir.Enum", action)
+	}
+	for _, name := range []string{"types/Wrapper$Int", "types/Wrapper$ByteArray", "types/Wrapper$types/Action"} {
+		wrapper, ok := m.Lookup(name).Type.(*ir.Record)
+		if !ok || wrapper.Title != "Wrapper" || len(wrapper.Fields) != 2 || wrapper.Fields[1].Type != ir.Int {
+			t.Errorf("%s: got %#v, want a Wrapper record", name, m.Lookup(name).Type)
+		}
+	}
+	if inner := m.Lookup("types/Wrapper$types/Action").Type.(*ir.Record).Fields[0].Type; inner != action {
+		t.Errorf("Wrapper<Action>.inner: got %#v, want Action", inner)
+	}
+
+	limits, ok := m.Lookup("List$Option$Int").Type.(*ir.List)
+	if !ok {
+		t.Fatalf("List<Option<Int>>: got %T", m.Lookup("List$Option$Int").Type)
+	}
+	if option, ok := limits.Elem.(*ir.Option); !ok || option.Elem != ir.Int || limits.Elem != m.Lookup("Option$Int").Type {
+		t.Errorf("List<Option<Int>>: got items %#v, want Option$Int", limits.Elem)
+	}
+	if option, ok := m.Lookup("Option$types/Action").Type.(*ir.Option); !ok || option.Elem != action {
+		t.Errorf("Option<Action>: got %#v", m.Lookup("Option$types/Action").Type)
+	}
+
+	value, ok := m.Lookup("Pairs$types/PolicyId_Pairs$ByteArray_Int").Type.(*ir.Pairs)
+	if !ok || value.Key != m.Lookup("types/PolicyId").Type || value.Value != m.Lookup("Pairs$ByteArray_Int").Type {
+		t.Errorf("Pairs<PolicyId, Pairs<ByteArray, Int>>: got %#v", m.Lookup("Pairs$types/PolicyId_Pairs$ByteArray_Int").Type)
+	}
+}
+
+// TestResolve_Unresolvable checks that schemas without a known shape and
+// references to missing definitions resolve to opaque data.
+func TestResolve_Unresolvable(t *testing.T) {
+	m := resolveJSON(t, `{
+  "preamble": {"title": "test/unresolvable"},
+  "validators": [],
+  "definitions": {
+    "Label": {"title": "Label", "dataType": "#string"},
+    "Datum": {
+      "title": "Datum",
+      "anyOf": [{
+        "title": "Datum",
+        "dataType": "constructor",
+        "index": 0,
+        "fields": [
+          {"title": "owner", "$ref": "#/definitions/types~1Owner"},
+          {"title": "tags", "dataType": "list", "items": {"dataType": "#string"}},
+          {"title": "any", "dataType": "list"},
+          {"title": "pairs", "$ref": "#/definitions/Pairs$Broken"}
+        ]
+      }]
+    }
+  }
+}`)
+
+	if label, ok := m.Lookup("Label").Type.(*ir.Opaque); !ok || label.Name != "Label" {
+		t.Errorf("Label: got %#v, want an opaque Label", m.Lookup("Label").Type)
+	}
+	datum, ok := m.Lookup("Datum").Type.(*ir.Record)
+	if !ok || len(datum.Fields) != 4 {
+		t.Fatalf("Datum: got %#v, want a record of 4 fields", m.Lookup("Datum").Type)
+	}
+	if owner, ok := datum.Fields[0].Type.(*ir.Opaque); !ok || owner.Name != "types/Owner" {
+		t.Errorf("owner: got %#v, want an opaque types/Owner", datum.Fields[0].Type)
+	}
+	if tags, ok := datum.Fields[1].Type.(*ir.List); !ok {
+		t.Errorf("tags: got %#v, want a list", datum.Fields[1].Type)
+	} else if item, ok := tags.Elem.(*ir.Opaque); !ok || item.Name != "" {
+		t.Errorf("tags: got items %#v, want anonymous opaque data", tags.Elem)
+	}
+	if list, ok := datum.Fields[2].Type.(*ir.List); !ok {
+		t.Errorf("any: got %#v, want a list", datum.Fields[2].Type)
+	} else if _, ok := list.Elem.(*ir.Opaque); !ok {
+		t.Errorf("any: got items %#v, want opaque data", list.Elem)
+	}
+	if pairs, ok := datum.Fields[3].Type.(*ir.Pairs); !ok || pairs.Key != ir.ByteArray {
+		t.Errorf("pairs: got %#v, want pairs keyed by ByteArray", datum.Fields[3].Type)
+	} else if _, ok := pairs.Value.(*ir.Opaque); !ok {
+		t.Errorf("pairs: got values %#v, want opaque data", pairs.Value)
+	}
+	if m.Lookup("types/Owner") != nil {
+		t.Error("missing definitions should not become definitions")
+	}
+}
+
+// TestResolve_EnumShapes checks how definitions with constructors resolve:
+// records, enums with declared or positional indexes, and the Bool, Void
+// and Option shapes under other names.
+func TestResolve_EnumShapes(t *testing.T) {
+	m := resolveJSON(t, `{
+  "preamble": {"title": "test/enums"},
+  "validators": [],
+  "definitions": {
+    "Int": {"dataType": "integer"},
+    "types/Status": {
+      "title": "Status",
+      "anyOf": [
+        {"title": "Active", "dataType": "constructor", "index": 0, "fields": []},
+        {"title": "Paused", "dataType": "constructor", "index": 4, "fields": [{"$ref": "#/definitions/Int"}]},
+        {"title": "Closed", "dataType": "constructor", "index": 7, "fields": [{"title": "at", "$ref": "#/definitions/Int"}]}
+      ]
+    },
+    "types/Positional": {
+      "title": "Positional",
+      "anyOf": [
+        {"title": "First", "dataType": "constructor", "fields": []},
+        {"title": "Second", "dataType": "constructor", "fields": []}
+      ]
+    },
+    "types/Tagged": {
+      "title": "Tagged",
+      "anyOf": [{"title": "Tagged", "dataType": "constructor", "index": 2, "fields": [{"title": "n", "$ref": "#/definitions/Int"}]}]
+    },
+    "types/Flag": {
+      "title": "Flag",
+      "anyOf": [
+        {"title": "False", "dataType": "constructor", "index": 0, "fields": []},
+        {"title": "True", "dataType": "constructor", "index": 1, "fields": []}
+      ]
+    },
+    "types/Nothing": {
+      "title": "Nothing",
+      "anyOf": [{"title": "Nothing", "dataType": "constructor", "index": 0, "fields": []}]
+    },
+    "types/MaybeInt": {
+      "title": "Option",
+      "anyOf": [
+        {"title": "Some", "dataType": "constructor", "index": 0, "fields": [{"$ref": "#/definitions/Int"}]},
+        {"title": "None", "dataType": "constructor", "index": 1, "fields": []}
+      ]
+    }
+  }
+}`)
+
+	status, ok := m.Lookup("types/Status").Type.(*ir.Enum)
+	if !ok || status.Title != "Status" || len(status.Variants) != 3 {
+		t.Fatalf("Status: got %#v, want an enum of 3 variants", m.Lookup("types/Status").Type)
+	}
+	for i, want := range []struct {
+		title  string
+		index  int
+		fields int
+	}{{"Active", 0, 0}, {"Paused", 4, 1}, {"Closed", 7, 1}} {
+		v := status.Variants[i]
+		if v.Title != want.title || v.Index != want.index || len(v.Fields) != want.fields {
+			t.Errorf("Status variant %d: got %s at %d with %d fields", i, v.Title, v.Index, len(v.Fields))
+		}
+	}
+	if f := status.Variants[1].Fields[0]; f.Title != "" || f.Type != ir.Int {
+		t.Errorf("Paused field: got %#v, want a positional Int", f)
+	}
+	if f := status.Variants[2].Fields[0]; f.Title != "at" || f.Type != ir.Int {
+		t.Errorf("Closed field: got %#v, want at: Int", f)
+	}
+
+	positional, ok := m.Lookup("types/Positional").Type.(*ir.Enum)
+	if !ok || len(positional.Variants) != 2 || positional.Variants[0].Index != 0 || positional.Variants[1].Index != 1 {
+		t.Errorf("Positional: got %#v, want variants at 0 and 1", m.Lookup("types/Positional").Type)
+	}
+	if tagged, ok := m.Lookup("types/Tagged").Type.(*ir.Record); !ok || tagged.Index != 2 || len(tagged.Fields) != 1 {
+		t.Errorf("Tagged: got %#v, want a record at index 2", m.Lookup("types/Tagged").Type)
+	}
+	if flag, ok := m.Lookup("types/Flag").Type.(*ir.Alias); !ok || flag.Target != ir.Bool {
+		t.Errorf("Flag: got %#v, want an alias of Bool", m.Lookup("types/Flag").Type)
+	}
+	if nothing, ok := m.Lookup("types/Nothing").Type.(*ir.Alias); !ok || nothing.Target != ir.Void {
+		t.Errorf("Nothing: got %#v, want an alias of Void", m.Lookup("types/Nothing").Type)
+	}
+	if option, ok := m.Lookup("types/MaybeInt").Type.(*ir.Option); !ok || option.Elem != ir.Int {
+		t.Errorf("MaybeInt: got %#v, want an option of Int", m.Lookup("types/MaybeInt").Type)
+	}
+}
@@ -0,0 +1,690 @@
+// Package plutus converts hand-written Go types to and from PlutusData using
+// reflection and struct tags, in the spirit of encoding/json.
+//
+// Structs encode as constructors whose fields are the exported struct fields
+// in declaration order. Options are set with the `plutus` struct tag:
+//
+//	_      struct{}   `plutus:"constr=1"` // constructor index of the struct (default 0)
+//	_      struct{}   `plutus:"aslist"`   // encode the struct as a plain list (Aiken tuple)
+//	Amount *big.Int   `plutus:"field=2"`  // position of the field in the constructor
+//	Assets []Asset    `plutus:"asmap"`    // encode a slice of key/value structs as a map
+//	Cache  string     `plutus:"-"`        // skip the field
+//
+// Other types map as follows:
+//
+//	*big.Int, big.Int, int*, uint*  integer
+//	[]byte, [N]byte, string         bytes
+//	bool                            constructor 0 (False) or 1 (True)
+//	slices and arrays               list
+//	maps                            map, entries sorted by encoded key
+//	pointers                        Option: nil is None, non-nil is Some
+//	interfaces                      the dynamic value, or the value a pointer
+//	                                points to; decoding requires RegisterEnum
+//
+// Types implementing blueprint.PlutusMarshaler (and whose pointer implements
+// blueprint.PlutusUnmarshaler) encode and decode themselves, so
+// blueprint.PlutusData can be used for raw Data fields. So do the types
+// generated by aiken2go, whose ToPlutusData and FromPlutusData use the
+// PlutusData of their own package: they are converted through their
+// MarshalCBOR and UnmarshalCBOR methods.
+package plutus
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pgrange/aiken_to_go/pkg/blueprint"
+)
+
+// PlutusData is the PlutusData type from the blueprint runtime.
+type PlutusData = blueprint.PlutusData
+
+var (
+	bigIntType      = reflect.TypeOf(big.Int{})
+	bigIntPtrType   = reflect.TypeOf((*big.Int)(nil))
+	marshalerType   = reflect.TypeOf((*blueprint.PlutusMarshaler)(nil)).Elem()
+	unmarshalerType = reflect.TypeOf((*blueprint.PlutusUnmarshaler)(nil)).Elem()
+
+	cborMarshalerType   = reflect.TypeOf((*cborMarshaler)(nil)).Elem()
+	cborUnmarshalerType = reflect.TypeOf((*cborUnmarshaler)(nil)).Elem()
+)
+
+// cborMarshaler and cborUnmarshaler are the CBOR methods of generated types.
+type cborMarshaler interface {
+	MarshalCBOR() ([]byte, error)
+}
+
+type cborUnmarshaler interface {
+	UnmarshalCBOR([]byte) error
+}
+
+// isMarshaler reports whether values of t encode themselves, either as a
+// blueprint.PlutusMarshaler or as a generated type. A pointer to such a
+// value is an Option instead.
+func isMarshaler(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer && isMarshaler(t.Elem()) {
+		return false
+	}
+	if t.Implements(marshalerType) {
+		return true
+	}
+	_, generated := t.MethodByName("ToPlutusData")
+	return generated && t.Implements(cborMarshalerType)
+}
+
+// isUnmarshaler reports whether values of t decode themselves, either as a
+// blueprint.PlutusUnmarshaler or as a generated type.
+func isUnmarshaler(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	if pt.Implements(unmarshalerType) {
+		return true
+	}
+	_, generated := pt.MethodByName("FromPlutusData")
+	return generated && pt.Implements(cborUnmarshalerType)
+}
+
+// Marshal returns the PlutusData encoding of v.
+func Marshal(v any) (PlutusData, error) {
+	if v == nil {
+		return PlutusData{}, errors.New("plutus: cannot marshal nil value")
+	}
+	pd, err := encodeValue(reflect.ValueOf(v), false)
+	if err != nil {
+		return PlutusData{}, fmt.Errorf("plutus: %w", err)
+	}
+	return pd, nil
+}
+
+// Unmarshal decodes pd into the value pointed to by v.
+func Unmarshal(pd PlutusData, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("plutus: Unmarshal requires a non-nil pointer, got %T", v)
+	}
+	if err := decodeValue(pd, rv.Elem(), false); err != nil {
+		return fmt.Errorf("plutus: %w", err)
+	}
+	return nil
+}
+
+// MarshalCBOR returns the CBOR encoding of v.
+func MarshalCBOR(v any) ([]byte, error) {
+	pd, err := Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return pd.MarshalCBOR()
+}
+
+// UnmarshalCBOR decodes CBOR bytes into the value pointed to by v.
+func UnmarshalCBOR(data []byte, v any) error {
+	var pd PlutusData
+	if err := pd.UnmarshalCBOR(data); err != nil {
+		return fmt.Errorf("plutus: %w", err)
+	}
+	return Unmarshal(pd, v)
+}
+
+var (
+	enumsMu sync.RWMutex
+	enums   = map[reflect.Type]map[uint64]reflect.Type{}
+)
+
+// RegisterEnum registers the variants of the enum interface T so that
+// Unmarshal can pick the variant matching a constructor index. Each variant
+// must be a struct type with a distinct `plutus:"constr=N"` index.
+func RegisterEnum[T any](variants ...T) {
+	iface := reflect.TypeOf((*T)(nil)).Elem()
+	if iface.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("plutus: RegisterEnum requires an interface type, got %s", iface))
+	}
+	byIndex := make(map[uint64]reflect.Type, len(variants))
+	for _, variant := range variants {
+		vt := reflect.TypeOf(variant)
+		if vt == nil || vt.Kind() != reflect.Struct {
+			panic(fmt.Sprintf("plutus: variant of %s must be a struct, got %v", iface, vt))
+		}
+		info, err := structInfoOf(vt)
+		if err != nil {
+			panic(fmt.Sprintf("plutus: %v", err))
+		}
+		if info.asList {
+			panic(fmt.Sprintf("plutus: variant %s of %s cannot be encoded as a list", vt, iface))
+		}
+		if prev, ok := byIndex[info.constr]; ok {
+			panic(fmt.Sprintf("plutus: variants %s and %s of %s share constructor index %d", prev, vt, iface, info.constr))
+		}
+		byIndex[info.constr] = vt
+	}
+	enumsMu.Lock()
+	enums[iface] = byIndex
+	enumsMu.Unlock()
+}
+
+func enumVariant(iface reflect.Type, index uint64) (reflect.Type, bool, error) {
+	enumsMu.RLock()
+	byIndex, registered := enums[iface]
+	enumsMu.RUnlock()
+	if !registered {
+		return nil, false, fmt.Errorf("%s is not a registered enum", iface)
+	}
+	vt, ok := byIndex[index]
+	return vt, ok, nil
+}
+
+// structInfo describes how a struct type is encoded.
+type structInfo struct {
+	constr uint64
+	asList bool
+	fields []fieldInfo // in encoding order
+}
+
+type fieldInfo struct {
+	index int
+	name  string
+	asMap bool
+}
+
+var structInfoCache sync.Map // reflect.Type -> *structInfo
+
+func structInfoOf(t reflect.Type) (*structInfo, error) {
+	if cached, ok := structInfoCache.Load(t); ok {
+		return cached.(*structInfo), nil
+	}
+
+	info := &structInfo{}
+	positions := map[int]fieldInfo{}
+	next := 0
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("plutus")
+		if f.Name == "_" {
+			if err := parseStructTag(t, tag, info); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+
+		field := fieldInfo{index: i, name: f.Name}
+		pos := next
+		for _, opt := range splitTag(tag) {
+			switch {
+			case opt == "asmap":
+				field.asMap = true
+			case strings.HasPrefix(opt, "field="):
+				n, err := strconv.Atoi(strings.TrimPrefix(opt, "field="))
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf("%s.%s: invalid field position %q", t, f.Name, opt)
+				}
+				pos = n
+			default:
+				return nil, fmt.Errorf("%s.%s: unknown plutus tag option %q", t, f.Name, opt)
+			}
+		}
+		if prev, ok := positions[pos]; ok {
+			return nil, fmt.Errorf("%s: fields %s and %s share position %d", t, prev.name, f.Name, pos)
+		}
+		positions[pos] = field
+		next = pos + 1
+	}
+
+	info.fields = make([]fieldInfo, len(positions))
+	for pos, field := range positions {
+		if pos >= len(positions) {
+			return nil, fmt.Errorf("%s.%s: field position %d leaves a gap (struct has %d fields)", t, field.name, pos, len(positions))
+		}
+		info.fields[pos] = field
+	}
+
+	cached, _ := structInfoCache.LoadOrStore(t, info)
+	return cached.(*structInfo), nil
+}
+
+func parseStructTag(t reflect.Type, tag string, info *structInfo) error {
+	for _, opt := range splitTag(tag) {
+		switch {
+		case opt == "aslist":
+			info.asList = true
+		case strings.HasPrefix(opt, "constr="):
+			n, err := strconv.ParseUint(strings.TrimPrefix(opt, "constr="), 10, 64)
+			if err != nil {
+				return fmt.Errorf("%s: invalid constructor index %q", t, opt)
+			}
+			info.constr = n
+		default:
+			return fmt.Errorf("%s: unknown plutus tag option %q", t, opt)
+		}
+	}
+	return nil
+}
+
+func splitTag(tag string) []string {
+	var opts []string
+	for _, opt := range strings.Split(tag, ",") {
+		if opt = strings.TrimSpace(opt); opt != "" {
+			opts = append(opts, opt)
+		}
+	}
+	return opts
+}
+
+func encodeValue(v reflect.Value, asMap bool) (PlutusData, error) {
+	t := v.Type()
+	switch {
+	case t == bigIntPtrType:
+		if v.IsNil() {
+			return PlutusData{}, errors.New("value is nil")
+		}
+		return blueprint.NewIntPlutusData(new(big.Int).Set(v.Interface().(*big.Int))), nil
+	case t == bigIntType:
+		i := v.Interface().(big.Int)
+		return blueprint.NewIntPlutusData(new(big.Int).Set(&i)), nil
+	case isMarshaler(t):
+		if m, ok := v.Interface().(blueprint.PlutusMarshaler); ok {
+			return m.ToPlutusData()
+		}
+		data, err := v.Interface().(cborMarshaler).MarshalCBOR()
+		if err != nil {
+			return PlutusData{}, err
+		}
+		var pd PlutusData
+		if err := pd.UnmarshalCBOR(data); err != nil {
+			return PlutusData{}, err
+		}
+		return pd, nil
+	case t.Kind() == reflect.Pointer:
+		if v.IsNil() {
+			return blueprint.NewConstrPlutusData(1), nil // None
+		}
+		inner, err := encodeValue(v.Elem(), asMap)
+		if err != nil {
+			return PlutusData{}, fmt.Errorf("Some: %w", err)
+		}
+		return blueprint.NewConstrPlutusData(0, inner), nil
+	case t.Kind() == reflect.Interface:
+		if v.IsNil() {
+			return PlutusData{}, errors.New("value is nil")
+		}
+		// An interface holds an enum variant, never an Option
+		elem := v.Elem()
+		if elem.Kind() == reflect.Pointer && elem.Type() != bigIntPtrType {
+			if elem.IsNil() {
+				return PlutusData{}, errors.New("value is nil")
+			}
+			elem = elem.Elem()
+		}
+		return encodeValue(elem, asMap)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return blueprint.NewConstrPlutusData(1), nil
+		}
+		return blueprint.NewConstrPlutusData(0), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return blueprint.NewIntPlutusData(big.NewInt(v.Int())), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return blueprint.NewIntPlutusData(new(big.Int).SetUint64(v.Uint())), nil
+	case reflect.String:
+		return blueprint.NewBytesPlutusData([]byte(v.String())), nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return blueprint.NewBytesPlutusData(b), nil
+		}
+		if asMap {
+			return encodePairs(v)
+		}
+		items := make([]PlutusData, v.Len())
+		for i := range items {
+			item, err := encodeValue(v.Index(i), false)
+			if err != nil {
+				return PlutusData{}, fmt.Errorf("item[%d]: %w", i, err)
+			}
+			items[i] = item
+		}
+		return blueprint.NewListPlutusData(items...), nil
+	case reflect.Map:
+		return encodeMap(v)
+	case reflect.Struct:
+		return encodeStruct(v)
+	default:
+		return PlutusData{}, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+func encodeStruct(v reflect.Value) (PlutusData, error) {
+	info, err := structInfoOf(v.Type())
+	if err != nil {
+		return PlutusData{}, err
+	}
+	fields := make([]PlutusData, len(info.fields))
+	for i, f := range info.fields {
+		pd, err := encodeValue(v.Field(f.index), f.asMap)
+		if err != nil {
+			return PlutusData{}, fmt.Errorf("field %s: %w", f.name, err)
+		}
+		fields[i] = pd
+	}
+	if info.asList {
+		return blueprint.NewListPlutusData(fields...), nil
+	}
+	return blueprint.NewConstrPlutusData(info.constr, fields...), nil
+}
+
+// encodePairs encodes a slice of two-field structs as an ordered map.
+func encodePairs(v reflect.Value) (PlutusData, error) {
+	info, err := pairInfoOf(v.Type().Elem())
+	if err != nil {
+		return PlutusData{}, err
+	}
+	entries := make([]blueprint.PlutusDataMapEntry, v.Len())
+	for i := range entries {
+		entry := v.Index(i)
+		keyPd, err := encodeValue(entry.Field(info.fields[0].index), info.fields[0].asMap)
+		if err != nil {
+			return PlutusData{}, fmt.Errorf("entry[%d] key: %w", i, err)
+		}
+		valPd, err := encodeValue(entry.Field(info.fields[1].index), info.fields[1].asMap)
+		if err != nil {
+			return PlutusData{}, fmt.Errorf("entry[%d] value: %w", i, err)
+		}
+		entries[i] = blueprint.PlutusDataMapEntry{Key: keyPd, Value: valPd}
+	}
+	return blueprint.NewMapPlutusData(entries...), nil
+}
+
+func pairInfoOf(t reflect.Type) (*structInfo, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("asmap requires a slice of structs, got []%s", t)
+	}
+	info, err := structInfoOf(t)
+	if err != nil {
+		return nil, err
+	}
+	if len(info.fields) != 2 {
+		return nil, fmt.Errorf("asmap requires a key and a value field, %s has %d fields", t, len(info.fields))
+	}
+	return info, nil
+}
+
+// encodeMap encodes a Go map. Entries are sorted by encoded key so that the
+// output is deterministic.
+func encodeMap(v reflect.Value) (PlutusData, error) {
+	type encodedEntry struct {
+		key   []byte
+		entry blueprint.PlutusDataMapEntry
+	}
+	encoded := make([]encodedEntry, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		keyPd, err := encodeValue(iter.Key(), false)
+		if err != nil {
+			return PlutusData{}, fmt.Errorf("key: %w", err)
+		}
+		valPd, err := encodeValue(iter.Value(), false)
+		if err != nil {
+			return PlutusData{}, fmt.Errorf("value: %w", err)
+		}
+		keyBytes, err := keyPd.MarshalCBOR()
+		if err != nil {
+			return PlutusData{}, fmt.Errorf("key: %w", err)
+		}
+		encoded = append(encoded, encodedEntry{key: keyBytes, entry: blueprint.PlutusDataMapEntry{Key: keyPd, Value: valPd}})
+	}
+	sort.Slice(encoded, func(i, j int) bool {
+		return bytes.Compare(encoded[i].key, encoded[j].key) < 0
+	})
+	entries := make([]blueprint.PlutusDataMapEntry, len(encoded))
+	for i, e := range encoded {
+		entries[i] = e.entry
+	}
+	return blueprint.NewMapPlutusData(entries...), nil
+}
+
+func decodeValue(pd PlutusData, v reflect.Value, asMap bool) error {
+	t := v.Type()
+	switch {
+	case t == bigIntPtrType:
+		i, ok := pd.AsInteger()
+		if !ok {
+			return fmt.Errorf("expected integer, got %s", kindOf(pd))
+		}
+		v.Set(reflect.ValueOf(new(big.Int).Set(i)))
+		return nil
+	case t == bigIntType:
+		i, ok := pd.AsInteger()
+		if !ok {
+			return fmt.Errorf("expected integer, got %s", kindOf(pd))
+		}
+		v.Addr().Interface().(*big.Int).Set(i)
+		return nil
+	case t.Kind() == reflect.Pointer:
+		if pd.Kind() != blueprint.KindConstr {
+			return fmt.Errorf("expected constructor for Option, got %s", kindOf(pd))
+		}
+		switch {
+		case pd.Constr.Index == 1 && len(pd.Constr.Fields) == 0: // None
+			v.Set(reflect.Zero(t))
+			return nil
+		case pd.Constr.Index == 0 && len(pd.Constr.Fields) == 1: // Some
+			elem := reflect.New(t.Elem())
+			if err := decodeValue(pd.Constr.Fields[0], elem.Elem(), asMap); err != nil {
+				return fmt.Errorf("Some: %w", err)
+			}
+			v.Set(elem)
+			return nil
+		default:
+			return fmt.Errorf("invalid Option: constructor %d with %d fields", pd.Constr.Index, len(pd.Constr.Fields))
+		}
+	case isUnmarshaler(t):
+		if u, ok := v.Addr().Interface().(blueprint.PlutusUnmarshaler); ok {
+			return u.FromPlutusData(pd)
+		}
+		data, err := pd.MarshalCBOR()
+		if err != nil {
+			return err
+		}
+		return v.Addr().Interface().(cborUnmarshaler).UnmarshalCBOR(data)
+	case t.Kind() == reflect.Interface:
+		return decodeEnum(pd, v)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if pd.Kind() != blueprint.KindConstr || pd.Constr.Index > 1 || len(pd.Constr.Fields) != 0 {
+			return fmt.Errorf("expected Bool constructor, got %s", kindOf(pd))
+		}
+		v.SetBool(pd.Constr.Index == 1)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := pd.AsInteger()
+		if !ok {
+			return fmt.Errorf("expected integer, got %s", kindOf(pd))
+		}
+		if !i.IsInt64() || v.OverflowInt(i.Int64()) {
+			return fmt.Errorf("integer %s overflows %s", i, t)
+		}
+		v.SetInt(i.Int64())
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i, ok := pd.AsInteger()
+		if !ok {
+			return fmt.Errorf("expected integer, got %s", kindOf(pd))
+		}
+		if !i.IsUint64() || v.OverflowUint(i.Uint64()) {
+			return fmt.Errorf("integer %s overflows %s", i, t)
+		}
+		v.SetUint(i.Uint64())
+		return nil
+	case reflect.String:
+		if pd.Kind() != blueprint.KindBytes {
+			return fmt.Errorf("expected bytes, got %s", kindOf(pd))
+		}
+		v.SetString(string(pd.ByteString))
+		return nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			if pd.Kind() != blueprint.KindBytes {
+				return fmt.Errorf("expected bytes, got %s", kindOf(pd))
+			}
+			b := reflect.MakeSlice(t, len(pd.ByteString), len(pd.ByteString))
+			reflect.Copy(b, reflect.ValueOf(pd.ByteString))
+			v.Set(b)
+			return nil
+		}
+		if asMap {
+			return decodePairs(pd, v)
+		}
+		if pd.Kind() != blueprint.KindList {
+			return fmt.Errorf("expected list, got %s", kindOf(pd))
+		}
+		items := reflect.MakeSlice(t, len(pd.List), len(pd.List))
+		for i, item := range pd.List {
+			if err := decodeValue(item, items.Index(i), false); err != nil {
+				return fmt.Errorf("item[%d]: %w", i, err)
+			}
+		}
+		v.Set(items)
+		return nil
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			if pd.Kind() != blueprint.KindBytes {
+				return fmt.Errorf("expected bytes, got %s", kindOf(pd))
+			}
+			if len(pd.ByteString) != t.Len() {
+				return fmt.Errorf("expected %d bytes, got %d", t.Len(), len(pd.ByteString))
+			}
+			reflect.Copy(v, reflect.ValueOf(pd.ByteString))
+			return nil
+		}
+		if pd.Kind() != blueprint.KindList {
+			return fmt.Errorf("expected list, got %s", kindOf(pd))
+		}
+		if len(pd.List) != t.Len() {
+			return fmt.Errorf("expected %d items, got %d", t.Len(), len(pd.List))
+		}
+		for i, item := range pd.List {
+			if err := decodeValue(item, v.Index(i), false); err != nil {
+				return fmt.Errorf("item[%d]: %w", i, err)
+			}
+		}
+		return nil
+	case reflect.Map:
+		if pd.Kind() != blueprint.KindMap {
+			return fmt.Errorf("expected map, got %s", kindOf(pd))
+		}
+		m := reflect.MakeMapWithSize(t, len(pd.Map))
+		for i, entry := range pd.Map {
+			key := reflect.New(t.Key()).Elem()
+			if err := decodeValue(entry.Key, key, false); err != nil {
+				return fmt.Errorf("entry[%d] key: %w", i, err)
+			}
+			val := reflect.New(t.Elem()).Elem()
+			if err := decodeValue(entry.Value, val, false); err != nil {
+				return fmt.Errorf("entry[%d] value: %w", i, err)
+			}
+			m.SetMapIndex(key, val)
+		}
+		v.Set(m)
+		return nil
+	case reflect.Struct:
+		return decodeStruct(pd, v)
+	default:
+		return fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+func decodeStruct(pd PlutusData, v reflect.Value) error {
+	t := v.Type()
+	info, err := structInfoOf(t)
+	if err != nil {
+		return err
+	}
+	var fields []PlutusData
+	if info.asList {
+		if pd.Kind() != blueprint.KindList {
+			return fmt.Errorf("expected list for %s, got %s", t, kindOf(pd))
+		}
+		fields = pd.List
+	} else {
+		if pd.Kind() != blueprint.KindConstr {
+			return fmt.Errorf("expected constructor for %s, got %s", t, kindOf(pd))
+		}
+		if pd.Constr.Index != info.constr {
+			return fmt.Errorf("wrong constructor index for %s: expected %d, got %d", t, info.constr, pd.Constr.Index)
+		}
+		fields = pd.Constr.Fields
+	}
+	if len(fields) != len(info.fields) {
+		return fmt.Errorf("wrong number of fields for %s: expected %d, got %d", t, len(info.fields), len(fields))
+	}
+	for i, f := range info.fields {
+		if err := decodeValue(fields[i], v.Field(f.index), f.asMap); err != nil {
+			return fmt.Errorf("field %s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+func decodePairs(pd PlutusData, v reflect.Value) error {
+	t := v.Type()
+	info, err := pairInfoOf(t.Elem())
+	if err != nil {
+		return err
+	}
+	if pd.Kind() != blueprint.KindMap {
+		return fmt.Errorf("expected map, got %s", kindOf(pd))
+	}
+	entries := reflect.MakeSlice(t, len(pd.Map), len(pd.Map))
+	for i, entry := range pd.Map {
+		e := entries.Index(i)
+		if err := decodeValue(entry.Key, e.Field(info.fields[0].index), info.fields[0].asMap); err != nil {
+			return fmt.Errorf("entry[%d] key: %w", i, err)
+		}
+		if err := decodeValue(entry.Value, e.Field(info.fields[1].index), info.fields[1].asMap); err != nil {
+			return fmt.Errorf("entry[%d] value: %w", i, err)
+		}
+	}
+	v.Set(entries)
+	return nil
+}
+
+func decodeEnum(pd PlutusData, v reflect.Value) error {
+	t := v.Type()
+	if pd.Kind() != blueprint.KindConstr {
+		return fmt.Errorf("expected constructor for %s, got %s", t, kindOf(pd))
+	}
+	vt, ok, err := enumVariant(t, pd.Constr.Index)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("unknown constructor index for %s: %d", t, pd.Constr.Index)
+	}
+	variant := reflect.New(vt).Elem()
+	if err := decodeStruct(pd, variant); err != nil {
+		return err
+	}
+	v.Set(variant)
+	return nil
+}
+
+func kindOf(pd PlutusData) string {
+	if pd.Kind() == blueprint.KindConstr {
+		return fmt.Sprintf("constructor(%d)", pd.Constr.Index)
+	}
+	return pd.Kind().String()
+}
@@ -0,0 +1,272 @@
+package plutus
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pgrange/aiken_to_go/pkg/blueprint"
+)
+
+type action interface{ isAction() }
+
+type actionMint struct {
+	_      struct{} `plutus:"constr=0"`
+	Amount *big.Int
+}
+
+type actionBurn struct {
+	_ struct{} `plutus:"constr=1"`
+}
+
+func (actionMint) isAction() {}
+func (actionBurn) isAction() {}
+
+func init() {
+	RegisterEnum[action](actionMint{}, actionBurn{})
+}
+
+type asset struct {
+	Name     []byte
+	Quantity int64
+}
+
+type point struct {
+	_ struct{} `plutus:"aslist"`
+	X int
+	Y int
+}
+
+type datum struct {
+	_        struct{} `plutus:"constr=2"`
+	Owner    []byte
+	Amount   *big.Int
+	Label    string
+	Active   bool
+	Deadline *uint64
+	Next     action
+	History  []action
+	Assets   []asset `plutus:"asmap"`
+	Point    point
+	Raw      blueprint.PlutusData
+	Hash     [4]byte
+	Cache    string `plutus:"-"`
+	private  int
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	deadline := uint64(1700000000)
+	original := datum{
+		Owner:    []byte{0xde, 0xad},
+		Amount:   big.NewInt(-5),
+		Label:    "hello",
+		Active:   true,
+		Deadline: &deadline,
+		Next:     actionMint{Amount: big.NewInt(7)},
+		History:  []action{actionBurn{}, actionMint{Amount: big.NewInt(1)}},
+		Assets:   []asset{{Name: []byte("a"), Quantity: 1}, {Name: []byte("b"), Quantity: 2}},
+		Point:    point{X: 1, Y: 2},
+		Raw:      blueprint.NewIntPlutusData(big.NewInt(9)),
+		Hash:     [4]byte{1, 2, 3, 4},
+		Cache:    "not encoded",
+	}
+
+	data, err := MarshalCBOR(original)
+	if err != nil {
+		t.Fatalf("MarshalCBOR failed: %v", err)
+	}
+
+	var decoded datum
+	if err := UnmarshalCBOR(data, &decoded); err != nil {
+		t.Fatalf("UnmarshalCBOR failed: %v", err)
+	}
+
+	original.Cache = ""
+	sortAssets(original.Assets)
+	sortAssets(decoded.Assets)
+	if !reflect.DeepEqual(stripRaw(original), stripRaw(decoded)) {
+		t.Errorf("decoded value differs:\noriginal: %+v\ndecoded:  %+v", original, decoded)
+	}
+	if !decoded.Raw.Equals(original.Raw) {
+		t.Errorf("Raw differs: %+v", decoded.Raw)
+	}
+}
+
+// sortAssets orders assets by name, so that an asmap is compared regardless
+// of the order its entries were decoded in.
+func sortAssets(assets []asset) {
+	sort.Slice(assets, func(i, j int) bool {
+		return bytes.Compare(assets[i].Name, assets[j].Name) < 0
+	})
+}
+
+// stripRaw clears the PlutusData field, whose nil/empty slices are not
+// comparable with reflect.DeepEqual.
+func stripRaw(d datum) datum {
+	d.Raw = blueprint.PlutusData{}
+	return d
+}
+
+func TestMarshalEncoding(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		hex   string
+	}{
+		{"constructor with index", actionMint{Amount: big.NewInt(42)}, "d8799f182aff"},
+		{"fieldless constructor", actionBurn{}, "d87a80"},
+		{"bool", true, "d87a80"},
+		{"none", (*int)(nil), "d87a80"},
+		{"some", ptr(3), "d8799f03ff"},
+		{"tuple", point{X: 1, Y: 2}, "9f0102ff"},
+		{"string as bytes", "ab", "426162"},
+		{"empty list", []int{}, "9fff"},
+		{"sorted map", map[string]int{"b": 2, "a": 1}, "bf416101416202ff"},
+		{"explicit positions", struct {
+			A int `plutus:"field=1"`
+			B int `plutus:"field=0"`
+		}{A: 1, B: 2}, "d8799f0201ff"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pd, err := Marshal(tt.value)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			got, err := pd.ToHex()
+			if err != nil {
+				t.Fatalf("ToHex failed: %v", err)
+			}
+			if got != tt.hex {
+				t.Errorf("expected %s, got %s", tt.hex, got)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		pd     blueprint.PlutusData
+		target any
+		errMsg string
+	}{
+		{"wrong constructor", blueprint.NewConstrPlutusData(1), new(actionMint), "wrong constructor index"},
+		{"unknown variant", blueprint.NewConstrPlutusData(5), new(action), "unknown constructor index"},
+		{"overflow", blueprint.NewIntPlutusData(big.NewInt(300)), new(uint8), "overflows"},
+		{"wrong kind", blueprint.NewBytesPlutusData([]byte{1}), new(int), "expected integer"},
+		{"nested field", blueprint.NewConstrPlutusData(0, blueprint.NewBytesPlutusData([]byte{1})), new(actionMint), "field Amount: expected integer"},
+		{"unregistered enum", blueprint.NewConstrPlutusData(0), new(interface{ Foo() }), "not a registered enum"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Unmarshal(tt.pd, tt.target)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing %q, got %q", tt.errMsg, err)
+			}
+		})
+	}
+
+	var d datum
+	if err := Unmarshal(blueprint.NewConstrPlutusData(0), d); err == nil {
+		t.Error("expected error for non-pointer target")
+	}
+}
+
+func TestMarshalNilPointerField(t *testing.T) {
+	_, err := Marshal(actionMint{})
+	if err == nil || !strings.Contains(err.Error(), "field Amount: value is nil") {
+		t.Errorf("expected nil field error, got %v", err)
+	}
+}
+
+func TestRegisterEnumDuplicateIndex(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for duplicate constructor index")
+		}
+	}()
+	RegisterEnum[action](actionMint{}, actionMint{})
+}
+
+// generatedInt mimics a type generated by aiken2go: its ToPlutusData and
+// FromPlutusData use a PlutusData type of its own package, so it only
+// interoperates through CBOR.
+type generatedInt struct {
+	Value int64
+}
+
+type generatedPlutusData struct{}
+
+func (generatedInt) ToPlutusData() (generatedPlutusData, error) {
+	return generatedPlutusData{}, nil
+}
+
+func (*generatedInt) FromPlutusData(generatedPlutusData) error {
+	return nil
+}
+
+func (v generatedInt) MarshalCBOR() ([]byte, error) {
+	return blueprint.NewConstrPlutusData(3, blueprint.NewIntPlutusData(big.NewInt(v.Value))).MarshalCBOR()
+}
+
+func (v *generatedInt) UnmarshalCBOR(data []byte) error {
+	var pd blueprint.PlutusData
+	if err := pd.UnmarshalCBOR(data); err != nil {
+		return err
+	}
+	v.Value = pd.Constr.Fields[0].Integer.Int64()
+	return nil
+}
+
+func TestMarshalSelfEncodingTypes(t *testing.T) {
+	type holder struct {
+		Next   action
+		Gen    generatedInt
+		MaybeG *generatedInt
+		Raw    *blueprint.PlutusData
+	}
+	raw := blueprint.NewIntPlutusData(big.NewInt(1))
+	original := holder{
+		Next:   &actionMint{Amount: big.NewInt(7)},
+		Gen:    generatedInt{Value: 5},
+		MaybeG: &generatedInt{Value: 6},
+		Raw:    &raw,
+	}
+	data, err := MarshalCBOR(original)
+	if err != nil {
+		t.Fatalf("MarshalCBOR failed: %v", err)
+	}
+	// A pointer in an interface encodes as the variant, not as Some, while
+	// pointer fields stay Options of self-encoding types
+	want := "d8799f" + "d8799f07ff" + "d87c9f05ff" + "d8799fd87c9f06ffff" + "d8799f01ff" + "ff"
+	if got := hex.EncodeToString(data); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	var decoded holder
+	if err := UnmarshalCBOR(data, &decoded); err != nil {
+		t.Fatalf("UnmarshalCBOR failed: %v", err)
+	}
+	if decoded.Gen.Value != 5 || decoded.MaybeG == nil || decoded.MaybeG.Value != 6 || !decoded.Raw.Equals(raw) {
+		t.Errorf("decoded value differs: %+v", decoded)
+	}
+	if next, ok := decoded.Next.(actionMint); !ok || next.Amount.Int64() != 7 {
+		t.Errorf("decoded Next differs: %+v", decoded.Next)
+	}
+
+	if _, err := Marshal(holder{Next: (*actionMint)(nil)}); err == nil || !strings.Contains(err.Error(), "field Next: value is nil") {
+		t.Errorf("expected nil variant error, got %v", err)
+	}
+}